@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/app"
+	"github.com/brandonli/lazyliner/internal/editor"
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new issue",
+	Long: `Create an issue from flags, for scripts, git hooks and CI. Teams, users,
+labels, projects and states are given by name, and the new issue's
+identifier and URL are printed.
+
+Without a title, or a team when there's no default, create opens the
+create form in the TUI instead if stdin is a terminal, filled in from the
+flags given.`,
+	Example: `  lazyliner create --title "Fix login redirect" --team ENG --priority high --label bug
+  git log -1 --format=%b | lazyliner create --title "Follow up" --description-file -`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCreate,
+}
+
+var (
+	createTitle           string
+	createDescription     string
+	createDescriptionFile string
+	createTeam            string
+	createProject         string
+	createAssignee        string
+	createPriority        string
+	createLabels          []string
+	createEstimate        int
+	createDue             string
+	createParent          string
+	createState           string
+)
+
+func init() {
+	flags := createCmd.Flags()
+	flags.StringVarP(&createTitle, "title", "t", "", "Issue title")
+	flags.StringVarP(&createDescription, "description", "d", "", "Description in markdown")
+	flags.StringVarP(&createDescriptionFile, "description-file", "F", "", `Read the description from a file, or "-" for stdin`)
+	flags.StringVar(&createTeam, "team", "", "Team key or name (default: the parent's team, defaults.team or the only team)")
+	flags.StringVar(&createProject, "project", "", "Project name")
+	flags.StringVarP(&createAssignee, "assignee", "a", "", `Assignee name, email or "me"`)
+	flags.StringVarP(&createPriority, "priority", "p", "", "Priority: none, urgent, high, medium, low or 0-4")
+	flags.StringArrayVarP(&createLabels, "label", "l", nil, "Label name (repeatable)")
+	flags.IntVarP(&createEstimate, "estimate", "e", 0, "Estimate in points")
+	flags.StringVar(&createDue, "due", "", "Due date: 2024-01-31, today, +3d or +2w")
+	flags.StringVar(&createParent, "parent", "", "Parent issue identifier, making this a sub-issue")
+	flags.StringVarP(&createState, "state", "s", "", "Workflow state name or type, e.g. Todo or started")
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	if err := requireAPIKey(); err != nil {
+		return err
+	}
+
+	input := linear.IssueCreateInput{
+		Title:       strings.TrimSpace(createTitle),
+		Description: createDescription,
+	}
+
+	// Check what can be checked before calling the API
+	if createDescriptionFile != "" {
+		description, err := readDescription(createDescriptionFile)
+		if err != nil {
+			return err
+		}
+		input.Description = description
+	}
+	if createPriority != "" {
+		priority, err := linear.ParsePriority(createPriority)
+		if err != nil {
+			return err
+		}
+		input.Priority = priority
+	}
+	if cmd.Flags().Changed("estimate") {
+		input.Estimate = &createEstimate
+	}
+	if createDue != "" {
+		due, err := parseDue(createDue)
+		if err != nil {
+			return err
+		}
+		input.DueDate = due
+	}
+
+	client := linear.NewClient(cfg.Linear.APIKey)
+	ctx := context.Background()
+	r := newResolver(ctx, client)
+
+	var parent *linear.Issue
+	if createParent != "" {
+		var err error
+		if parent, err = client.GetIssue(ctx, createParent); err != nil {
+			return fmt.Errorf("failed to fetch parent issue: %w", err)
+		}
+		input.ParentID = parent.ID
+	}
+
+	team, err := createTeamFor(r, parent)
+	if err != nil {
+		return err
+	}
+
+	var assignee *linear.User
+	if createAssignee != "" {
+		if assignee, err = r.user(createAssignee); err != nil {
+			return err
+		}
+		input.AssigneeID = assignee.ID
+	}
+	var project *linear.Project
+	if createProject != "" {
+		if project, err = r.project(createProject); err != nil {
+			return err
+		}
+		input.ProjectID = project.ID
+	}
+
+	// Labels and states belong to a team
+	labelNames := createLabels
+	if team != nil {
+		input.TeamID = team.ID
+		labelNames = nil
+		for _, name := range createLabels {
+			label, err := r.label(team.ID, name)
+			if err != nil {
+				return err
+			}
+			input.LabelIDs = append(input.LabelIDs, label.ID)
+			labelNames = append(labelNames, label.Name)
+		}
+		if createState != "" {
+			state, err := r.state(team.ID, createState)
+			if err != nil {
+				return err
+			}
+			input.StateID = state.ID
+		}
+	}
+
+	if input.Title == "" || team == nil {
+		if isTerminal(os.Stdin) {
+			return runCreateForm(cmd, input, team, assignee, project, parent, labelNames)
+		}
+		if input.Title == "" {
+			return errors.New("--title is required")
+		}
+		var keys []string
+		for _, t := range r.teams {
+			keys = append(keys, t.Key)
+		}
+		return fmt.Errorf("--team is required (one of: %s)", strings.Join(keys, ", "))
+	}
+
+	issue, err := client.CreateIssue(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create issue: %w", err)
+	}
+	if issue == nil {
+		return errors.New("failed to create issue")
+	}
+	fmt.Printf("%s %s\n", issue.Identifier, issue.URL)
+	return nil
+}
+
+// createTeamFor returns the team to create an issue in: the one given with
+// --team, else the parent's, else the default team. It returns nil if it
+// can't tell which.
+func createTeamFor(r *resolver, parent *linear.Issue) (*linear.Team, error) {
+	switch {
+	case createTeam != "":
+		return r.team(createTeam)
+	case parent != nil && parent.Team != nil:
+		return parent.Team, nil
+	default:
+		return r.defaultTeam()
+	}
+}
+
+// readDescription reads a description from a file, or from stdin for "-"
+func readDescription(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read description: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// isTerminal reports whether f is a terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// formlessFlags are the create flags with no field in the create form
+var formlessFlags = []string{"estimate", "due", "state"}
+
+// runCreateForm opens the TUI on the create form, filled in from the flags.
+// It fails on flags the form has no field for rather than dropping them.
+func runCreateForm(cmd *cobra.Command, input linear.IssueCreateInput, team *linear.Team, assignee *linear.User, project *linear.Project, parent *linear.Issue, labels []string) error {
+	var formless []string
+	for _, name := range formlessFlags {
+		if cmd.Flags().Changed(name) {
+			formless = append(formless, "--"+name)
+		}
+	}
+	if len(formless) > 0 {
+		return fmt.Errorf("%s can't be set in the create form; give --title and --team to create the issue directly",
+			strings.Join(formless, ", "))
+	}
+
+	fields := []editor.Field{{Key: "title", Value: input.Title}}
+	if team != nil {
+		fields = append(fields, editor.Field{Key: "team", Value: team.Key})
+	}
+	if createPriority != "" {
+		fields = append(fields, editor.Field{Key: "priority", Value: strings.ToLower(theme.PriorityLabel(input.Priority))})
+	}
+	if assignee != nil {
+		fields = append(fields, editor.Field{Key: "assignee", Value: assignee.Name})
+	}
+	if project != nil {
+		fields = append(fields, editor.Field{Key: "project", Value: project.Name})
+	}
+	if len(labels) > 0 {
+		fields = append(fields, editor.Field{Key: "labels", Value: strings.Join(labels, ", ")})
+	}
+	draft := editor.Format(fields, input.Description)
+
+	p := tea.NewProgram(
+		app.New(cfg).OpenCreateForm(draft, parent),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+	)
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running app: %w", err)
+	}
+	return nil
+}
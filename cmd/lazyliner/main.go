@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/brandonli/lazyliner/internal/app"
 	"github.com/brandonli/lazyliner/internal/config"
+	"github.com/brandonli/lazyliner/internal/git"
 	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/markdown"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/ui/views/filter"
 	"github.com/brandonli/lazyliner/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -38,24 +43,35 @@ var viewCmd = &cobra.Command{
 	RunE:  runView,
 }
 
-var createCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create a new issue",
-	RunE:  runCreate,
+var currentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Show the issue for the current git branch",
+	Long: `Show the issue for the current git branch, e.g. for a shell prompt.
+
+Exits with status 1 and prints nothing if the branch doesn't name an issue.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runCurrent,
 }
 
 var (
 	listLimit int
 	listMine  bool
+	listView  string
+
+	currentIDOnly bool
 )
 
 func init() {
 	listCmd.Flags().IntVarP(&listLimit, "limit", "n", 20, "Number of issues to display")
 	listCmd.Flags().BoolVarP(&listMine, "mine", "m", false, "Show only my issues")
+	listCmd.Flags().StringVar(&listView, "view", "", "Show the issues of a view from your config or a Linear custom view")
+	currentCmd.Flags().BoolVar(&currentIDOnly, "id", false, "Print only the identifier, without calling the API")
 
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(viewCmd)
 	rootCmd.AddCommand(createCmd)
+	rootCmd.AddCommand(currentCmd)
 }
 
 func main() {
@@ -111,7 +127,9 @@ func runList(cmd *cobra.Command, args []string) error {
 	var conn linear.IssueConnection
 	var err error
 
-	if listMine {
+	if listView != "" {
+		conn, err = listViewIssues(ctx, client, listView)
+	} else if listMine {
 		conn, err = client.GetMyIssues(ctx, listLimit, "")
 	} else {
 		conn, err = client.GetIssues(ctx, linear.IssueFilter{Limit: listLimit})
@@ -153,6 +171,51 @@ func runList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// listViewIssues returns the issues of the view called name: one defined in
+// config, or else one of the workspace's custom views in Linear
+func listViewIssues(ctx context.Context, client *linear.Client, name string) (linear.IssueConnection, error) {
+	var issueFilter linear.IssueFilter
+	if listMine {
+		issueFilter.AssigneeName = "me"
+	}
+
+	for _, view := range cfg.Views {
+		if !strings.EqualFold(view.Name, name) {
+			continue
+		}
+		f, err := filter.FromConfig(view.Filter, time.Now())
+		if err != nil {
+			return linear.IssueConnection{}, fmt.Errorf("view %q: %w", view.Name, err)
+		}
+		if listMine {
+			f.AssigneeName = "me"
+			f.NoAssignee = false
+		}
+		f.Limit = listLimit
+		return client.GetIssues(ctx, f)
+	}
+
+	views, err := client.GetCustomViews(ctx)
+	if err != nil {
+		return linear.IssueConnection{}, err
+	}
+	var names []string
+	for _, view := range views {
+		if strings.EqualFold(view.Name, name) {
+			issueFilter.Limit = listLimit
+			return client.GetCustomViewIssues(ctx, view.ID, issueFilter)
+		}
+		names = append(names, view.Name)
+	}
+	for _, view := range cfg.Views {
+		names = append(names, view.Name)
+	}
+	if len(names) == 0 {
+		return linear.IssueConnection{}, fmt.Errorf("no view named %q", name)
+	}
+	return linear.IssueConnection{}, fmt.Errorf("no view named %q (available: %s)", name, strings.Join(names, ", "))
+}
+
 func runView(cmd *cobra.Command, args []string) error {
 	if err := requireAPIKey(); err != nil {
 		return err
@@ -189,8 +252,8 @@ func runView(cmd *cobra.Command, args []string) error {
 
 	if issue.Description != "" {
 		fmt.Printf("│ Description:\n")
-		for _, line := range strings.Split(issue.Description, "\n") {
-			fmt.Printf("│   %s\n", util.Truncate(line, 56))
+		for _, line := range strings.Split(markdown.Render(issue.Description, 56), "\n") {
+			fmt.Printf("│   %s\n", line)
 		}
 	} else {
 		fmt.Printf("│ No description\n")
@@ -200,19 +263,61 @@ func runView(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runCreate(cmd *cobra.Command, args []string) error {
+// errNoIssue is returned by current when the branch doesn't name an issue.
+// It is not printed, so prompts can call current anywhere.
+var errNoIssue = errors.New("the current branch doesn't name an issue")
+
+// noIssue fails the command with errNoIssue, silently
+func noIssue(cmd *cobra.Command) error {
+	cmd.SilenceErrors = true
+	return errNoIssue
+}
+
+func runCurrent(cmd *cobra.Command, args []string) error {
+	branch, err := git.GetCurrentBranch()
+	if err != nil || branch == "HEAD" {
+		return noIssue(cmd)
+	}
+
+	if currentIDOnly {
+		identifier, ok := git.IssueIdentifier(branch, cfg.Git.BranchFormat, nil)
+		if !ok {
+			return noIssue(cmd)
+		}
+		fmt.Println(identifier)
+		return nil
+	}
+
 	if err := requireAPIKey(); err != nil {
 		return err
 	}
 
-	p := tea.NewProgram(
-		app.New(cfg),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
+	client := linear.NewClient(cfg.Linear.APIKey)
+	ctx := context.Background()
 
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("error running app: %w", err)
+	teams, err := client.GetTeams(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch teams: %w", err)
+	}
+	teamKeys := make([]string, len(teams))
+	for i, team := range teams {
+		teamKeys[i] = team.Key
+	}
+
+	identifier, ok := git.IssueIdentifier(branch, cfg.Git.BranchFormat, teamKeys)
+	if !ok {
+		return noIssue(cmd)
+	}
+
+	issue, err := client.GetIssue(ctx, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issue: %w", err)
+	}
+
+	status := "Unknown"
+	if issue.State != nil {
+		status = issue.State.Name
 	}
+	fmt.Printf("%s %s [%s]\n", issue.Identifier, util.Truncate(issue.Title, 50), status)
 	return nil
 }
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/views/filter"
+)
+
+// resolver turns the names given on the command line into Linear objects,
+// fetching each list once
+type resolver struct {
+	ctx    context.Context
+	client *linear.Client
+
+	viewer   *linear.Viewer
+	teams    []linear.Team
+	users    []linear.User
+	projects []linear.Project
+	labels   map[string][]linear.Label         // By team ID
+	states   map[string][]linear.WorkflowState // By team ID
+}
+
+func newResolver(ctx context.Context, client *linear.Client) *resolver {
+	return &resolver{
+		ctx:    ctx,
+		client: client,
+		labels: make(map[string][]linear.Label),
+		states: make(map[string][]linear.WorkflowState),
+	}
+}
+
+// notFound reports a name that matched nothing, listing the names that exist
+func notFound(kind, name string, available []string) error {
+	if len(available) == 0 {
+		return fmt.Errorf("no %s named %q", kind, name)
+	}
+	return fmt.Errorf("no %s named %q (available: %s)", kind, name, strings.Join(available, ", "))
+}
+
+// loadTeams fetches the teams unless they are already loaded
+func (r *resolver) loadTeams() error {
+	if r.teams != nil {
+		return nil
+	}
+	teams, err := r.client.GetTeams(r.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch teams: %w", err)
+	}
+	r.teams = teams
+	return nil
+}
+
+// team returns the team with the given key or name
+func (r *resolver) team(name string) (*linear.Team, error) {
+	if err := r.loadTeams(); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for i, team := range r.teams {
+		if strings.EqualFold(team.Key, name) || strings.EqualFold(team.Name, name) {
+			return &r.teams[i], nil
+		}
+		keys = append(keys, team.Key)
+	}
+	return nil, notFound("team", name, keys)
+}
+
+// defaultTeam returns the team issues go to when none is given: the
+// configured default team, or the only team there is. It returns nil if
+// neither settles it.
+func (r *resolver) defaultTeam() (*linear.Team, error) {
+	if cfg.Defaults.Team != "" {
+		return r.team(cfg.Defaults.Team)
+	}
+	if err := r.loadTeams(); err != nil {
+		return nil, err
+	}
+	if len(r.teams) == 1 {
+		return &r.teams[0], nil
+	}
+	return nil, nil
+}
+
+// user returns the user with the given name, display name or email, or the
+// viewer for "me"
+func (r *resolver) user(name string) (*linear.User, error) {
+	if strings.EqualFold(name, "me") {
+		if r.viewer == nil {
+			viewer, err := r.client.GetViewer(r.ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch viewer: %w", err)
+			}
+			r.viewer = viewer
+		}
+		return &linear.User{ID: r.viewer.ID, Name: r.viewer.Name, DisplayName: r.viewer.DisplayName, Email: r.viewer.Email}, nil
+	}
+
+	if r.users == nil {
+		users, err := r.client.GetUsers(r.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch users: %w", err)
+		}
+		r.users = users
+	}
+	if user := linear.FindUser(r.users, name); user != nil {
+		return user, nil
+	}
+	var names []string
+	for _, u := range r.users {
+		names = append(names, u.Name)
+	}
+	return nil, notFound("user", name, names)
+}
+
+// project returns the project with the given name
+func (r *resolver) project(name string) (*linear.Project, error) {
+	if r.projects == nil {
+		projects, err := r.client.GetProjects(r.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch projects: %w", err)
+		}
+		r.projects = projects
+	}
+
+	var names []string
+	for i, project := range r.projects {
+		if strings.EqualFold(project.Name, name) {
+			return &r.projects[i], nil
+		}
+		names = append(names, project.Name)
+	}
+	return nil, notFound("project", name, names)
+}
+
+// label returns the label of a team with the given name
+func (r *resolver) label(teamID, name string) (*linear.Label, error) {
+	if _, ok := r.labels[teamID]; !ok {
+		labels, err := r.client.GetLabels(r.ctx, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch labels: %w", err)
+		}
+		r.labels[teamID] = labels
+	}
+
+	if label := linear.FindLabel(r.labels[teamID], name); label != nil {
+		return label, nil
+	}
+	var names []string
+	for _, label := range r.labels[teamID] {
+		names = append(names, label.Name)
+	}
+	return nil, notFound("label", name, names)
+}
+
+// loadStates fetches a team's workflow states unless they are already loaded
+func (r *resolver) loadStates(teamID string) ([]linear.WorkflowState, error) {
+	if states, ok := r.states[teamID]; ok {
+		return states, nil
+	}
+	states, err := r.client.GetWorkflowStates(r.ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workflow states: %w", err)
+	}
+	// The API doesn't return states in workflow order, which picking a
+	// state by type relies on
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i].Position < states[j].Position
+	})
+	r.states[teamID] = states
+	return states, nil
+}
+
+// stateOfType returns a team's first workflow state of a type, such as
+// "completed", in workflow order, whatever the states are named
+func (r *resolver) stateOfType(teamID, stateType string) (*linear.WorkflowState, error) {
+	states, err := r.loadStates(teamID)
+	if err != nil {
+		return nil, err
+	}
+	for i, state := range states {
+		if strings.EqualFold(state.Type, stateType) {
+			return &states[i], nil
+		}
+	}
+	return nil, fmt.Errorf("team has no %s state", stateType)
+}
+
+// state returns the workflow state of a team with the given name, or the
+// team's first state of a type, such as "completed", in workflow order
+func (r *resolver) state(teamID, name string) (*linear.WorkflowState, error) {
+	states, err := r.loadStates(teamID)
+	if err != nil {
+		return nil, err
+	}
+	for i, state := range states {
+		if strings.EqualFold(state.Name, name) {
+			return &states[i], nil
+		}
+	}
+	var names []string
+	for i, state := range states {
+		if strings.EqualFold(state.Type, name) {
+			return &states[i], nil
+		}
+		names = append(names, state.Name)
+	}
+	return nil, notFound("state", name, names)
+}
+
+// parseDue parses a due date: a day such as 2024-01-31, today, or a
+// relative day such as +3d or +2w
+func parseDue(s string) (string, error) {
+	r, err := filter.ParseDateRange(s, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if r.To.IsZero() || (!r.From.IsZero() && !strings.HasPrefix(s, "+") && !r.From.Equal(r.To)) {
+		return "", fmt.Errorf("invalid due date %q (2024-01-31, today, +3d or +2w)", s)
+	}
+	return r.To.Format("2006-01-02"), nil
+}
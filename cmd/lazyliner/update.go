@@ -0,0 +1,360 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update <id>...",
+	Short: "Change fields of one or more issues",
+	Long: `Change fields of one or more issues, given by identifier. Only the fields
+whose flags are given change; labels are added with --label and removed
+with --remove-label, leaving the others.`,
+	Example: `  lazyliner update ENG-12 --priority urgent --label regression
+  lazyliner update ENG-12 ENG-13 --project "Q3 launch" --due +1w`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runUpdate,
+}
+
+var moveCmd = &cobra.Command{
+	Use:   "move <id>... <state>",
+	Short: "Move issues to a workflow state",
+	Long: `Move issues to a workflow state, given by name ("In Review") or by type
+(backlog, unstarted, started, completed, canceled). States are looked up in
+each issue's own team.`,
+	Example: `  lazyliner move ENG-12 "In Review"
+  lazyliner move ENG-12 ENG-13 started`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE:         runMove,
+}
+
+var assignCmd = &cobra.Command{
+	Use:   "assign <id>... <user>",
+	Short: "Assign issues to a user",
+	Long:  `Assign issues to a user, given by name, display name, email or "me". Use "none" to unassign them.`,
+	Example: `  lazyliner assign ENG-12 me
+  lazyliner assign ENG-12 ENG-13 jane@example.com`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE:         runAssign,
+}
+
+var closeCmd = &cobra.Command{
+	Use:   "close <id>...",
+	Short: "Complete, cancel or delete issues",
+	Long: `Move issues to their team's first completed state, or with --canceled to
+its first canceled state. --delete moves them to the trash instead.`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runClose,
+}
+
+var (
+	updateTitle           string
+	updateDescription     string
+	updateDescriptionFile string
+	updateAssignee        string
+	updatePriority        string
+	updateLabels          []string
+	updateRemoveLabels    []string
+	updateProject         string
+	updateEstimate        int
+	updateDue             string
+	updateParent          string
+	updateState           string
+
+	closeCanceled bool
+	closeDelete   bool
+)
+
+func init() {
+	flags := updateCmd.Flags()
+	flags.StringVarP(&updateTitle, "title", "t", "", "New title")
+	flags.StringVarP(&updateDescription, "description", "d", "", "New description in markdown")
+	flags.StringVarP(&updateDescriptionFile, "description-file", "F", "", `Read the new description from a file, or "-" for stdin`)
+	flags.StringVarP(&updateAssignee, "assignee", "a", "", `Assignee name, email, "me" or "none"`)
+	flags.StringVarP(&updatePriority, "priority", "p", "", "Priority: none, urgent, high, medium, low or 0-4")
+	flags.StringArrayVarP(&updateLabels, "label", "l", nil, "Label to add (repeatable)")
+	flags.StringArrayVar(&updateRemoveLabels, "remove-label", nil, "Label to remove (repeatable)")
+	flags.StringVar(&updateProject, "project", "", `Project name, or "none"`)
+	flags.IntVarP(&updateEstimate, "estimate", "e", 0, "Estimate in points")
+	flags.StringVar(&updateDue, "due", "", "Due date: 2024-01-31, today, +3d or +2w")
+	flags.StringVar(&updateParent, "parent", "", "Parent issue identifier")
+	flags.StringVarP(&updateState, "state", "s", "", "Workflow state name or type")
+
+	closeCmd.Flags().BoolVar(&closeCanceled, "canceled", false, "Cancel the issues instead of completing them")
+	closeCmd.Flags().BoolVar(&closeDelete, "delete", false, "Move the issues to the trash")
+	closeCmd.MarkFlagsMutuallyExclusive("canceled", "delete")
+
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(moveCmd)
+	rootCmd.AddCommand(assignCmd)
+	rootCmd.AddCommand(closeCmd)
+}
+
+// eachIssue fetches the issues given by ids and calls apply on each one,
+// printing what apply reports. A failing issue is reported on stderr
+// without stopping the rest, and makes the command fail at the end.
+func eachIssue(r *resolver, ids []string, apply func(issue *linear.Issue) (string, error)) error {
+	failed := 0
+	for _, id := range ids {
+		issue, err := r.client.GetIssue(r.ctx, id)
+		if err == nil {
+			var result string
+			if result, err = apply(issue); err == nil {
+				fmt.Printf("%s %s\n", issue.Identifier, result)
+				continue
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", id, err)
+		failed++
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d issues failed", failed, len(ids))
+	}
+	return nil
+}
+
+// newCLIResolver checks the API key and returns a resolver for a command
+func newCLIResolver() (*resolver, error) {
+	if err := requireAPIKey(); err != nil {
+		return nil, err
+	}
+	return newResolver(context.Background(), linear.NewClient(cfg.Linear.APIKey)), nil
+}
+
+// teamOf returns the ID of an issue's team, which its states and labels belong to
+func teamOf(issue *linear.Issue) (string, error) {
+	if issue.Team == nil {
+		return "", errors.New("issue has no team")
+	}
+	return issue.Team.ID, nil
+}
+
+// stateName returns the name of an updated issue's state
+func stateName(issue *linear.Issue) string {
+	if issue == nil || issue.State == nil {
+		return "updated"
+	}
+	return "→ " + issue.State.Name
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	if flags.NFlag() == 0 {
+		return errors.New("nothing to update: give at least one field flag")
+	}
+
+	// Fields that don't depend on the issue's team are resolved once
+	var input linear.IssueUpdateInput
+	if flags.Changed("title") {
+		title := strings.TrimSpace(updateTitle)
+		input.Title = &title
+	}
+	if flags.Changed("description") {
+		input.Description = &updateDescription
+	}
+	if updateDescriptionFile != "" {
+		description, err := readDescription(updateDescriptionFile)
+		if err != nil {
+			return err
+		}
+		input.Description = &description
+	}
+	if updatePriority != "" {
+		priority, err := linear.ParsePriority(updatePriority)
+		if err != nil {
+			return err
+		}
+		input.Priority = &priority
+	}
+	if flags.Changed("estimate") {
+		input.Estimate = &updateEstimate
+	}
+	if updateDue != "" {
+		due, err := parseDue(updateDue)
+		if err != nil {
+			return err
+		}
+		input.DueDate = &due
+	}
+
+	r, err := newCLIResolver()
+	if err != nil {
+		return err
+	}
+
+	if updateAssignee != "" {
+		assigneeID, err := assigneeID(r, updateAssignee)
+		if err != nil {
+			return err
+		}
+		input.AssigneeID = &assigneeID
+	}
+	if updateProject != "" {
+		projectID := ""
+		if !strings.EqualFold(updateProject, "none") {
+			project, err := r.project(updateProject)
+			if err != nil {
+				return err
+			}
+			projectID = project.ID
+		}
+		input.ProjectID = &projectID
+	}
+	if updateParent != "" {
+		parent, err := r.client.GetIssue(r.ctx, updateParent)
+		if err != nil {
+			return fmt.Errorf("failed to fetch parent issue: %w", err)
+		}
+		input.ParentID = &parent.ID
+	}
+
+	return eachIssue(r, args, func(issue *linear.Issue) (string, error) {
+		// Labels and states belong to the issue's team
+		issueInput := input
+		if len(updateLabels) > 0 || len(updateRemoveLabels) > 0 || updateState != "" {
+			teamID, err := teamOf(issue)
+			if err != nil {
+				return "", err
+			}
+			if issueInput.AddedLabelIDs, err = labelIDs(r, teamID, updateLabels); err != nil {
+				return "", err
+			}
+			if issueInput.RemovedLabelIDs, err = labelIDs(r, teamID, updateRemoveLabels); err != nil {
+				return "", err
+			}
+			if updateState != "" {
+				state, err := r.state(teamID, updateState)
+				if err != nil {
+					return "", err
+				}
+				issueInput.StateID = &state.ID
+			}
+		}
+
+		if _, err := r.client.UpdateIssue(r.ctx, issue.ID, issueInput); err != nil {
+			return "", err
+		}
+		return "updated", nil
+	})
+}
+
+// labelIDs resolves label names in a team
+func labelIDs(r *resolver, teamID string, names []string) ([]string, error) {
+	var ids []string
+	for _, name := range names {
+		label, err := r.label(teamID, name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, label.ID)
+	}
+	return ids, nil
+}
+
+// assigneeID resolves a user for assigning issues to. "none" gives an empty
+// ID, which unassigns them.
+func assigneeID(r *resolver, name string) (string, error) {
+	if strings.EqualFold(name, "none") {
+		return "", nil
+	}
+	user, err := r.user(name)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+func runMove(cmd *cobra.Command, args []string) error {
+	ids, stateArg := args[:len(args)-1], args[len(args)-1]
+
+	r, err := newCLIResolver()
+	if err != nil {
+		return err
+	}
+
+	return eachIssue(r, ids, func(issue *linear.Issue) (string, error) {
+		teamID, err := teamOf(issue)
+		if err != nil {
+			return "", err
+		}
+		state, err := r.state(teamID, stateArg)
+		if err != nil {
+			return "", err
+		}
+		updated, err := r.client.UpdateIssueState(r.ctx, issue.ID, state.ID)
+		if err != nil {
+			return "", err
+		}
+		return stateName(updated), nil
+	})
+}
+
+func runAssign(cmd *cobra.Command, args []string) error {
+	ids, userArg := args[:len(args)-1], args[len(args)-1]
+
+	r, err := newCLIResolver()
+	if err != nil {
+		return err
+	}
+	userID, err := assigneeID(r, userArg)
+	if err != nil {
+		return err
+	}
+
+	return eachIssue(r, ids, func(issue *linear.Issue) (string, error) {
+		updated, err := r.client.UpdateIssueAssignee(r.ctx, issue.ID, &userID)
+		if err != nil {
+			return "", err
+		}
+		if updated == nil || updated.Assignee == nil {
+			return "→ unassigned", nil
+		}
+		return "→ " + updated.Assignee.Name, nil
+	})
+}
+
+func runClose(cmd *cobra.Command, args []string) error {
+	r, err := newCLIResolver()
+	if err != nil {
+		return err
+	}
+
+	stateType := "completed"
+	if closeCanceled {
+		stateType = "canceled"
+	}
+
+	return eachIssue(r, args, func(issue *linear.Issue) (string, error) {
+		if closeDelete {
+			if err := r.client.DeleteIssue(r.ctx, issue.ID); err != nil {
+				return "", err
+			}
+			return "deleted", nil
+		}
+
+		teamID, err := teamOf(issue)
+		if err != nil {
+			return "", err
+		}
+		state, err := r.stateOfType(teamID, stateType)
+		if err != nil {
+			return "", err
+		}
+		updated, err := r.client.UpdateIssueState(r.ctx, issue.ID, state.ID)
+		if err != nil {
+			return "", err
+		}
+		return stateName(updated), nil
+	})
+}
@@ -2,20 +2,26 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
+	"time"
 
+	"github.com/brandonli/lazyliner/internal/ai"
+	"github.com/brandonli/lazyliner/internal/cache"
 	"github.com/brandonli/lazyliner/internal/config"
 	"github.com/brandonli/lazyliner/internal/git"
 	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/queue"
 	"github.com/brandonli/lazyliner/internal/ui/components"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/ui/views/filter"
 	"github.com/brandonli/lazyliner/internal/ui/views/help"
 	"github.com/brandonli/lazyliner/internal/ui/views/issues"
 	"github.com/brandonli/lazyliner/internal/ui/views/kanban"
 	"github.com/brandonli/lazyliner/internal/ui/views/setup"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -44,8 +50,24 @@ const (
 	TabAllIssues
 	TabActive
 	TabBacklog
+	TabCycle
+	TabLinearView // A custom view from Linear, shown while one is open
+
+	// TabView is the tab of the first view defined in config; view i is
+	// shown in tab TabView + i
+	TabView
 )
 
+// tabTitles maps each tab to its label in the tab bar
+var tabTitles = map[Tab]string{
+	TabProject:   "Project",
+	TabMyIssues:  "My Issues",
+	TabAllIssues: "All Issues",
+	TabActive:    "Active",
+	TabBacklog:   "Backlog",
+	TabCycle:     "Cycle",
+}
+
 // Model is the main application model
 type Model struct {
 	// Configuration
@@ -60,6 +82,7 @@ type Model struct {
 	users    []linear.User
 	states   []linear.WorkflowState
 	labels   []linear.Label
+	cycles   map[string][]linear.Cycle // Current and upcoming cycles by team ID
 
 	// UI state
 	width     int
@@ -78,10 +101,46 @@ type Model struct {
 	filteredIssues   []linear.Issue
 	allProjectIssues []linear.Issue
 
+	// Search completion state: the query tab last completed, what it was
+	// completed from and how many times in a row
+	completionBase   string
+	completionCursor int
+	completionCount  int
+	completionResult string
+	completionHints  []string
+
 	// Pagination state
 	pageInfo    linear.PageInfo
 	loadingMore bool
 
+	// Local cache state
+	cache       *cache.Store
+	offline     bool
+	cachedAt    time.Time
+	issuesKey   string // list key of the issues currently shown
+	issuesFresh bool   // whether the shown issues came from the API rather than the cache
+
+	// Time until which Linear is rejecting requests for the rate limit
+	rateLimitedUntil time.Time
+
+	// Why the API key was rejected, shown on the setup view
+	setupErr string
+
+	// Batch action state
+	bulk              *bulkOp
+	bulkSeq           int
+	confirmBulkDelete bool
+	bulkLabelPicker   *components.MultiPickerModel
+	bulkLabels        []string // Labels on every selected issue when bulkLabelPicker opened
+
+	// Comment that pressing X again deletes
+	confirmCommentDelete string
+
+	// Offline mutation queue state
+	queue          *queue.Queue
+	replaying      bool
+	retryScheduled bool
+
 	// Components
 	spinner    spinner.Model
 	listView   issues.ListModel
@@ -91,54 +150,96 @@ type Model struct {
 	helpView   help.Model
 	kanbanView kanban.Model
 	setupView  setup.Model
-	picker     *components.PickerModel
-	pickerType string // "status", "assignee", "priority", "project"
+
+	// Pickers
+	picker      *components.PickerModel
+	pickerType  string // "status", "assignee", "priority", "project", "cycle"
+	labelPicker *components.MultiPickerModel
+
+	columnPicker *components.MultiPickerModel // Toggles the issue list's columns
+
+	relationKind  linear.RelationKind // Relation being added by the relation pickers
+	relationQuery string              // Last query searched by the relation issue picker
+
+	// Filter panel state
+	filter      linear.IssueFilter // User-selected filters (applies to all tabs); the project is kept in filterProject
+	filterPanel *filter.Model
+
+	// Views defined in config, shown as tabs after the built-in ones
+	views []config.ViewConfig
+
+	// Sort order and grouping picked for each tab, keyed by tabKey
+	layouts map[string]listLayout
+
+	// Custom views from Linear, loaded when the view picker first opens
+	customViews []linear.CustomView
+	linearView  *linear.CustomView // Shown in TabLinearView
+
+	// Text prompt
+	prompt     *components.PromptModel
+	promptType string // "view-save", "view-rename"
 
 	// Current data
 	issues         []linear.Issue
 	currentIssue   *linear.Issue
+	detailHistory  []*linear.Issue // Issues navigated away from in the detail view
 	currentProject *linear.Project // Auto-detected from git repo (shows Project tab)
+	currentBranch  string          // Git branch lazyliner was started on
+	branchIssue    *linear.Issue   // Issue detected from currentBranch
 	filterProject  *linear.Project // User-selected project filter (applies to all tabs)
+
+	// Draft for the create form asked for on the command line, opened once
+	// the teams, users and labels it names have loaded
+	pendingCreate *string
+	pendingParent *linear.Issue
+
+	// Issue whose cycle picker opens once its team's cycles have loaded
+	cyclePickerIssue *linear.Issue
+
+	// AI draft being generated for the create form. Each request gets the
+	// next draftSeq, so results of canceled or replaced requests are ignored.
+	draftCancel context.CancelFunc
+	draftSeq    int
 }
 
-func (m Model) tabNames() []string {
+// tabs returns the tabs shown in the tab bar, in order
+func (m Model) tabs() []Tab {
+	tabs := []Tab{TabMyIssues, TabAllIssues, TabActive, TabBacklog, TabCycle}
 	if m.currentProject != nil {
-		return []string{"Project", "My Issues", "All Issues", "Active", "Backlog"}
+		tabs = append([]Tab{TabProject}, tabs...)
+	}
+	for i := range m.views {
+		tabs = append(tabs, TabView+Tab(i))
+	}
+	if m.linearView != nil {
+		tabs = append(tabs, TabLinearView)
 	}
-	return []string{"My Issues", "All Issues", "Active", "Backlog"}
+	return tabs
+}
+
+func (m Model) tabNames() []string {
+	tabs := m.tabs()
+	names := make([]string, len(tabs))
+	for i, tab := range tabs {
+		names[i] = m.tabTitle(tab)
+	}
+	return names
 }
 
 func (m Model) tabCount() int {
-	return len(m.tabNames())
+	return len(m.tabs())
 }
 
 func (m Model) tabAtIndex(index int) Tab {
-	if m.currentProject != nil {
-		tabs := []Tab{TabProject, TabMyIssues, TabAllIssues, TabActive, TabBacklog}
-		if index >= 0 && index < len(tabs) {
-			return tabs[index]
-		}
-		return TabProject
-	}
-	tabs := []Tab{TabMyIssues, TabAllIssues, TabActive, TabBacklog}
+	tabs := m.tabs()
 	if index >= 0 && index < len(tabs) {
 		return tabs[index]
 	}
-	return TabMyIssues
+	return tabs[0]
 }
 
 func (m Model) indexOfTab(tab Tab) int {
-	if m.currentProject != nil {
-		tabs := []Tab{TabProject, TabMyIssues, TabAllIssues, TabActive, TabBacklog}
-		for i, t := range tabs {
-			if t == tab {
-				return i
-			}
-		}
-		return 0
-	}
-	tabs := []Tab{TabMyIssues, TabAllIssues, TabActive, TabBacklog}
-	for i, t := range tabs {
+	for i, t := range m.tabs() {
 		if t == tab {
 			return i
 		}
@@ -146,6 +247,15 @@ func (m Model) indexOfTab(tab Tab) int {
 	return 0
 }
 
+// switchTab activates tab and loads its issues. The list takes the tab's
+// columns straight away, as saved views can have their own.
+func (m Model) switchTab(tab Tab) (tea.Model, tea.Cmd) {
+	m.activeTab = tab
+	m.listView = m.listView.SetColumns(m.listColumns())
+	m.loading = true
+	return m, m.loadIssues()
+}
+
 // New creates a new application model
 func New(cfg *config.Config) Model {
 	s := spinner.New()
@@ -153,8 +263,8 @@ func New(cfg *config.Config) Model {
 	s.Style = theme.SpinnerStyle
 
 	ti := textinput.New()
-	ti.Placeholder = "Search issues..."
-	ti.CharLimit = 100
+	ti.Placeholder = "Search issues... (tab completes fields like state: or label:)"
+	ti.CharLimit = 200
 	ti.Width = 40
 
 	// Determine initial view based on API key configuration
@@ -165,18 +275,64 @@ func New(cfg *config.Config) Model {
 		loading = false
 	}
 
+	// The cache is best-effort: without it we simply always hit the API
+	var store *cache.Store
+	var mutations *queue.Queue
+	if cfg.Linear.APIKey != "" {
+		store, _ = cache.Open(config.CacheDir(), cfg.Linear.APIKey)
+		mutations, _ = queue.Open(config.CacheDir(), cfg.Linear.APIKey)
+	}
+
+	// Detached HEAD has no branch to match an issue against
+	branch, _ := git.GetCurrentBranch()
+	if branch == "HEAD" {
+		branch = ""
+	}
+
 	return Model{
-		config:      cfg,
-		keymap:      DefaultKeyMap(),
-		client:      linear.NewClient(cfg.Linear.APIKey),
-		loading:     loading,
-		spinner:     s,
-		activeTab:   TabMyIssues,
-		view:        initialView,
-		searchInput: ti,
+		config:        cfg,
+		currentBranch: branch,
+		keymap:        DefaultKeyMap(),
+		client:        linear.NewClient(cfg.Linear.APIKey),
+		cache:         store,
+		queue:         mutations,
+		loading:       loading,
+		spinner:       s,
+		activeTab:     startupTab(cfg),
+		views:         cfg.Views,
+		layouts:       make(map[string]listLayout),
+		cycles:        make(map[string][]linear.Cycle),
+		view:          initialView,
+		searchInput:   ti,
 	}
 }
 
+// OpenCreateForm makes the app open the create form on startup, filled in
+// from draft: markdown with front matter, as edited in $EDITOR. A non-nil
+// parent makes it a sub-issue form.
+func (m Model) OpenCreateForm(draft string, parent *linear.Issue) Model {
+	m.pendingCreate = &draft
+	m.pendingParent = parent
+	return m
+}
+
+// openPendingCreate opens the create form asked for on the command line
+// once the data it resolves names against is loaded
+func (m Model) openPendingCreate() Model {
+	if m.pendingCreate == nil || m.teams == nil || m.users == nil || m.labels == nil {
+		return m
+	}
+	m.createView = issues.NewCreateModel(m.teams, m.projects, m.states, m.users, m.labels, m.cycles[m.cycleTeamID()], m.width, m.height-4)
+	if m.pendingParent != nil {
+		m.createView = m.createView.SetParent(m.pendingParent)
+	}
+	m.createView = m.createView.ApplyEditorDraft(*m.pendingCreate)
+	m.pendingCreate = nil
+	m.pendingParent = nil
+	m.view = ViewCreate
+	return m
+}
+
 // Init initializes the application
 func (m Model) Init() tea.Cmd {
 	// Don't load data if we're in setup view (no API key)
@@ -185,13 +341,50 @@ func (m Model) Init() tea.Cmd {
 	}
 	return tea.Batch(
 		m.spinner.Tick,
+		m.loadCachedData(),
 		m.loadInitialData(),
 	)
 }
 
+// loadCachedData loads the last known workspace data from the local cache so
+// the UI can render instantly while fresh data is fetched
+func (m Model) loadCachedData() tea.Cmd {
+	if m.cache == nil || m.cache.Workspace() == "" {
+		return nil
+	}
+	store := m.cache
+	savedProjectID := m.config.Defaults.Project
+	return func() tea.Msg {
+		var viewer linear.Viewer
+		savedAt, ok := store.Get(cache.KeyViewer, &viewer)
+		if !ok {
+			return nil
+		}
+
+		msg := CachedDataLoadedMsg{Viewer: &viewer, SavedAt: savedAt}
+		store.Get(cache.KeyTeams, &msg.Teams)
+		store.Get(cache.KeyProjects, &msg.Projects)
+		store.Get(cache.KeyUsers, &msg.Users)
+		if len(msg.Teams) > 0 {
+			store.Get(cache.StatesKey(msg.Teams[0].ID), &msg.States)
+			store.Get(cache.LabelsKey(msg.Teams[0].ID), &msg.Labels)
+		}
+		msg.Cycles = make(map[string][]linear.Cycle)
+		for _, team := range msg.Teams {
+			var cycles []linear.Cycle
+			if _, ok := store.Get(cache.CyclesKey(team.ID), &cycles); ok {
+				msg.Cycles[team.ID] = cycles
+			}
+		}
+		msg.MatchedProject = matchProject(msg.Projects, savedProjectID)
+		return msg
+	}
+}
+
 // loadInitialData loads the initial data from Linear
 func (m Model) loadInitialData() tea.Cmd {
 	savedProjectID := m.config.Defaults.Project
+	store := m.cache
 	return func() tea.Msg {
 		ctx := context.Background()
 
@@ -210,49 +403,99 @@ func (m Model) loadInitialData() tea.Cmd {
 			return DataLoadedMsg{Err: err}
 		}
 
-		var matchedProject *linear.Project
-
-		// First check if there's a saved project filter in config
-		if savedProjectID != "" {
-			for i := range projects {
-				if projects[i].ID == savedProjectID {
-					matchedProject = &projects[i]
-					break
-				}
-			}
-		}
-
-		// If no saved project, try to match based on repo name
-		if matchedProject == nil {
-			repoName := git.GetRepoName()
-			if repoName != "" {
-				repoNameLower := strings.ToLower(repoName)
-				repoNameNormalized := strings.ReplaceAll(strings.ReplaceAll(repoNameLower, "-", ""), "_", "")
-				for i := range projects {
-					projectNameLower := strings.ToLower(projects[i].Name)
-					projectNameNormalized := strings.ReplaceAll(strings.ReplaceAll(projectNameLower, "-", ""), "_", "")
-					if strings.Contains(projectNameLower, repoNameLower) ||
-						strings.Contains(repoNameLower, projectNameLower) ||
-						strings.Contains(projectNameNormalized, repoNameNormalized) ||
-						strings.Contains(repoNameNormalized, projectNameNormalized) {
-						matchedProject = &projects[i]
-						break
-					}
-				}
-			}
+		if store != nil && viewer.Organization != nil {
+			store.SetWorkspace(viewer.Organization.ID)
+			store.Put(cache.KeyViewer, viewer)
+			store.Put(cache.KeyTeams, teams)
+			store.Put(cache.KeyProjects, projects)
 		}
 
 		return DataLoadedMsg{
 			Viewer:         viewer,
 			Teams:          teams,
 			Projects:       projects,
-			MatchedProject: matchedProject,
+			MatchedProject: matchProject(projects, savedProjectID),
+		}
+	}
+}
+
+// matchProject returns the project saved in config, or failing that the
+// project whose name matches the current git repository
+func matchProject(projects []linear.Project, savedProjectID string) *linear.Project {
+	// First check if there's a saved project filter in config
+	if savedProjectID != "" {
+		for i := range projects {
+			if projects[i].ID == savedProjectID {
+				return &projects[i]
+			}
 		}
 	}
+
+	// If no saved project, try to match based on repo name
+	repoName := git.GetRepoName()
+	if repoName == "" {
+		return nil
+	}
+	repoNameLower := strings.ToLower(repoName)
+	repoNameNormalized := strings.ReplaceAll(strings.ReplaceAll(repoNameLower, "-", ""), "_", "")
+	for i := range projects {
+		projectNameLower := strings.ToLower(projects[i].Name)
+		projectNameNormalized := strings.ReplaceAll(strings.ReplaceAll(projectNameLower, "-", ""), "_", "")
+		if strings.Contains(projectNameLower, repoNameLower) ||
+			strings.Contains(repoNameLower, projectNameLower) ||
+			strings.Contains(projectNameNormalized, repoNameNormalized) ||
+			strings.Contains(repoNameNormalized, projectNameNormalized) {
+			return &projects[i]
+		}
+	}
+	return nil
 }
 
+// issuesListKey identifies the issue list for the current tab and filters,
+// used both as the cache key and to discard responses for a stale tab
+func (m Model) issuesListKey() string {
+	key := m.tabKey()
+	if m.activeTab == TabProject {
+		if m.currentProject != nil {
+			key += ":project:" + m.currentProject.ID
+		}
+	} else if m.filterProject != nil {
+		key += ":project:" + m.filterProject.ID
+	}
+	if m.activeTab == TabCycle {
+		if cycle := m.activeCycle(); cycle != nil {
+			key += ":cycle:" + cycle.ID
+		}
+	}
+	return key + m.filterKey()
+}
+
+// loadIssues renders cached issues for the current tab right away (if any)
+// and revalidates them against the API in the background
 func (m Model) loadIssues() tea.Cmd {
-	return m.loadIssuesWithCursor("")
+	return tea.Batch(m.loadCachedIssues(), m.loadIssuesWithCursor(""))
+}
+
+// loadCachedIssues loads the cached issue list for the current tab
+func (m Model) loadCachedIssues() tea.Cmd {
+	if m.cache == nil {
+		return nil
+	}
+	store := m.cache
+	key := m.issuesListKey()
+	return func() tea.Msg {
+		issues, pageInfo, savedAt, ok := store.IssueList(key)
+		if !ok {
+			return nil
+		}
+		return IssuesLoadedMsg{
+			Issues:    issues,
+			PageInfo:  pageInfo,
+			Key:       key,
+			FromCache: true,
+			CachedAt:  savedAt,
+		}
+	}
 }
 
 func (m Model) loadMoreIssues() tea.Cmd {
@@ -271,26 +514,52 @@ func (m Model) loadIssuesWithCursor(cursor string) tea.Cmd {
 	if m.currentProject != nil {
 		currentProjectID = m.currentProject.ID
 	}
+	cycleID := ""
+	if cycle := m.activeCycle(); cycle != nil {
+		cycleID = cycle.ID
+	}
 	isAppend := cursor != ""
+	key := m.issuesListKey()
+	store := m.cache
+
+	// Filters chosen in the filter panel are composed with the tab's filter
+	// and applied server-side, as are the filters of views
+	_, isView := m.viewForTab(m.activeTab)
+	hasFilter := isView || !filter.IsEmpty(m.filter)
+	issueFilter, filterErr := m.tabIssueFilter(50, cursor)
+	linearViewID := ""
+	if m.linearView != nil {
+		linearViewID = m.linearView.ID
+	}
 
 	return func() tea.Msg {
 		ctx := context.Background()
 		var conn linear.IssueConnection
 		var err error
 
-		switch m.activeTab {
-		case TabMyIssues:
+		switch {
+		case filterErr != nil:
+			err = filterErr
+		case m.activeTab == TabLinearView:
+			if linearViewID != "" {
+				conn, err = m.client.GetCustomViewIssues(ctx, linearViewID, issueFilter)
+			}
+		case hasFilter:
+			if m.activeTab != TabCycle || issueFilter.CycleID != "" {
+				conn, err = m.client.GetIssues(ctx, issueFilter)
+			}
+		case m.activeTab == TabMyIssues:
 			conn, err = m.client.GetMyIssues(ctx, 50, cursor)
 			if err == nil && filterProjectID != "" {
 				conn.Nodes = filterIssuesByProject(conn.Nodes, filterProjectID)
 			}
-		case TabAllIssues:
+		case m.activeTab == TabAllIssues:
 			filter := linear.IssueFilter{Limit: 50, After: cursor}
 			if filterProjectID != "" {
 				filter.ProjectID = filterProjectID
 			}
 			conn, err = m.client.GetIssues(ctx, filter)
-		case TabActive:
+		case m.activeTab == TabActive:
 			filter := linear.IssueFilter{
 				StateType: "started",
 				Limit:     50,
@@ -300,7 +569,7 @@ func (m Model) loadIssuesWithCursor(cursor string) tea.Cmd {
 				filter.ProjectID = filterProjectID
 			}
 			conn, err = m.client.GetIssues(ctx, filter)
-		case TabBacklog:
+		case m.activeTab == TabBacklog:
 			filter := linear.IssueFilter{
 				StateType: "backlog",
 				Limit:     50,
@@ -310,16 +579,31 @@ func (m Model) loadIssuesWithCursor(cursor string) tea.Cmd {
 				filter.ProjectID = filterProjectID
 			}
 			conn, err = m.client.GetIssues(ctx, filter)
-		case TabProject:
+		case m.activeTab == TabCycle:
+			if cycleID != "" {
+				filter := linear.IssueFilter{
+					CycleID:   cycleID,
+					ProjectID: filterProjectID,
+					Limit:     50,
+					After:     cursor,
+				}
+				conn, err = m.client.GetIssues(ctx, filter)
+			}
+		case m.activeTab == TabProject:
 			if currentProjectID != "" {
 				conn, err = m.client.GetProjectIssues(ctx, currentProjectID, 50, false, cursor)
 			}
 		}
 
+		if err == nil && store != nil {
+			store.PutIssueList(key, conn.Nodes, conn.PageInfo, isAppend)
+		}
+
 		return IssuesLoadedMsg{
 			Issues:   conn.Nodes,
 			PageInfo: conn.PageInfo,
 			Append:   isAppend,
+			Key:      key,
 			Err:      err,
 		}
 	}
@@ -352,9 +636,14 @@ func (m Model) loadWorkflowStates() tea.Cmd {
 	if len(m.teams) == 0 {
 		return nil
 	}
+	teamID := m.teams[0].ID
+	store := m.cache
 	return func() tea.Msg {
 		ctx := context.Background()
-		states, err := m.client.GetWorkflowStates(ctx, m.teams[0].ID)
+		states, err := m.client.GetWorkflowStates(ctx, teamID)
+		if err == nil && store != nil {
+			store.Put(cache.StatesKey(teamID), states)
+		}
 		return WorkflowStatesLoadedMsg{States: states, Err: err}
 	}
 }
@@ -364,18 +653,27 @@ func (m Model) loadLabels() tea.Cmd {
 	if len(m.teams) == 0 {
 		return nil
 	}
+	teamID := m.teams[0].ID
+	store := m.cache
 	return func() tea.Msg {
 		ctx := context.Background()
-		labels, err := m.client.GetLabels(ctx, m.teams[0].ID)
+		labels, err := m.client.GetLabels(ctx, teamID)
+		if err == nil && store != nil {
+			store.Put(cache.LabelsKey(teamID), labels)
+		}
 		return LabelsLoadedMsg{Labels: labels, Err: err}
 	}
 }
 
 // loadUsers loads users
 func (m Model) loadUsers() tea.Cmd {
+	store := m.cache
 	return func() tea.Msg {
 		ctx := context.Background()
 		users, err := m.client.GetUsers(ctx)
+		if err == nil && store != nil {
+			store.Put(cache.KeyUsers, users)
+		}
 		return UsersLoadedMsg{Users: users, Err: err}
 	}
 }
@@ -395,9 +693,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Handle picker if it's open
+		if m.prompt != nil {
+			return m.updatePrompt(msg)
+		}
+		if m.filterPanel != nil {
+			return m.updateFilterPanel(msg)
+		}
 		if m.picker != nil {
 			return m.updatePicker(msg)
 		}
+		if m.labelPicker != nil {
+			return m.updateLabelPicker(msg)
+		}
+		if m.columnPicker != nil {
+			return m.updateColumnPicker(msg)
+		}
+		if m.bulkLabelPicker != nil {
+			return m.updateBulkLabelPicker(msg)
+		}
 
 		// Handle view-specific keys
 		switch m.view {
@@ -420,9 +733,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if clickedTab := m.getClickedTab(msg.X, msg.Y); clickedTab >= 0 {
 				newTab := m.tabAtIndex(clickedTab)
 				if newTab != m.activeTab {
-					m.activeTab = newTab
-					m.loading = true
-					return m, m.loadIssues()
+					return m.switchTab(newTab)
 				}
 			}
 		}
@@ -436,6 +747,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.createView = m.createView.SetSize(msg.Width, msg.Height-4)
 		m.editView = m.editView.SetSize(msg.Width, msg.Height-4)
 		m.kanbanView = m.kanbanView.SetSize(msg.Width, msg.Height-4)
+		if m.filterPanel != nil {
+			panel := m.filterPanel.SetSize(msg.Width, msg.Height)
+			m.filterPanel = &panel
+		}
 		return m, nil
 
 	case spinner.TickMsg:
@@ -443,34 +758,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case CachedDataLoadedMsg:
+		// Fresh data already arrived; the cache has nothing to add
+		if m.viewer != nil {
+			return m, nil
+		}
+		m.viewer = msg.Viewer
+		m.teams = msg.Teams
+		m.projects = msg.Projects
+		m.users = msg.Users
+		m.states = msg.States
+		m.labels = msg.Labels
+		m.cycles = msg.Cycles
+		m.cachedAt = msg.SavedAt
+		m.currentProject = msg.MatchedProject
+		if m.currentProject != nil && opensProjectTab(m.config) {
+			m.activeTab = TabProject
+		}
+		m = m.openPendingCreate()
+		return m, m.loadCachedIssues()
+
 	case DataLoadedMsg:
 		if msg.Err != nil {
 			m.loading = false
-			m.statusMsg = "Error: " + msg.Err.Error()
-			m.statusErr = true
-			return m, nil
+			// Keep browsing cached data when the API can't be reached
+			if m.viewer != nil && linear.IsNetworkError(msg.Err) {
+				m.statusMsg = "Offline - showing cached data from " + m.cachedAt.Format("Jan 2 15:04")
+				m.statusErr = true
+				return m.setOffline()
+			}
+			return m.showError("Error: ", msg.Err)
 		}
+		m.offline = false
 		m.viewer = msg.Viewer
 		m.teams = msg.Teams
 		m.projects = msg.Projects
 		m.currentProject = msg.MatchedProject
-		if m.currentProject != nil {
+		if m.currentProject != nil && opensProjectTab(m.config) {
 			m.activeTab = TabProject
 		}
+		m = m.openPendingCreate()
 		return m, tea.Batch(
 			m.loadIssues(),
 			m.loadWorkflowStates(),
 			m.loadLabels(),
 			m.loadUsers(),
+			m.loadAllCycles(),
+			m.loadBranchIssue(),
 		)
 
 	case IssuesLoadedMsg:
+		// Drop responses for a tab or filter that is no longer active
+		if msg.Key != m.issuesListKey() {
+			return m, nil
+		}
+		if msg.FromCache {
+			if m.issuesKey == msg.Key && m.issuesFresh {
+				return m, nil
+			}
+			m.loading = false
+			m.pageInfo = msg.PageInfo
+			m.issues = sortIssues(msg.Issues)
+			m.issuesKey = msg.Key
+			m.issuesFresh = false
+			m.cachedAt = msg.CachedAt
+			m.listView = m.issueListView()
+			return m, nil
+		}
 		m.loading = false
 		m.loadingMore = false
 		if msg.Err != nil {
-			m.statusMsg = "Error loading issues: " + msg.Err.Error()
-			m.statusErr = true
-			return m, nil
+			if linear.IsNetworkError(msg.Err) && m.issuesKey == msg.Key {
+				m.statusMsg = "Offline - showing cached issues from " + m.cachedAt.Format("Jan 2 15:04")
+				m.statusErr = true
+				return m.setOffline()
+			}
+			return m.showError("Error loading issues: ", msg.Err)
+		}
+		m.offline = false
+		m.issuesKey = msg.Key
+		m.issuesFresh = true
+		if m.queue != nil && m.queue.Len() > 0 && !m.replaying {
+			m.replaying = true
+			cmds = append(cmds, m.replayQueue())
 		}
 		m.pageInfo = msg.PageInfo
 		if msg.Append {
@@ -479,46 +849,60 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.issues = msg.Issues
 		}
 		m.issues = sortIssues(m.issues)
-		m.listView = issues.NewListModelWithPagination(m.issues, m.width, m.height-4, m.pageInfo.HasNextPage)
+		m.listView = m.issueListView()
 		if msg.PageInfo.HasNextPage && !msg.Append {
 			m.statusMsg = fmt.Sprintf("Loaded %d issues (more available, press L)", len(m.issues))
 		} else if msg.Append {
 			m.statusMsg = fmt.Sprintf("Loaded %d total issues", len(m.issues))
 		}
-		return m, nil
+		return m, tea.Batch(cmds...)
 
 	case WorkflowStatesLoadedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error loading workflow states: " + msg.Err.Error()
-			m.statusErr = true
-			return m, nil
+			return m.showError("Error loading workflow states: ", msg.Err)
 		}
 		m.states = msg.States
 		return m, nil
 
 	case LabelsLoadedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error loading labels: " + msg.Err.Error()
-			m.statusErr = true
-			return m, nil
+			return m.showError("Error loading labels: ", msg.Err)
 		}
 		m.labels = msg.Labels
+		return m.openPendingCreate(), nil
+
+	case CyclesLoadedMsg:
+		// The cycle picker waiting on these cycles opens now
+		var pending *linear.Issue
+		if m.cyclePickerIssue != nil && issueTeamID(m.cyclePickerIssue) == msg.TeamID {
+			pending, m.cyclePickerIssue = m.cyclePickerIssue, nil
+		}
+		if msg.Err != nil {
+			return m.showError("Error loading cycles: ", msg.Err)
+		}
+		key := m.issuesListKey()
+		m.cycles[msg.TeamID] = msg.Cycles
+		if pending != nil {
+			m.statusMsg = ""
+			m = m.showCyclePicker(pending)
+		}
+		// The Cycle tab lists the active cycle, which may have just changed
+		if m.activeTab == TabCycle && m.issuesListKey() != key {
+			m.loading = true
+			return m, m.loadIssues()
+		}
 		return m, nil
 
 	case UsersLoadedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error loading users: " + msg.Err.Error()
-			m.statusErr = true
-			return m, nil
+			return m.showError("Error loading users: ", msg.Err)
 		}
 		m.users = msg.Users
-		return m, nil
+		return m.openPendingCreate(), nil
 
 	case AllProjectIssuesLoadedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error loading project issues: " + msg.Err.Error()
-			m.statusErr = true
-			return m, nil
+			return m.showError("Error loading project issues: ", msg.Err)
 		}
 		m.allProjectIssues = sortIssues(msg.Issues)
 		m.filterIssues()
@@ -526,8 +910,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case IssueUpdatedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error: " + msg.Err.Error()
-			m.statusErr = true
+			var cmd tea.Cmd
+			m, cmd = m.showError("Error: ", msg.Err)
+			cmds = append(cmds, cmd)
+			if m.view == ViewEdit {
+				m.editView = m.editView.SetFieldErrors(linear.FieldErrors(msg.Err))
+			}
 		} else {
 			m.statusMsg = "Issue updated"
 			m.statusErr = false
@@ -541,35 +929,67 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				// Re-sort issues after update (status/priority may have changed)
 				m.issues = sortIssues(m.issues)
-				m.listView = issues.NewListModel(m.issues, m.width, m.height-4)
+				m.listView = m.issueListView()
 				if m.currentIssue != nil && m.currentIssue.ID == msg.Issue.ID {
 					m.currentIssue = msg.Issue
-					m.detailView = issues.NewDetailModel(m.currentIssue, m.width, m.height-4)
+					m.detailView = m.detailView.SetIssue(m.currentIssue)
 				}
 			}
 			if m.view == ViewEdit {
 				m.view = ViewDetail
 			}
 		}
-		return m, nil
+		return m, tea.Batch(cmds...)
 
 	case IssueCreatedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error creating issue: " + msg.Err.Error()
-			m.statusErr = true
+			var cmd tea.Cmd
+			m, cmd = m.showError("Error creating issue: ", msg.Err)
+			cmds = append(cmds, cmd)
+			if m.view == ViewCreate {
+				m.createView = m.createView.SetFieldErrors(linear.FieldErrors(msg.Err))
+			}
 		} else {
 			m.statusMsg = "Issue created: " + msg.Issue.Identifier
 			m.statusErr = false
-			m.view = ViewList
+			m.view = m.createReturnView()
 			// Refresh issues
 			cmds = append(cmds, m.loadIssues())
+			if m.view == ViewDetail {
+				cmds = append(cmds, m.loadHierarchy(m.currentIssue.ID))
+			}
 		}
 		return m, tea.Batch(cmds...)
 
+	case IssueQueuedMsg:
+		return m.handleIssueQueued(msg)
+
+	case ConnectivityRetryMsg:
+		m.retryScheduled = false
+		if !m.offline {
+			return m, nil
+		}
+		return m, m.checkConnectivity()
+
+	case ConnectivityCheckedMsg:
+		if msg.Err != nil {
+			return m.setOffline()
+		}
+		m.offline = false
+		if m.queue != nil && m.queue.Len() > 0 && !m.replaying {
+			m.replaying = true
+			return m, tea.Batch(m.replayQueue(), m.loadIssues())
+		}
+		return m, m.loadIssues()
+
+	case QueueReplayedMsg:
+		return m.handleQueueReplayed(msg)
+
 	case IssueDeletedMsg:
 		if msg.Err != nil {
-			m.statusMsg = "Error deleting issue: " + msg.Err.Error()
-			m.statusErr = true
+			var cmd tea.Cmd
+			m, cmd = m.showError("Error deleting issue: ", msg.Err)
+			cmds = append(cmds, cmd)
 		} else {
 			m.statusMsg = "Issue deleted: " + msg.Identifier
 			m.statusErr = false
@@ -579,6 +999,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case BulkItemDoneMsg:
+		return m.handleBulkItemDone(msg)
+
+	case RateLimitTickMsg:
+		if time.Now().Before(m.rateLimitedUntil) {
+			return m, rateLimitTick()
+		}
+		m.rateLimitedUntil = time.Time{}
+		m.statusMsg = "Rate limit reset"
+		m.statusErr = false
+		return m, nil
+
 	case StatusMsg:
 		m.statusMsg = msg.Message
 		m.statusErr = msg.IsError
@@ -595,6 +1027,149 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case kanban.MoveIssueMsg:
 		return m, m.updateIssueState(msg.IssueID, msg.StateID)
+
+	case CommentsLoadedMsg:
+		if m.currentIssue == nil || m.currentIssue.ID != msg.IssueID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.detailView = m.detailView.CommentsFailed()
+			return m.showError("Error loading comments: ", msg.Err)
+		}
+		m.detailView = m.detailView.SetComments(msg.Comments, msg.PageInfo, msg.Append)
+		return m, nil
+
+	case CommentSavedMsg:
+		if msg.Err != nil {
+			m.detailView = m.detailView.CommentSaveFailed()
+			return m.showError("Error saving comment: ", msg.Err)
+		}
+		m.detailView = m.detailView.CommentSaved(msg.Comment)
+		if msg.Created {
+			m.statusMsg = "Comment posted"
+		} else {
+			m.statusMsg = "Comment updated"
+		}
+		m.statusErr = false
+		return m, nil
+
+	case CommentDeletedMsg:
+		if msg.Err != nil {
+			return m.showError("Error deleting comment: ", msg.Err)
+		}
+		m.detailView = m.detailView.CommentDeleted(msg.CommentID)
+		m.statusMsg = "Comment deleted"
+		m.statusErr = false
+		return m, nil
+
+	case issues.SubmitCommentMsg:
+		return m, m.saveComment(msg.IssueID, msg.CommentID, msg.Body)
+
+	case issues.DeleteCommentMsg:
+		if m.confirmCommentDelete != msg.CommentID {
+			m.confirmCommentDelete = msg.CommentID
+			m.statusMsg = "Delete this comment? Press X again to confirm"
+			m.statusErr = true
+			return m, nil
+		}
+		m.confirmCommentDelete = ""
+		return m, m.deleteComment(msg.CommentID)
+
+	case issues.ComposeInEditorMsg:
+		return m, openEditor(editorComment, msg.Body)
+
+	case EditorClosedMsg:
+		return m.handleEditorClosed(msg)
+
+	case issues.ToggleTaskMsg:
+		return m.toggleTask(msg)
+
+	case TaskToggleFailedMsg:
+		return m.handleTaskToggleFailed(msg)
+
+	case issues.LoadMoreCommentsMsg:
+		return m, m.loadComments(msg.IssueID, msg.After)
+
+	case issues.CreateLabelMsg:
+		return m, m.createLabel(msg.TeamID, msg.Name)
+
+	case LabelCreatedMsg:
+		return m.handleLabelCreated(msg)
+
+	case BranchCheckedOutMsg:
+		return m.handleBranchCheckedOut(msg)
+
+	case StartedStateLoadedMsg:
+		return m.handleStartedStateLoaded(msg)
+
+	case BranchIssueLoadedMsg:
+		return m.handleBranchIssueLoaded(msg)
+
+	case HierarchyLoadedMsg:
+		return m.handleHierarchyLoaded(msg)
+
+	case issues.OpenIssueMsg:
+		return m.openLinkedIssue(msg.IssueID)
+
+	case LinkedIssueLoadedMsg:
+		return m.handleLinkedIssueLoaded(msg)
+
+	case RelationsLoadedMsg:
+		return m.handleRelationsLoaded(msg)
+
+	case RelationSearchMsg:
+		return m.handleRelationSearch(msg)
+
+	case RelationSearchResultsMsg:
+		return m.handleRelationSearchResults(msg)
+
+	case RelationChangedMsg:
+		return m.handleRelationChanged(msg)
+
+	case AttachmentsLoadedMsg:
+		return m.handleAttachmentsLoaded(msg)
+
+	case LocalCommitsLoadedMsg:
+		return m.handleLocalCommitsLoaded(msg)
+
+	case filter.ChangedMsg:
+		return m.handleFilterChanged(msg)
+
+	case CustomViewsLoadedMsg:
+		return m.handleCustomViewsLoaded(msg)
+
+	case SearchResultsMsg:
+		return m.handleSearchResults(msg)
+
+	case issues.GenerateDraftMsg:
+		m = m.cancelDraft()
+		ctx, cancel := context.WithCancel(context.Background())
+		m.draftCancel = cancel
+		m.statusMsg = "Generating draft..."
+		m.statusErr = false
+		return m, m.generateDraft(ctx, m.draftSeq, msg.Prompt)
+
+	case issues.CancelDraftMsg:
+		m = m.cancelDraft()
+		m.statusMsg = "Draft canceled"
+		m.statusErr = false
+		return m, nil
+
+	case AIDraftGeneratedMsg:
+		// Ignore drafts that were canceled, replaced by a newer prompt or
+		// arrive after the form was closed
+		if msg.Seq != m.draftSeq || m.view != ViewCreate {
+			return m, nil
+		}
+		m = m.cancelDraft()
+		if msg.Err != nil {
+			m.createView = m.createView.DraftFailed()
+			return m.showError("AI draft failed: ", msg.Err)
+		}
+		m.createView = m.createView.ApplyDraft(msg.Draft)
+		m.statusMsg = fmt.Sprintf("Draft generated by %s - review and press Ctrl+S to submit", msg.Provider)
+		m.statusErr = false
+		return m, textinput.Blink
 	}
 
 	return m, tea.Batch(cmds...)
@@ -621,6 +1196,22 @@ func (m Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.updateSearchMode(msg)
 	}
 
+	if m.listView.HasSelection() || m.confirmBulkDelete {
+		if m, cmd, handled := m.updateBulkKeys(msg); handled {
+			return m, cmd
+		}
+	}
+
+	// Issues created offline only exist in the queue until it's replayed
+	if selected := m.listView.SelectedIssue(); selected != nil && isQueuedIssue(selected.ID) {
+		switch msg.String() {
+		case "enter", "s", "Y", "l", "y", "B", "o", "w", "d", " ":
+			m.statusMsg = fmt.Sprintf("Offline: %q will be created once back online", selected.Title)
+			m.statusErr = true
+			return m, nil
+		}
+	}
+
 	switch {
 	case msg.String() == "/":
 		m.searchMode = true
@@ -635,80 +1226,29 @@ func (m Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case msg.String() == "enter":
 		if selected := m.listView.SelectedIssue(); selected != nil {
-			m.currentIssue = selected
-			m.detailView = issues.NewDetailModel(selected, m.width, m.height-4)
-			m.view = ViewDetail
+			return m.openDetail(selected)
 		}
 		return m, nil
 
 	case msg.String() == "c":
-		m.createView = issues.NewCreateModel(m.teams, m.projects, m.states, m.users, m.labels, m.width, m.height-4)
+		m.createView = issues.NewCreateModel(m.teams, m.projects, m.states, m.users, m.labels, m.cycles[m.cycleTeamID()], m.width, m.height-4)
 		m.view = ViewCreate
 		return m, nil
 
 	case msg.String() == "tab":
 		currentIndex := m.indexOfTab(m.activeTab)
 		nextIndex := (currentIndex + 1) % m.tabCount()
-		m.activeTab = m.tabAtIndex(nextIndex)
-		m.loading = true
-		return m, m.loadIssues()
+		return m.switchTab(m.tabAtIndex(nextIndex))
 
 	case msg.String() == "shift+tab":
 		currentIndex := m.indexOfTab(m.activeTab)
 		prevIndex := (currentIndex - 1 + m.tabCount()) % m.tabCount()
-		m.activeTab = m.tabAtIndex(prevIndex)
-		m.loading = true
-		return m, m.loadIssues()
-
-	case msg.String() == "1":
-		targetTab := TabMyIssues
-		if m.currentProject != nil {
-			targetTab = TabProject
-		}
-		if m.activeTab != targetTab {
-			m.activeTab = targetTab
-			m.loading = true
-			return m, m.loadIssues()
-		}
+		return m.switchTab(m.tabAtIndex(prevIndex))
 
-	case msg.String() == "2":
-		targetTab := TabAllIssues
-		if m.currentProject != nil {
-			targetTab = TabMyIssues
-		}
-		if m.activeTab != targetTab {
-			m.activeTab = targetTab
-			m.loading = true
-			return m, m.loadIssues()
-		}
-
-	case msg.String() == "3":
-		targetTab := TabActive
-		if m.currentProject != nil {
-			targetTab = TabAllIssues
-		}
-		if m.activeTab != targetTab {
-			m.activeTab = targetTab
-			m.loading = true
-			return m, m.loadIssues()
-		}
-
-	case msg.String() == "4":
-		targetTab := TabBacklog
-		if m.currentProject != nil {
-			targetTab = TabActive
-		}
-		if m.activeTab != targetTab {
-			m.activeTab = targetTab
-			m.loading = true
-			return m, m.loadIssues()
-		}
-
-	case msg.String() == "5":
-		if m.currentProject != nil && m.activeTab != TabBacklog {
-			m.activeTab = TabBacklog
-			m.loading = true
-			return m, m.loadIssues()
+	case len(msg.String()) == 1 && msg.String() >= "1" && msg.String() <= "9":
+		index := int(msg.String()[0] - '1')
+		if index < m.tabCount() && m.tabAtIndex(index) != m.activeTab {
+			return m.switchTab(m.tabAtIndex(index))
 		}
 
 	case msg.String() == "r":
@@ -724,16 +1264,48 @@ func (m Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case msg.String() == "Y":
+		// Open cycle picker
+		if selected := m.listView.SelectedIssue(); selected != nil {
+			return m.openCyclePicker(selected)
+		}
+		return m, nil
+
+	case msg.String() == "l":
+		// Open label picker
+		if selected := m.listView.SelectedIssue(); selected != nil {
+			return m.openLabelPicker(selected), nil
+		}
+		return m, nil
+
 	case msg.String() == "P":
 		// Open project filter picker
 		m.picker = components.NewPickerModel("Filter by Project", m.projectsToItems(), m.width, m.height)
 		m.pickerType = "project"
 		return m, nil
 
+	case msg.String() == "f":
+		return m.openFilterPanel(), nil
+
+	case msg.String() == "F":
+		return m.clearFilters()
+
+	case msg.String() == "V":
+		return m.openViewMenu(), nil
+
+	case msg.String() == "O":
+		return m.openLayoutMenu(), nil
+
 	case msg.String() == "y":
 		// Copy branch name
 		if selected := m.listView.SelectedIssue(); selected != nil {
-			return m, m.copyToClipboard(selected.BranchName, "Branch name copied")
+			return m, m.copyToClipboard(m.branchName(selected), "Branch name copied")
+		}
+
+	case msg.String() == "B":
+		// Create and check out the issue's branch
+		if selected := m.listView.SelectedIssue(); selected != nil {
+			return m, m.checkoutBranch(selected)
 		}
 
 	case msg.String() == "o":
@@ -768,17 +1340,71 @@ func (m Model) updateListView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Forward to list view
 	var cmd tea.Cmd
 	m.listView, cmd = m.listView.Update(msg)
+	if msg.String() == "z" || msg.String() == "Z" {
+		m.rememberCollapsed()
+	}
 	return m, cmd
 }
 
+// openDetail switches to the detail view for an issue and loads its comments
+func (m Model) openDetail(issue *linear.Issue) (Model, tea.Cmd) {
+	m.currentIssue = issue
+	m.detailHistory = nil
+	m.detailView = issues.NewDetailModel(issue, m.width, m.height-4)
+	if m.viewer != nil {
+		m.detailView = m.detailView.SetViewerID(m.viewer.ID)
+	}
+	m.detailView = m.detailView.SetSyncState(m.syncStates()[issue.ID])
+	m.view = ViewDetail
+	return m, tea.Batch(
+		m.loadComments(issue.ID, ""),
+		m.loadAttachments(issue.ID),
+		m.loadLocalCommits(issue.ID, issue.Identifier),
+		m.loadHierarchy(issue.ID),
+		m.loadRelations(issue.ID),
+	)
+}
+
 // updateDetailView handles updates in the detail view
 func (m Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The comment compose box owns all keys while open
+	if m.detailView.IsComposing() {
+		var cmd tea.Cmd
+		m.detailView, cmd = m.detailView.Update(msg)
+		return m, cmd
+	}
+
+	// Any key but X cancels deleting a comment
+	if m.confirmCommentDelete != "" && msg.String() != "X" {
+		m.confirmCommentDelete = ""
+		m.statusMsg = "Delete cancelled"
+		m.statusErr = false
+		return m, nil
+	}
+
 	switch {
 	case msg.String() == "esc" || msg.String() == "q":
+		if len(m.detailHistory) > 0 {
+			return m.navigateBack()
+		}
 		m.view = ViewList
 		m.currentIssue = nil
 		return m, nil
 
+	case msg.String() == "R":
+		if m.currentIssue != nil {
+			m = m.openRelationPicker()
+		}
+		return m, nil
+
+	case msg.String() == "S":
+		if m.currentIssue != nil {
+			m.createView = issues.NewCreateModel(m.teams, m.projects, m.states, m.users, m.labels, m.cycles[m.cycleTeamID()], m.width, m.height-4).
+				SetParent(m.currentIssue)
+			m.view = ViewCreate
+		}
+		return m, nil
+
 	case msg.String() == "s":
 		// Open status picker
 		if m.currentIssue != nil {
@@ -803,10 +1429,30 @@ func (m Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case msg.String() == "Y":
+		// Open cycle picker
+		if m.currentIssue != nil {
+			return m.openCyclePicker(m.currentIssue)
+		}
+		return m, nil
+
+	case msg.String() == "l":
+		// Open label picker
+		if m.currentIssue != nil {
+			return m.openLabelPicker(m.currentIssue), nil
+		}
+		return m, nil
+
 	case msg.String() == "y":
 		// Copy branch name
 		if m.currentIssue != nil {
-			return m, m.copyToClipboard(m.currentIssue.BranchName, "Branch name copied")
+			return m, m.copyToClipboard(m.branchName(m.currentIssue), "Branch name copied")
+		}
+
+	case msg.String() == "B":
+		// Create and check out the issue's branch
+		if m.currentIssue != nil {
+			return m, m.checkoutBranch(m.currentIssue)
 		}
 
 	case msg.String() == "o":
@@ -825,9 +1471,43 @@ func (m Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.deleteIssue(m.currentIssue.ID, m.currentIssue.Identifier)
 		}
 
+	case msg.String() == "F":
+		// Apply conflicting offline edits over the server's changes
+		if m.currentIssue != nil && m.queue != nil && m.syncStates()[m.currentIssue.ID] == issues.SyncConflict {
+			if err := m.queue.Force(m.currentIssue.ID); err != nil {
+				m.statusMsg = "Error: " + err.Error()
+				m.statusErr = true
+				return m, nil
+			}
+			m.refreshSyncStates()
+			m.statusMsg = "Applying offline edits to " + m.currentIssue.Identifier
+			m.statusErr = false
+			if m.replaying || m.offline {
+				return m, nil
+			}
+			m.replaying = true
+			return m, m.replayQueue()
+		}
+		return m, nil
+
+	case msg.String() == "U":
+		// Discard offline edits that haven't been sent yet
+		if m.currentIssue != nil && m.queue != nil && m.syncStates()[m.currentIssue.ID] != issues.SyncNone {
+			if err := m.queue.Discard(m.currentIssue.ID); err != nil {
+				m.statusMsg = "Error: " + err.Error()
+				m.statusErr = true
+				return m, nil
+			}
+			m.refreshSyncStates()
+			m.statusMsg = "Discarded offline edits for " + m.currentIssue.Identifier
+			m.statusErr = false
+			return m, m.loadIssues()
+		}
+		return m, nil
+
 	case msg.String() == "e":
 		if m.currentIssue != nil {
-			m.editView = issues.NewEditModel(m.currentIssue, m.teams, m.projects, m.states, m.users, m.labels, m.width, m.height-4)
+			m.editView = issues.NewEditModel(m.currentIssue, m.teams, m.projects, m.states, m.users, m.labels, m.cycles[issueTeamID(m.currentIssue)], m.width, m.height-4)
 			m.view = ViewEdit
 		}
 		return m, nil
@@ -842,26 +1522,40 @@ func (m Model) updateDetailView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m Model) updateSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
+		m = m.resetCompletion()
 		m.searchMode = false
 		m.searchQuery = ""
 		m.searchInput.SetValue("")
 		m.searchInput.Blur()
 		m.filteredIssues = nil
 		m.allProjectIssues = nil
-		m.listView = issues.NewListModel(m.issues, m.width, m.height-4)
+		m.listView = m.issueListView()
 		return m, nil
 
 	case "enter":
+		m = m.resetCompletion()
+		// Queries with fields are run by Linear
+		if m.parseSearch().Structured {
+			return m.submitSearch()
+		}
 		m.searchMode = false
 		m.searchInput.Blur()
 		m.allProjectIssues = nil
 		return m, nil
+
+	case "tab":
+		m = m.completeSearch()
+		m.searchQuery = m.searchInput.Value()
+		return m, nil
 	}
 
+	m = m.resetCompletion()
 	var cmd tea.Cmd
 	m.searchInput, cmd = m.searchInput.Update(msg)
 	m.searchQuery = m.searchInput.Value()
-	m.filterIssues()
+	if !m.parseSearch().Structured {
+		m.filterIssues()
+	}
 	return m, cmd
 }
 
@@ -869,7 +1563,7 @@ func (m Model) updateSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 func (m *Model) filterIssues() {
 	if m.searchQuery == "" {
 		m.filteredIssues = nil
-		m.listView = issues.NewListModel(m.issues, m.width, m.height-4)
+		m.listView = m.issueListView()
 		return
 	}
 
@@ -877,6 +1571,10 @@ func (m *Model) filterIssues() {
 	if m.activeTab == TabProject && len(m.allProjectIssues) > 0 {
 		searchSource = m.allProjectIssues
 	}
+	// Offline, search everything we have cached rather than just this tab
+	if m.offline && m.cache != nil {
+		searchSource = m.cache.Issues()
+	}
 
 	query := strings.ToLower(m.searchQuery)
 	var filtered []linear.Issue
@@ -888,14 +1586,29 @@ func (m *Model) filterIssues() {
 		}
 	}
 	m.filteredIssues = sortIssues(filtered)
-	m.listView = issues.NewListModel(m.filteredIssues, m.width, m.height-4)
+	m.listView = m.newListView(m.filteredIssues, false)
 }
 
 // updateCreateView handles updates in the create view
 func (m Model) updateCreateView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The AI prompt bar and pickers own all keys (including esc and enter) while open
+	if m.createView.InAIMode() || m.createView.PickerOpen() {
+		var cmd tea.Cmd
+		m.createView, cmd = m.createView.Update(msg)
+		return m, cmd
+	}
+
 	switch {
+	case key.Matches(msg, m.keymap.AIDraft):
+		m.createView = m.createView.StartAIDraft()
+		return m, textinput.Blink
+
+	case msg.String() == "ctrl+o":
+		return m, openEditor(editorCreate, m.createView.EditorDraft())
+
 	case msg.String() == "esc":
-		m.view = ViewList
+		m = m.cancelDraft()
+		m.view = m.createReturnView()
 		return m, nil
 
 	case msg.String() == "ctrl+s":
@@ -917,12 +1630,33 @@ func (m Model) updateCreateView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// updateSetupView handles updates in the setup view
+func (m Model) updateSetupView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "q" {
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	m.setupView, cmd = m.setupView.Update(msg)
+	return m, cmd
+}
+
 func (m Model) updateEditView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Pickers own all keys (including esc) while open
+	if m.editView.PickerOpen() {
+		var cmd tea.Cmd
+		m.editView, cmd = m.editView.Update(msg)
+		return m, cmd
+	}
+
 	switch {
 	case msg.String() == "esc":
 		m.view = ViewDetail
 		return m, nil
 
+	case msg.String() == "ctrl+o":
+		return m, openEditor(editorEdit, m.editView.EditorDraft())
+
 	case msg.String() == "ctrl+s":
 		issueID := m.editView.GetIssueID()
 		input := m.editView.GetUpdateInput()
@@ -942,14 +1676,12 @@ func (m Model) updateKanbanView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "enter":
 		if selected := m.kanbanView.SelectedIssue(); selected != nil {
-			m.currentIssue = selected
-			m.detailView = issues.NewDetailModel(selected, m.width, m.height-4)
-			m.view = ViewDetail
+			return m.openDetail(selected)
 		}
 		return m, nil
 
 	case "c":
-		m.createView = issues.NewCreateModel(m.teams, m.projects, m.states, m.users, m.labels, m.width, m.height-4)
+		m.createView = issues.NewCreateModel(m.teams, m.projects, m.states, m.users, m.labels, m.cycles[m.cycleTeamID()], m.width, m.height-4)
 		m.view = ViewCreate
 		return m, nil
 
@@ -960,7 +1692,12 @@ func (m Model) updateKanbanView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "y":
 		if selected := m.kanbanView.SelectedIssue(); selected != nil {
-			return m, m.copyToClipboard(selected.BranchName, "Branch name copied")
+			return m, m.copyToClipboard(m.branchName(selected), "Branch name copied")
+		}
+
+	case "B":
+		if selected := m.kanbanView.SelectedIssue(); selected != nil {
+			return m, m.checkoutBranch(selected)
 		}
 
 	case "o":
@@ -977,6 +1714,12 @@ func (m Model) updateKanbanView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if selected := m.kanbanView.SelectedIssue(); selected != nil {
 			return m, m.deleteIssue(selected.ID, selected.Identifier)
 		}
+
+	case "t":
+		// Open label picker (l moves between columns here)
+		if selected := m.kanbanView.SelectedIssue(); selected != nil {
+			return m.openLabelPicker(selected), nil
+		}
 	}
 
 	var cmd tea.Cmd
@@ -1007,11 +1750,32 @@ func (m Model) updatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Forward navigation keys to picker
 	var cmd tea.Cmd
 	m.picker, cmd = m.picker.Update(msg)
+	if m.pickerType == "relation-issue" {
+		var searchCmd tea.Cmd
+		m, searchCmd = m.updateRelationSearch()
+		return m, tea.Batch(cmd, searchCmd)
+	}
 	return m, cmd
 }
 
 // handlePickerSelection handles the selection from a picker
 func (m Model) handlePickerSelection(item *components.PickerItem) (tea.Model, tea.Cmd) {
+	if strings.HasPrefix(m.pickerType, "bulk-") {
+		return m.handleBulkPickerSelection(item)
+	}
+	if strings.HasPrefix(m.pickerType, "relation-") {
+		return m.handleRelationPickerSelection(item)
+	}
+	if m.pickerType == "view" {
+		return m.handleViewMenuSelection(item)
+	}
+	if m.pickerType == "linear-view" {
+		return m.handleLinearViewSelection(item)
+	}
+	if m.pickerType == "layout" {
+		return m.handleLayoutSelection(item)
+	}
+
 	defer func() {
 		m.picker = nil
 		m.pickerType = ""
@@ -1035,6 +1799,12 @@ func (m Model) handlePickerSelection(item *components.PickerItem) (tea.Model, te
 			input := linear.IssueUpdateInput{Priority: &priority}
 			return m, m.updateIssue(m.currentIssue.ID, input)
 		}
+	case "cycle":
+		if m.currentIssue != nil {
+			cycleID := item.ID
+			input := linear.IssueUpdateInput{CycleID: &cycleID}
+			return m, m.updateIssue(m.currentIssue.ID, input)
+		}
 	case "project":
 		// Handle project filter selection
 		if item.ID == "" {
@@ -1065,38 +1835,184 @@ func (m Model) handlePickerSelection(item *components.PickerItem) (tea.Model, te
 
 // createIssue creates a new issue
 func (m Model) createIssue(input linear.IssueCreateInput) tea.Cmd {
+	store := m.cache
+	mutations := m.queue
+	offline := m.offline
 	return func() tea.Msg {
+		mutation := queue.Mutation{Kind: queue.KindCreate, Create: &input}
+		if offline && mutations != nil {
+			return enqueueMutation(mutations, mutation)
+		}
+
 		ctx := context.Background()
 		issue, err := m.client.CreateIssue(ctx, input)
+		// Only a create that never reached Linear is safe to send again
+		if linear.IsUnsent(err) && mutations != nil {
+			return enqueueMutation(mutations, mutation)
+		}
+		if linear.IsNetworkError(err) {
+			err = fmt.Errorf("no answer from Linear, check whether the issue was created: %w", err)
+		}
+		if err == nil && issue != nil && store != nil {
+			store.PutIssues([]linear.Issue{*issue})
+		}
 		return IssueCreatedMsg{Issue: issue, Err: err}
 	}
 }
 
+// loadComments loads a page of comments for an issue
+func (m Model) loadComments(issueID, after string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		conn, err := m.client.GetComments(ctx, issueID, 50, after)
+		return CommentsLoadedMsg{
+			IssueID:  issueID,
+			Comments: conn.Nodes,
+			PageInfo: conn.PageInfo,
+			Append:   after != "",
+			Err:      err,
+		}
+	}
+}
+
+// saveComment creates a new comment, or updates commentID when it is set
+func (m Model) saveComment(issueID, commentID, body string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if commentID == "" {
+			comment, err := m.client.CreateComment(ctx, issueID, body)
+			return CommentSavedMsg{Comment: comment, Created: true, Err: err}
+		}
+		comment, err := m.client.UpdateComment(ctx, commentID, body)
+		return CommentSavedMsg{Comment: comment, Err: err}
+	}
+}
+
+func (m Model) deleteComment(commentID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		err := m.client.DeleteComment(ctx, commentID)
+		return CommentDeletedMsg{CommentID: commentID, Err: err}
+	}
+}
+
+// cancelDraft stops the AI draft being generated, if any. Its result, which
+// may still arrive, is ignored.
+func (m Model) cancelDraft() Model {
+	if m.draftCancel != nil {
+		m.draftCancel()
+		m.draftCancel = nil
+	}
+	m.draftSeq++
+	return m
+}
+
+// generateDraft asks the configured AI provider to draft an issue from a
+// prompt. The result carries seq so it can be matched to the request.
+func (m Model) generateDraft(ctx context.Context, seq int, prompt string) tea.Cmd {
+	aiConfig := m.config.AI
+	labelNames := make([]string, len(m.labels))
+	for i, l := range m.labels {
+		labelNames[i] = l.Name
+	}
+
+	return func() tea.Msg {
+		provider, err := ai.NewProvider(aiConfig)
+		if err != nil {
+			return AIDraftGeneratedMsg{Seq: seq, Err: err}
+		}
+
+		draft, err := provider.GenerateIssue(ctx, ai.GenerateIssueInput{
+			Prompt:          prompt,
+			AvailableLabels: labelNames,
+		})
+		return AIDraftGeneratedMsg{Seq: seq, Draft: draft, Provider: provider.Name(), Err: err}
+	}
+}
+
+// updateIssue updates an issue, journaling the change instead when offline
 func (m Model) updateIssue(issueID string, input linear.IssueUpdateInput) tea.Cmd {
+	store := m.cache
+	mutations := m.queue
+	offline := m.offline
+
+	mutation := queue.Mutation{Kind: queue.KindUpdate, IssueID: issueID, Update: &input}
+	if issue := m.findIssue(issueID); issue != nil {
+		mutation.Identifier = issue.Identifier
+		mutation.BaseUpdatedAt = issue.UpdatedAt
+	}
+
 	return func() tea.Msg {
+		if offline && mutations != nil {
+			return enqueueMutation(mutations, mutation)
+		}
+
 		ctx := context.Background()
 		issue, err := m.client.UpdateIssue(ctx, issueID, input)
+		// Updates are safe to send twice, so any network failure queues them
+		if linear.IsNetworkError(err) && mutations != nil {
+			return enqueueMutation(mutations, mutation)
+		}
+		if err == nil && issue != nil && store != nil {
+			store.PutIssues([]linear.Issue{*issue})
+		}
 		return IssueUpdatedMsg{Issue: issue, Err: err}
 	}
 }
 
 // updateIssueState updates the state of an issue
 func (m Model) updateIssueState(issueID, stateID string) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-		issue, err := m.client.UpdateIssueState(ctx, issueID, stateID)
-		return IssueUpdatedMsg{Issue: issue, Err: err}
-	}
+	return m.updateIssue(issueID, linear.IssueUpdateInput{StateID: &stateID})
 }
 
 func (m Model) deleteIssue(issueID, identifier string) tea.Cmd {
+	store := m.cache
 	return func() tea.Msg {
 		ctx := context.Background()
 		err := m.client.DeleteIssue(ctx, issueID)
+		if err == nil && store != nil {
+			store.RemoveIssue(issueID)
+		}
 		return IssueDeletedMsg{IssueID: issueID, Identifier: identifier, Err: err}
 	}
 }
 
+// showError reports err in the status bar. A rejected API key sends the user
+// to the setup view, and a rate-limit error starts a countdown until Linear
+// accepts requests again.
+func (m Model) showError(prefix string, err error) (Model, tea.Cmd) {
+	m.statusMsg = prefix + err.Error()
+	m.statusErr = true
+
+	var apiErr *linear.APIError
+	if errors.As(err, &apiErr) && apiErr.Has(linear.ErrorAuthentication) {
+		m.loading = false
+		m.setupErr = apiErr.Presentable()
+		m.view = ViewSetup
+		return m, nil
+	}
+
+	var rateErr *linear.RateLimitError
+	if !errors.As(err, &rateErr) || rateErr.ResetAt.IsZero() {
+		return m, nil
+	}
+	ticking := time.Now().Before(m.rateLimitedUntil)
+	if rateErr.ResetAt.After(m.rateLimitedUntil) {
+		m.rateLimitedUntil = rateErr.ResetAt
+	}
+	if ticking {
+		return m, nil
+	}
+	return m, rateLimitTick()
+}
+
+// rateLimitTick refreshes the rate-limit countdown once a second
+func rateLimitTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return RateLimitTickMsg{}
+	})
+}
+
 // copyToClipboard copies text to clipboard
 func (m Model) copyToClipboard(text, message string) tea.Cmd {
 	return func() tea.Msg {
@@ -1210,7 +2126,7 @@ func (m Model) View() string {
 
 	// Show setup view without header/status bar
 	if m.view == ViewSetup {
-		m.setupView = setup.New(m.width, m.height)
+		m.setupView = setup.New(m.width, m.height).SetError(m.setupErr)
 		return m.setupView.View()
 	}
 
@@ -1254,9 +2170,24 @@ func (m Model) View() string {
 	)
 
 	// Overlay picker if open
+	if m.prompt != nil {
+		return m.prompt.View()
+	}
+	if m.filterPanel != nil {
+		return m.filterPanel.View()
+	}
 	if m.picker != nil {
 		return m.picker.View()
 	}
+	if m.labelPicker != nil {
+		return m.labelPicker.View()
+	}
+	if m.columnPicker != nil {
+		return m.columnPicker.View()
+	}
+	if m.bulkLabelPicker != nil {
+		return m.bulkLabelPicker.View()
+	}
 
 	return mainView
 }
@@ -1269,6 +2200,14 @@ func (m Model) renderHeader() string {
 	if m.viewer != nil {
 		userInfo = theme.HeaderInfoStyle.Render(m.viewer.Name)
 	}
+	if m.queue != nil {
+		if n := m.queue.Len(); n > 0 {
+			userInfo = theme.WarningStyle.Render(fmt.Sprintf("⏳ %d pending", n)) + theme.HeaderInfoStyle.Render("  ") + userInfo
+		}
+	}
+	if m.offline {
+		userInfo = theme.WarningStyle.Render("⚡ Offline") + theme.HeaderInfoStyle.Render("  ") + userInfo
+	}
 
 	var tabs string
 	for i, name := range m.tabNames() {
@@ -1281,6 +2220,13 @@ func (m Model) renderHeader() string {
 
 	// Build header
 	left := title
+	if m.currentBranch != "" {
+		branch := "⎇ " + m.currentBranch
+		if m.branchIssue != nil {
+			branch += " → " + m.branchIssue.Identifier
+		}
+		left += theme.HeaderInfoStyle.Render("  " + branch)
+	}
 	right := userInfo
 	padding := m.width - lipgloss.Width(left) - lipgloss.Width(right) - 2
 	if padding < 0 {
@@ -1298,24 +2244,17 @@ func (m Model) renderHeader() string {
 
 // renderListView renders the issue list view
 func (m Model) renderListView() string {
-	if m.searchMode || m.searchQuery != "" {
-		searchBar := m.renderSearchBar()
-		return lipgloss.JoinVertical(lipgloss.Left, searchBar, m.listView.View())
+	var parts []string
+	if chips := m.renderFilterChips(); chips != "" {
+		parts = append(parts, chips)
 	}
-	return m.listView.View()
-}
-
-// renderSearchBar renders the search input bar
-func (m Model) renderSearchBar() string {
-	prefix := theme.TextDimStyle.Render("/ ")
-	input := m.searchInput.View()
-
-	count := ""
-	if m.searchQuery != "" {
-		count = theme.TextDimStyle.Render(fmt.Sprintf(" (%d results)", len(m.filteredIssues)))
+	if m.searchMode || m.searchQuery != "" {
+		parts = append(parts, m.renderSearchBar())
+	} else if m.activeTab == TabCycle {
+		parts = append(parts, m.renderCycleSummary())
 	}
-
-	return theme.SearchBarStyle.Width(m.width).Render(prefix + input + count)
+	parts = append(parts, m.listView.View())
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
 
 // renderLoading renders a loading spinner
@@ -1333,7 +2272,11 @@ func (m Model) renderLoading() string {
 func (m Model) renderStatusBar() string {
 	// Status message
 	var status string
-	if m.statusMsg != "" {
+	if wait := time.Until(m.rateLimitedUntil); wait > 0 {
+		status = theme.ErrorStyle.Render(fmt.Sprintf("Rate limited by Linear - retry in %ds", int(wait.Seconds())+1))
+	} else if m.bulk != nil {
+		status = m.renderBulkProgress()
+	} else if m.statusMsg != "" {
 		if m.statusErr {
 			status = theme.ErrorStyle.Render(m.statusMsg)
 		} else {
@@ -1353,6 +2296,24 @@ func (m Model) renderStatusBar() string {
 	return theme.StatusBarStyle.Width(m.width).Render(help)
 }
 
+// listHelpKeys are the status bar hints for the issue list
+var listHelpKeys = []struct {
+	key  string
+	desc string
+}{
+	{"j/k", "navigate"},
+	{"enter", "view"},
+	{"space", "select"},
+	{"/", "search"},
+	{"f", "filter"},
+	{"b", "board"},
+	{"c", "create"},
+	{"d", "delete"},
+	{"w", "work"},
+	{"?", "help"},
+	{"q", "quit"},
+}
+
 func (m Model) renderHelp() string {
 	var keys []struct {
 		key  string
@@ -1369,6 +2330,14 @@ func (m Model) renderHelp() string {
 			{"s", "status"},
 			{"a", "assignee"},
 			{"p", "priority"},
+			{"C", "comment"},
+			{"(/)", "select task"},
+			{"x", "check task"},
+			{"[/]", "select comment"},
+			{"{/}", "linked issues"},
+			{"R", "relations"},
+			{"u", "parent"},
+			{"E/X", "edit/delete comment"},
 			{"y", "copy branch"},
 			{"o", "open in linear"},
 			{"esc", "back"},
@@ -1388,22 +2357,27 @@ func (m Model) renderHelp() string {
 			{"esc", "list"},
 			{"?", "help"},
 		}
-	default:
+	case ViewList:
+		if !m.listView.HasSelection() {
+			keys = listHelpKeys
+			break
+		}
 		keys = []struct {
 			key  string
 			desc string
 		}{
-			{"j/k", "navigate"},
-			{"enter", "view"},
-			{"/", "search"},
-			{"P", "project"},
-			{"b", "board"},
-			{"c", "create"},
+			{"space", "toggle"},
+			{"v", "range"},
+			{"ctrl+a", "all"},
+			{"s/a/p", "status/assignee/priority"},
+			{"l", "label"},
+			{"m", "project"},
+			{"Y", "cycle"},
 			{"d", "delete"},
-			{"w", "work"},
-			{"?", "help"},
-			{"q", "quit"},
+			{"esc", "clear"},
 		}
+	default:
+		keys = listHelpKeys
 	}
 
 	var parts []string
@@ -1452,76 +2426,10 @@ func appendUniqueIssues(existing, newIssues []linear.Issue) []linear.Issue {
 	return result
 }
 
-// stateTypePriority returns the sort priority for a workflow state type.
-// Lower values appear first. Incomplete states come before completed ones.
-func stateTypePriority(stateType string) int {
-	switch stateType {
-	case "started":
-		return 0 // In progress - highest priority
-	case "unstarted":
-		return 1 // Not yet started
-	case "backlog":
-		return 2 // Backlog items
-	case "triage":
-		return 3 // Triage items
-	case "completed":
-		return 4 // Done
-	case "canceled":
-		return 5 // Canceled - lowest priority
-	default:
-		return 3 // Unknown states go in the middle
-	}
-}
-
-// sortIssues sorts issues first by completion status (incomplete first),
-// then by priority (urgent first, no priority last).
+// sortIssues sorts issues in the default order: incomplete first, then by
+// priority (urgent first, no priority last)
 func sortIssues(issuesList []linear.Issue) []linear.Issue {
-	sorted := make([]linear.Issue, len(issuesList))
-	copy(sorted, issuesList)
-
-	sort.SliceStable(sorted, func(i, j int) bool {
-		issueA := sorted[i]
-		issueB := sorted[j]
-
-		// Get state types, defaulting to "unstarted" if state is nil
-		stateTypeA := "unstarted"
-		stateTypeB := "unstarted"
-		if issueA.State != nil {
-			stateTypeA = issueA.State.Type
-		}
-		if issueB.State != nil {
-			stateTypeB = issueB.State.Type
-		}
-
-		// First sort by state type priority (completion status)
-		statePriorityA := stateTypePriority(stateTypeA)
-		statePriorityB := stateTypePriority(stateTypeB)
-		if statePriorityA != statePriorityB {
-			return statePriorityA < statePriorityB
-		}
-
-		// Then sort by priority (lower number = higher priority)
-		// Priority 0 means "no priority" and should come last within the same state
-		priorityA := issueA.Priority
-		priorityB := issueB.Priority
-
-		// Treat 0 (no priority) as lowest priority (5)
-		if priorityA == 0 {
-			priorityA = 5
-		}
-		if priorityB == 0 {
-			priorityB = 5
-		}
-
-		if priorityA != priorityB {
-			return priorityA < priorityB
-		}
-
-		// Finally, sort by updated time (most recent first) for stability
-		return issueA.UpdatedAt.After(issueB.UpdatedAt)
-	})
-
-	return sorted
+	return issues.SortIssues(issuesList, issues.SortStatus)
 }
 
 func (m Model) getClickedTab(x, y int) int {
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+
+	"github.com/brandonli/lazyliner/internal/git"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// localCommitLimit caps the number of local commits shown for an issue
+const localCommitLimit = 10
+
+// loadAttachments fetches the pull requests, commits and links attached to an issue
+func (m Model) loadAttachments(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		attachments, err := m.client.GetAttachments(context.Background(), issueID)
+		return AttachmentsLoadedMsg{IssueID: issueID, Attachments: attachments, Err: err}
+	}
+}
+
+// loadLocalCommits finds commits on local branches that mention the issue.
+// Outside a git repository there are simply none.
+func (m Model) loadLocalCommits(issueID, identifier string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := git.CommitsMentioning(identifier, localCommitLimit)
+		return LocalCommitsLoadedMsg{IssueID: issueID, Commits: commits, Err: err}
+	}
+}
+
+// handleAttachmentsLoaded shows the attachments of the open issue
+func (m Model) handleAttachmentsLoaded(msg AttachmentsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.currentIssue == nil || m.currentIssue.ID != msg.IssueID {
+		return m, nil
+	}
+	if msg.Err != nil {
+		// The offline indicator already explains missing data
+		if m.offline {
+			return m, nil
+		}
+		return m.showError("Error loading attachments: ", msg.Err)
+	}
+	m.detailView = m.detailView.SetAttachments(msg.Attachments)
+	return m, nil
+}
+
+// handleLocalCommitsLoaded shows the local commits of the open issue
+func (m Model) handleLocalCommitsLoaded(msg LocalCommitsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.currentIssue == nil || m.currentIssue.ID != msg.IssueID || msg.Err != nil {
+		return m, nil
+	}
+	m.detailView = m.detailView.SetCommits(msg.Commits)
+	return m, nil
+}
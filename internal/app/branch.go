@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+
+	"github.com/brandonli/lazyliner/internal/git"
+	"github.com/brandonli/lazyliner/internal/linear"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// branchName renders the configured git branch name for an issue
+func (m Model) branchName(issue *linear.Issue) string {
+	info := git.BranchInfo{
+		Identifier: issue.Identifier,
+		Title:      issue.Title,
+	}
+	if issue.Team != nil {
+		info.TeamKey = issue.Team.Key
+	}
+	if issue.Assignee != nil {
+		info.Assignee = issue.Assignee.DisplayName
+		if info.Assignee == "" {
+			info.Assignee = issue.Assignee.Name
+		}
+	}
+	for _, label := range issue.Labels {
+		info.Labels = append(info.Labels, label.Name)
+	}
+
+	return git.BranchName(info, git.BranchOptions{
+		Format:     m.config.Git.BranchFormat,
+		Prefix:     m.config.Git.BranchPrefix,
+		MaxLength:  m.config.Git.BranchMaxLength,
+		TypeLabels: m.config.Git.TypeLabels,
+	})
+}
+
+// checkoutBranch creates (if needed) and checks out the issue's branch in
+// the current repository
+func (m Model) checkoutBranch(issue *linear.Issue) tea.Cmd {
+	issueID := issue.ID
+	branch := m.branchName(issue)
+	return func() tea.Msg {
+		created, err := git.CheckoutBranch(branch)
+		return BranchCheckedOutMsg{IssueID: issueID, Branch: branch, Created: created, Err: err}
+	}
+}
+
+// handleBranchCheckedOut reports the checkout and, if configured, starts the issue
+func (m Model) handleBranchCheckedOut(msg BranchCheckedOutMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m.showError("Checkout failed: ", msg.Err)
+	}
+
+	if msg.Created {
+		m.statusMsg = "Created and checked out " + msg.Branch
+	} else {
+		m.statusMsg = "Checked out " + msg.Branch
+	}
+	m.statusErr = false
+
+	if !m.config.Git.StartOnCheckout {
+		return m, nil
+	}
+	issue := m.findIssue(msg.IssueID)
+	if issue == nil || !canStart(issue) {
+		return m, nil
+	}
+	// Only the first team's workflow states are kept loaded; other teams'
+	// are fetched for the issue
+	teamID := issueTeamID(issue)
+	if teamID == "" && len(m.teams) > 0 {
+		teamID = m.teams[0].ID
+	}
+	switch {
+	case len(m.teams) > 0 && teamID == m.teams[0].ID && len(m.states) > 0:
+		return m.startIssue(issue.ID, startedState(m.states))
+	case teamID != "":
+		return m, m.loadStartedState(issue.ID, teamID)
+	}
+	m.statusMsg += ", but the issue's team is unknown so its state wasn't changed"
+	m.statusErr = true
+	return m, nil
+}
+
+// startIssue moves an issue to its team's started state after a checkout
+func (m Model) startIssue(issueID string, state *linear.WorkflowState) (Model, tea.Cmd) {
+	if state == nil {
+		m.statusMsg += ", but the issue's team has no started state so its state wasn't changed"
+		m.statusErr = true
+		return m, nil
+	}
+	m.statusMsg += ", moving to " + state.Name
+	return m, m.updateIssueState(issueID, state.ID)
+}
+
+// loadStartedState loads the workflow states of an issue's team to find the
+// state to start it in
+func (m Model) loadStartedState(issueID, teamID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		states, err := m.client.GetWorkflowStates(ctx, teamID)
+		return StartedStateLoadedMsg{IssueID: issueID, State: startedState(states), Err: err}
+	}
+}
+
+// handleStartedStateLoaded starts the checked-out issue of another team
+func (m Model) handleStartedStateLoaded(msg StartedStateLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m.showError("Issue state not changed: ", msg.Err)
+	}
+	return m.startIssue(msg.IssueID, msg.State)
+}
+
+// canStart reports whether an issue can be moved to a started state on
+// checkout: it isn't already started or closed
+func canStart(issue *linear.Issue) bool {
+	if issue.State == nil {
+		return true
+	}
+	switch issue.State.Type {
+	case "started", "completed", "canceled":
+		return false
+	}
+	return true
+}
+
+// startedState returns the first "started" workflow state of a team's
+// states, or nil if there is none
+func startedState(states []linear.WorkflowState) *linear.WorkflowState {
+	var started *linear.WorkflowState
+	for i := range states {
+		if states[i].Type == "started" && (started == nil || states[i].Position < started.Position) {
+			started = &states[i]
+		}
+	}
+	return started
+}
+
+// loadBranchIssue loads the issue named by the branch lazyliner was started on
+func (m Model) loadBranchIssue() tea.Cmd {
+	if m.currentBranch == "" {
+		return nil
+	}
+	teamKeys := make([]string, len(m.teams))
+	for i, team := range m.teams {
+		teamKeys[i] = team.Key
+	}
+	identifier, ok := git.IssueIdentifier(m.currentBranch, m.config.Git.BranchFormat, teamKeys)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		issue, err := m.client.GetIssue(ctx, identifier)
+		return BranchIssueLoadedMsg{Issue: issue, Err: err}
+	}
+}
+
+// handleBranchIssueLoaded opens the current branch's issue on launch
+func (m Model) handleBranchIssueLoaded(msg BranchIssueLoadedMsg) (tea.Model, tea.Cmd) {
+	// A branch that merely looks like an issue isn't worth an error
+	if msg.Err != nil || msg.Issue == nil {
+		return m, nil
+	}
+
+	// Only jump on launch, not when the data is reloaded later
+	opened := m.branchIssue != nil
+	m.branchIssue = msg.Issue
+	if opened || m.view != ViewList {
+		return m, nil
+	}
+	return m.openDetail(m.branchIssue)
+}
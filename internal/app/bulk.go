@@ -0,0 +1,372 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/queue"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkConcurrency caps how many issues a batch action sends at once
+const bulkConcurrency = 4
+
+// bulkOp tracks a batch action running against the selected issues
+type bulkOp struct {
+	id       int
+	action   string // Progress label, e.g. "Updating status"
+	total    int
+	done     int
+	queued   int
+	failures []string
+}
+
+// updateBulkKeys handles list keys that act on the multi-selection. It
+// reports false for keys that aren't batch actions.
+func (m Model) updateBulkKeys(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.confirmBulkDelete {
+		m.confirmBulkDelete = false
+		if msg.String() == "d" {
+			if m.offline {
+				return m.offlineDeleteBlocked(), nil, true
+			}
+			m, cmd := m.bulkDelete()
+			return m, cmd, true
+		}
+		m.statusMsg = "Delete cancelled"
+		m.statusErr = false
+		return m, nil, true
+	}
+
+	var title, pickerType string
+	var items []components.PickerItem
+
+	switch msg.String() {
+	case "s":
+		title, pickerType, items = "Set Status", "bulk-status", m.statesToItems()
+	case "a":
+		title, pickerType, items = "Set Assignee", "bulk-assignee", m.usersToItems()
+	case "p":
+		title, pickerType, items = "Set Priority", "bulk-priority", m.priorityItems()
+	case "l":
+		if m.bulk == nil {
+			return m.openBulkLabelPicker(), nil, true
+		}
+	case "m":
+		title, pickerType, items = "Move to Project", "bulk-project", m.bulkProjectItems()
+	case "Y":
+		// Cycles belong to a team, so every selected issue must share one
+		teamID, ok := m.selectionTeamID()
+		if !ok {
+			m.statusMsg = "Selected issues are in different teams"
+			m.statusErr = true
+			return m, nil, true
+		}
+		cycles, loaded := m.cycles[teamID]
+		if !loaded && teamID != "" {
+			m.statusMsg = "Cycles are still loading, try again"
+			m.statusErr = true
+			return m, m.loadCycles(teamID), true
+		}
+		title, pickerType, items = "Move to Cycle", "bulk-cycle", issues.CyclesToItems(cycles)
+	case "d":
+		if m.bulk != nil {
+			break
+		}
+		if m.offline {
+			return m.offlineDeleteBlocked(), nil, true
+		}
+		m.confirmBulkDelete = true
+		m.statusMsg = fmt.Sprintf("Delete %d issues? Press d again to confirm", m.listView.SelectionCount())
+		m.statusErr = true
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+
+	if m.bulk != nil {
+		m.statusMsg = "A batch action is already running"
+		m.statusErr = true
+		return m, nil, true
+	}
+
+	if len(items) == 0 {
+		m.statusMsg = "Nothing to choose from"
+		m.statusErr = true
+		return m, nil, true
+	}
+
+	title = fmt.Sprintf("%s (%d issues)", title, m.listView.SelectionCount())
+	m.picker = components.NewPickerModel(title, items, m.width, m.height)
+	m.pickerType = pickerType
+	return m, nil, true
+}
+
+// selectionTeamID returns the team of the selected issues. It reports false
+// if they are in more than one team.
+func (m Model) selectionTeamID() (string, bool) {
+	teamID := ""
+	for i, issue := range m.listView.SelectedIssues() {
+		id := issueTeamID(&issue)
+		if i > 0 && id != teamID {
+			return "", false
+		}
+		teamID = id
+	}
+	return teamID, true
+}
+
+// offlineDeleteBlocked explains why issues can't be deleted. Deletes aren't
+// journaled for replay like edits, so they need the API.
+func (m Model) offlineDeleteBlocked() Model {
+	m.statusMsg = "Can't delete issues while offline"
+	m.statusErr = true
+	return m
+}
+
+// handleBulkPickerSelection runs the batch action chosen from a bulk picker
+func (m Model) handleBulkPickerSelection(item *components.PickerItem) (tea.Model, tea.Cmd) {
+	pickerType := m.pickerType
+	m.picker = nil
+	m.pickerType = ""
+
+	var input linear.IssueUpdateInput
+	var action string
+	id := item.ID
+
+	switch pickerType {
+	case "bulk-status":
+		input.StateID = &id
+		action = "Setting status to " + item.Label
+	case "bulk-assignee":
+		input.AssigneeID = &id
+		action = "Assigning to " + item.Label
+	case "bulk-priority":
+		priority := 0
+		fmt.Sscanf(item.ID, "%d", &priority)
+		input.Priority = &priority
+		action = "Setting priority to " + item.Label
+	case "bulk-project":
+		input.ProjectID = &id
+		action = "Moving to " + item.Label
+	case "bulk-cycle":
+		input.CycleID = &id
+		action = "Moving to " + item.Label
+		if id == "" {
+			action = "Removing from cycle"
+		}
+	default:
+		return m, nil
+	}
+
+	return m.startBulk(action, m.bulkUpdate(input))
+}
+
+// openBulkLabelPicker opens the label picker for the selected issues, with
+// the labels every one of them has checked
+func (m Model) openBulkLabelPicker() Model {
+	targets := m.listView.SelectedIssues()
+	labels := m.labels[:len(m.labels):len(m.labels)]
+	counts := make(map[string]int)
+	for _, issue := range targets {
+		for _, label := range issue.Labels {
+			counts[label.ID]++
+			if !issues.HasLabel(labels, label.ID) {
+				labels = append(labels, label)
+			}
+		}
+	}
+
+	m.bulkLabels = nil
+	for _, label := range labels {
+		if counts[label.ID] == len(targets) {
+			m.bulkLabels = append(m.bulkLabels, label.ID)
+		}
+	}
+
+	title := fmt.Sprintf("Labels (%d issues)", len(targets))
+	m.bulkLabelPicker = components.NewMultiPickerModel(title, issues.LabelsToItems(labels), m.bulkLabels, m.width, m.height)
+	return m
+}
+
+// updateBulkLabelPicker handles the bulk label picker. Checking a label adds
+// it to every selected issue and unchecking one removes it from all of them;
+// labels left as they were aren't touched.
+func (m Model) updateBulkLabelPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.bulkLabelPicker = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.bulkLabelPicker, cmd = m.bulkLabelPicker.Update(msg)
+	if !m.bulkLabelPicker.Confirmed() {
+		return m, cmd
+	}
+
+	added, removed := issues.LabelChanges(m.bulkLabels, m.bulkLabelPicker.SelectedIDs())
+	m.bulkLabelPicker = nil
+	m.bulkLabels = nil
+	if len(added) == 0 && len(removed) == 0 {
+		return m, nil
+	}
+	input := linear.IssueUpdateInput{AddedLabelIDs: added, RemovedLabelIDs: removed}
+	return m.startBulk("Updating labels", m.bulkUpdate(input))
+}
+
+// bulkTask runs a batch action against one issue
+type bulkTask func(ctx context.Context, issue linear.Issue) BulkItemDoneMsg
+
+// startBulk runs task against every selected issue, at most
+// bulkConcurrency at a time
+func (m Model) startBulk(action string, task bulkTask) (Model, tea.Cmd) {
+	targets := m.listView.SelectedIssues()
+	if len(targets) == 0 {
+		return m, nil
+	}
+
+	m.bulkSeq++
+	m.bulk = &bulkOp{id: m.bulkSeq, action: action, total: len(targets)}
+	m.statusMsg = ""
+	m.statusErr = false
+
+	opID := m.bulkSeq
+	sem := make(chan struct{}, bulkConcurrency)
+	cmds := make([]tea.Cmd, len(targets))
+	for i, issue := range targets {
+		issue := issue
+		cmds[i] = func() tea.Msg {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			msg := task(context.Background(), issue)
+			msg.OpID = opID
+			msg.IssueID = issue.ID
+			msg.Identifier = issue.Identifier
+			return msg
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// bulkUpdate returns a task applying input to an issue. Edits that fail to
+// reach the API are journaled like single edits.
+func (m Model) bulkUpdate(input linear.IssueUpdateInput) bulkTask {
+	store := m.cache
+	mutations := m.queue
+	client := m.client
+	return func(ctx context.Context, issue linear.Issue) BulkItemDoneMsg {
+		updated, err := client.UpdateIssue(ctx, issue.ID, input)
+		if linear.IsNetworkError(err) && mutations != nil {
+			input := input
+			err = mutations.Enqueue(queue.Mutation{
+				Kind:          queue.KindUpdate,
+				IssueID:       issue.ID,
+				Identifier:    issue.Identifier,
+				Update:        &input,
+				BaseUpdatedAt: issue.UpdatedAt,
+			})
+			return BulkItemDoneMsg{Queued: err == nil, Update: &input, Err: err}
+		}
+		if err == nil && updated != nil && store != nil {
+			store.PutIssues([]linear.Issue{*updated})
+		}
+		return BulkItemDoneMsg{Issue: updated, Err: err}
+	}
+}
+
+// bulkDelete deletes every selected issue
+func (m Model) bulkDelete() (Model, tea.Cmd) {
+	store := m.cache
+	client := m.client
+	return m.startBulk("Deleting", func(ctx context.Context, issue linear.Issue) BulkItemDoneMsg {
+		err := client.DeleteIssue(ctx, issue.ID)
+		if err == nil && store != nil {
+			store.RemoveIssue(issue.ID)
+		}
+		return BulkItemDoneMsg{Err: err}
+	})
+}
+
+// handleBulkItemDone records one finished item and reports a summary once
+// the whole batch is done
+func (m Model) handleBulkItemDone(msg BulkItemDoneMsg) (tea.Model, tea.Cmd) {
+	if m.bulk == nil || m.bulk.id != msg.OpID {
+		return m, nil
+	}
+
+	op := m.bulk
+	op.done++
+	switch {
+	case msg.Err != nil:
+		op.failures = append(op.failures, msg.Identifier+" ("+msg.Err.Error()+")")
+	case msg.Queued:
+		op.queued++
+	}
+	var cacheCmd tea.Cmd
+	for i := range m.issues {
+		if m.issues[i].ID != msg.IssueID {
+			continue
+		}
+		if msg.Issue != nil {
+			m.issues[i] = *msg.Issue
+		} else if msg.Queued && msg.Update != nil {
+			m.applyUpdate(&m.issues[i], *msg.Update)
+			cacheCmd = m.cacheIssues(m.issues[i])
+		}
+		break
+	}
+
+	if op.done < op.total {
+		return m, cacheCmd
+	}
+
+	// Batch finished
+	m.bulk = nil
+	m.listView = m.listView.ClearSelection()
+	succeeded := op.total - len(op.failures) - op.queued
+
+	summary := fmt.Sprintf("%s: %d/%d done", op.action, succeeded, op.total)
+	if op.queued > 0 {
+		summary += fmt.Sprintf(", %d queued offline", op.queued)
+	}
+	if len(op.failures) > 0 {
+		summary += fmt.Sprintf(", %d failed: %s", len(op.failures), strings.Join(op.failures, ", "))
+	}
+	m.statusMsg = summary
+	m.statusErr = len(op.failures) > 0
+
+	// Queued edits only exist locally, so a reload would hide them
+	if op.queued > 0 {
+		m.issues = sortIssues(m.issues)
+		m.listView = m.issueListView()
+		m, cmd := m.setOffline()
+		return m, tea.Batch(cacheCmd, cmd)
+	}
+	return m, m.loadIssues()
+}
+
+// renderBulkProgress renders the progress of the running batch action
+func (m Model) renderBulkProgress() string {
+	op := m.bulk
+	const barWidth = 20
+	filled := barWidth * op.done / op.total
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+	text := fmt.Sprintf("%s %s %s %d/%d", m.spinner.View(), op.action, bar, op.done, op.total)
+	if len(op.failures) > 0 {
+		text += fmt.Sprintf(" (%d failed)", len(op.failures))
+	}
+	return theme.WarningStyle.Render(text)
+}
+
+// bulkProjectItems returns project picker items for moving issues
+func (m Model) bulkProjectItems() []components.PickerItem {
+	// Drop the "All Projects" filter option
+	return m.projectsToItems()[1:]
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleTask writes a description with a task item checked or unchecked
+// back to Linear. The loaded copies change right away so the list and
+// kanban progress follow, and go back if Linear rejects the change.
+func (m Model) toggleTask(msg issues.ToggleTaskMsg) (tea.Model, tea.Cmd) {
+	issue := m.findIssue(msg.IssueID)
+	if issue == nil {
+		return m, nil
+	}
+	previous := issue.Description
+
+	update := m.updateIssue(msg.IssueID, linear.IssueUpdateInput{Description: &msg.Description})
+	m = m.setDescription(msg.IssueID, msg.Description)
+
+	return m, func() tea.Msg {
+		result := update()
+		if updated, ok := result.(IssueUpdatedMsg); ok && updated.Err != nil {
+			return TaskToggleFailedMsg{IssueID: msg.IssueID, Description: previous, Err: updated.Err}
+		}
+		return result
+	}
+}
+
+// handleTaskToggleFailed restores the description from before a failed toggle
+func (m Model) handleTaskToggleFailed(msg TaskToggleFailedMsg) (tea.Model, tea.Cmd) {
+	m = m.setDescription(msg.IssueID, msg.Description)
+	return m.showError("Error updating checklist: ", msg.Err)
+}
+
+// setDescription replaces the description of the loaded copies of an issue
+func (m Model) setDescription(issueID, description string) Model {
+	for i := range m.issues {
+		if m.issues[i].ID == issueID {
+			m.issues[i].Description = description
+			m.listView = m.issueListView()
+			break
+		}
+	}
+	if m.currentIssue != nil && m.currentIssue.ID == issueID {
+		issue := *m.currentIssue
+		issue.Description = description
+		m.currentIssue = &issue
+		m.detailView = m.detailView.SetIssue(m.currentIssue)
+	}
+	return m
+}
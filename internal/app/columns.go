@@ -0,0 +1,181 @@
+package app
+
+import (
+	"github.com/brandonli/lazyliner/internal/config"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// columnConfig returns the issue list columns from the config, or the
+// default ones if it doesn't list any
+func (m Model) columnConfig() []config.ColumnConfig {
+	if len(m.config.UI.Columns) > 0 {
+		return m.config.UI.Columns
+	}
+	var columns []config.ColumnConfig
+	for _, col := range issues.DefaultColumns() {
+		columns = append(columns, config.ColumnConfig{Name: string(col.Column)})
+	}
+	return columns
+}
+
+// viewColumns returns the active view's columns if it lists any, taking
+// widths from ui.columns
+func (m Model) viewColumns() ([]config.ColumnConfig, bool) {
+	view, ok := m.viewForTab(m.activeTab)
+	if !ok || len(view.Columns) == 0 {
+		return nil, false
+	}
+	widths := make(map[issues.Column]config.ColumnConfig)
+	for _, col := range m.columnConfig() {
+		if c, ok := issues.ParseColumn(col.Name); ok {
+			widths[c] = col
+		}
+	}
+
+	var columns []config.ColumnConfig
+	for _, name := range view.Columns {
+		col := config.ColumnConfig{Name: name}
+		if c, ok := issues.ParseColumn(name); ok {
+			col.Width, col.HideBelow = widths[c].Width, widths[c].HideBelow
+		}
+		columns = append(columns, col)
+	}
+	return columns, true
+}
+
+// listColumns returns the columns the issue list shows: the active view's,
+// or else ui.columns. Unknown names are skipped, and ui.show_ids turns the
+// identifier column off.
+func (m Model) listColumns() []issues.ColumnSpec {
+	configured, ok := m.viewColumns()
+	if !ok {
+		configured = m.columnConfig()
+	}
+
+	var columns []issues.ColumnSpec
+	for _, col := range configured {
+		c, ok := issues.ParseColumn(col.Name)
+		if !ok || col.Hidden || (c == issues.ColumnIdentifier && !m.config.UI.ShowIDs) {
+			continue
+		}
+		columns = append(columns, issues.ColumnSpec{Column: c, Width: col.Width, HideBelow: col.HideBelow})
+	}
+	if len(columns) == 0 {
+		return []issues.ColumnSpec{{Column: issues.ColumnTitle}}
+	}
+	return columns
+}
+
+// openColumnMenu opens the menu for turning list columns on and off
+func (m Model) openColumnMenu() Model {
+	var items []components.PickerItem
+	for _, col := range issues.Columns {
+		items = append(items, components.PickerItem{ID: string(col), Label: col.Label()})
+	}
+	var checked []string
+	for _, col := range m.listColumns() {
+		checked = append(checked, string(col.Column))
+	}
+
+	m.columnPicker = components.NewMultiPickerModel("Columns", items, checked, m.width, m.height)
+	return m
+}
+
+// updateColumnPicker handles keys while the column menu is open
+func (m Model) updateColumnPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.columnPicker = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.columnPicker, cmd = m.columnPicker.Update(msg)
+	if m.columnPicker.Confirmed() {
+		selected := m.columnPicker.SelectedIDs()
+		m.columnPicker = nil
+		return m.setColumns(selected)
+	}
+	return m, cmd
+}
+
+// setColumns shows the named columns and hides the rest, keeping the
+// configured order and widths. Newly shown columns go at the end. A view
+// with its own columns keeps them in the view, other tabs use ui.columns.
+func (m Model) setColumns(names []string) (tea.Model, tea.Cmd) {
+	shown := make(map[issues.Column]bool)
+	for _, name := range names {
+		shown[issues.Column(name)] = true
+	}
+
+	if _, ok := m.viewColumns(); ok {
+		var err error
+		if m, err = m.setViewColumns(shown); err != nil {
+			return m.showError("Failed to save columns: ", err)
+		}
+	} else {
+		var columns []config.ColumnConfig
+		listed := make(map[issues.Column]bool)
+		for _, col := range m.columnConfig() {
+			if c, ok := issues.ParseColumn(col.Name); ok {
+				listed[c] = true
+				col.Hidden = !shown[c]
+			}
+			columns = append(columns, col)
+		}
+		for _, c := range issues.Columns {
+			if shown[c] && !listed[c] {
+				columns = append(columns, config.ColumnConfig{Name: string(c)})
+			}
+		}
+
+		if err := config.SaveColumns(columns); err != nil {
+			return m.showError("Failed to save columns: ", err)
+		}
+		m.config.UI.Columns = columns
+	}
+
+	// Turning the identifier column on overrides show_ids
+	if shown[issues.ColumnIdentifier] && !m.config.UI.ShowIDs {
+		if err := config.SaveShowIDs(true); err != nil {
+			return m.showError("Failed to save columns: ", err)
+		}
+		m.config.UI.ShowIDs = true
+	}
+
+	m.listView = m.listView.SetColumns(m.listColumns())
+	return m, nil
+}
+
+// setViewColumns saves the shown columns to the active view. A view lists
+// only the columns it shows, so hidden ones are dropped from it.
+func (m Model) setViewColumns(shown map[issues.Column]bool) (Model, error) {
+	index := int(m.activeTab - TabView)
+
+	var columns []string
+	listed := make(map[issues.Column]bool)
+	for _, name := range m.views[index].Columns {
+		if c, ok := issues.ParseColumn(name); ok && shown[c] && !listed[c] {
+			listed[c] = true
+			columns = append(columns, name)
+		}
+	}
+	for _, c := range issues.Columns {
+		if shown[c] && !listed[c] {
+			columns = append(columns, string(c))
+		}
+	}
+	if len(columns) == 0 {
+		// An empty list would fall back to ui.columns
+		columns = []string{string(issues.ColumnTitle)}
+	}
+
+	views := append([]config.ViewConfig(nil), m.views...)
+	views[index].Columns = columns
+	if err := config.SaveViews(views); err != nil {
+		return m, err
+	}
+	m.views = views
+	return m, nil
+}
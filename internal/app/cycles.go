@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/cache"
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadCycles loads the current and upcoming cycles of a team
+func (m Model) loadCycles(teamID string) tea.Cmd {
+	if teamID == "" {
+		return nil
+	}
+	store := m.cache
+	return func() tea.Msg {
+		ctx := context.Background()
+		cycles, err := m.client.GetCycles(ctx, teamID)
+		if err == nil && store != nil {
+			store.Put(cache.CyclesKey(teamID), cycles)
+		}
+		return CyclesLoadedMsg{TeamID: teamID, Cycles: cycles, Err: err}
+	}
+}
+
+// loadAllCycles loads the current and upcoming cycles of every team
+func (m Model) loadAllCycles() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, team := range m.teams {
+		cmds = append(cmds, m.loadCycles(team.ID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// cycleTeamID returns the team whose active cycle the Cycle tab shows
+func (m Model) cycleTeamID() string {
+	if len(m.teams) == 0 {
+		return ""
+	}
+	return m.teams[0].ID
+}
+
+// issueTeamID returns the ID of the issue's team, or "" if it isn't known
+func issueTeamID(issue *linear.Issue) string {
+	if issue == nil || issue.Team == nil {
+		return ""
+	}
+	return issue.Team.ID
+}
+
+// activeCycle returns the current cycle of the Cycle tab's team, if it has one
+func (m Model) activeCycle() *linear.Cycle {
+	cycles := m.cycles[m.cycleTeamID()]
+	for i := range cycles {
+		if cycles[i].IsActive {
+			return &cycles[i]
+		}
+	}
+	return nil
+}
+
+// findCycle returns the loaded cycle with the given ID, of any team
+func (m Model) findCycle(id string) *linear.Cycle {
+	for _, cycles := range m.cycles {
+		for i := range cycles {
+			if cycles[i].ID == id {
+				cycle := cycles[i]
+				return &cycle
+			}
+		}
+	}
+	return nil
+}
+
+// openCyclePicker opens the picker to move an issue to another cycle of its
+// team, loading the team's cycles first if they haven't been
+func (m Model) openCyclePicker(issue *linear.Issue) (Model, tea.Cmd) {
+	teamID := issueTeamID(issue)
+	if _, ok := m.cycles[teamID]; !ok && teamID != "" {
+		m.cyclePickerIssue = issue
+		m.statusMsg = "Loading cycles..."
+		m.statusErr = false
+		return m, m.loadCycles(teamID)
+	}
+	return m.showCyclePicker(issue), nil
+}
+
+// showCyclePicker opens the cycle picker with the loaded cycles of the
+// issue's team
+func (m Model) showCyclePicker(issue *linear.Issue) Model {
+	cycles := m.cycles[issueTeamID(issue)]
+	if len(cycles) == 0 && issue.Cycle == nil {
+		m.statusMsg = "No current or upcoming cycles"
+		m.statusErr = true
+		return m
+	}
+	m.picker = components.NewPickerModel("Move to Cycle", issues.CyclesToItems(cycles), m.width, m.height)
+	m.pickerType = "cycle"
+	m.currentIssue = issue
+	return m
+}
+
+// renderCycleSummary renders the active cycle's name, progress and time left
+// above the Cycle tab's issue list
+func (m Model) renderCycleSummary() string {
+	cycle := m.activeCycle()
+	if cycle == nil {
+		return theme.SearchBarStyle.Width(m.width).Render(theme.TextDimStyle.Render("No active cycle"))
+	}
+
+	const barWidth = 20
+	percent := int(math.Round(cycle.Progress * 100))
+	filled := min(barWidth*percent/100, barWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+
+	days := issues.CycleDaysLeft(*cycle)
+	left := fmt.Sprintf("%d days left", days)
+	if days == 1 {
+		left = "1 day left"
+	}
+
+	text := fmt.Sprintf("%s · %s %d%% · %s · %s",
+		theme.TitleStyle.Render(issues.CycleLabel(*cycle)),
+		bar,
+		percent,
+		cycle.EndsAt.Format("ends Jan 2"),
+		left,
+	)
+	return theme.SearchBarStyle.Width(m.width).Render(text)
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"os"
+
+	"github.com/brandonli/lazyliner/internal/editor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Drafts that can be edited in $EDITOR
+const (
+	editorCreate  = "create"
+	editorEdit    = "edit"
+	editorComment = "comment"
+)
+
+// openEditor suspends the TUI and opens text in the user's editor as a
+// temporary markdown file. The saved text comes back in an EditorClosedMsg
+// once the editor exits.
+func openEditor(target, text string) tea.Cmd {
+	path, err := editor.WriteTemp(text)
+	if err != nil {
+		return func() tea.Msg {
+			return EditorClosedMsg{Target: target, Err: err}
+		}
+	}
+
+	return tea.ExecProcess(editor.Command(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return EditorClosedMsg{Target: target, Err: err}
+		}
+		data, err := os.ReadFile(path)
+		return EditorClosedMsg{Target: target, Text: string(data), Err: err}
+	})
+}
+
+// handleEditorClosed loads a draft saved in $EDITOR back into the form or
+// compose box it came from, if that's still open
+func (m Model) handleEditorClosed(msg EditorClosedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m.showError("Editor: ", msg.Err)
+	}
+
+	switch msg.Target {
+	case editorCreate:
+		if m.view == ViewCreate {
+			m.createView = m.createView.ApplyEditorDraft(msg.Text)
+		}
+	case editorEdit:
+		if m.view == ViewEdit {
+			m.editView = m.editView.ApplyEditorDraft(msg.Text)
+		}
+	case editorComment:
+		if m.view == ViewDetail {
+			m.detailView = m.detailView.SetComposeBody(msg.Text)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/views/filter"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterOptions returns the values the filter panel offers
+func (m Model) filterOptions() filter.Options {
+	return filter.Options{
+		Teams:    m.teams,
+		Users:    m.users,
+		States:   m.states,
+		Labels:   m.labels,
+		Projects: m.projects,
+		Cycles:   m.cycles[m.cycleTeamID()],
+	}
+}
+
+// panelFilter returns the user's filters, including the project filter
+func (m Model) panelFilter() linear.IssueFilter {
+	f := m.filter
+	if m.filterProject != nil {
+		f.ProjectID = m.filterProject.ID
+	}
+	return f
+}
+
+// hasFilter reports whether any filter beyond the tab's own is active
+func (m Model) hasFilter() bool {
+	return !filter.IsEmpty(m.panelFilter())
+}
+
+// openFilterPanel shows the filter panel over the list
+func (m Model) openFilterPanel() Model {
+	panel := filter.New(m.panelFilter(), m.filterOptions(), m.width, m.height)
+	m.filterPanel = &panel
+	return m
+}
+
+// updateFilterPanel handles keys while the filter panel is open
+func (m Model) updateFilterPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !m.filterPanel.Editing() && (msg.String() == "esc" || msg.String() == "f" || msg.String() == "q") {
+		m.filterPanel = nil
+		return m, nil
+	}
+
+	panel, cmd := m.filterPanel.Update(msg)
+	m.filterPanel = &panel
+	return m, cmd
+}
+
+// handleFilterChanged applies the filter chosen in the panel and reloads
+func (m Model) handleFilterChanged(msg filter.ChangedMsg) (tea.Model, tea.Cmd) {
+	return m.setFilter(msg.Filter)
+}
+
+// setFilter replaces the user's filters and reloads the list
+func (m Model) setFilter(f linear.IssueFilter) (tea.Model, tea.Cmd) {
+	// The project filter is kept in filterProject, which the "P" picker shares
+	m.filterProject = nil
+	for i := range m.projects {
+		if m.projects[i].ID == f.ProjectID {
+			m.filterProject = &m.projects[i]
+			break
+		}
+	}
+	f.ProjectID = ""
+	m.filter = f
+
+	m.loading = true
+	return m, m.loadIssues()
+}
+
+// clearFilters removes every filter
+func (m Model) clearFilters() (tea.Model, tea.Cmd) {
+	if !m.hasFilter() {
+		return m, nil
+	}
+	m.statusMsg = "Filters cleared"
+	m.statusErr = false
+	return m.setFilter(filter.ClearAll(m.panelFilter()))
+}
+
+// tabIssueFilter combines the active tab's filter with the user's filters.
+// The user's filters take precedence where both set the same field.
+func (m Model) tabIssueFilter(limit int, cursor string) (linear.IssueFilter, error) {
+	f := m.panelFilter()
+	f.Limit = limit
+	f.After = cursor
+
+	if view, ok := m.viewForTab(m.activeTab); ok {
+		base, err := filter.FromConfig(view.Filter, time.Now())
+		if err != nil {
+			return linear.IssueFilter{}, fmt.Errorf("view %q: %w", view.Name, err)
+		}
+		return mergeFilter(base, f), nil
+	}
+
+	switch m.activeTab {
+	case TabMyIssues:
+		if m.viewer != nil && f.AssigneeID == "" && !f.NoAssignee {
+			f.AssigneeID = m.viewer.ID
+		}
+	case TabActive:
+		f.StateType = "started"
+	case TabBacklog:
+		f.StateType = "backlog"
+	case TabCycle:
+		if cycle := m.activeCycle(); cycle != nil && f.CycleID == "" {
+			f.CycleID = cycle.ID
+		}
+	case TabProject:
+		if m.currentProject != nil && m.filterProject == nil {
+			f.ProjectID = m.currentProject.ID
+		}
+	}
+	return f, nil
+}
+
+// mergeFilter applies the filters set in f on top of base, such as a view's
+// or tab's own filter. Each kind of filter f sets, by ID or by name, replaces
+// base's; the ones f leaves empty are kept.
+func mergeFilter(base, f linear.IssueFilter) linear.IssueFilter {
+	if f.TeamID != "" || f.TeamKey != "" {
+		base.TeamID = f.TeamID
+		base.TeamKey = f.TeamKey
+	}
+	if f.ProjectID != "" || f.ProjectName != "" {
+		base.ProjectID = f.ProjectID
+		base.ProjectName = f.ProjectName
+	}
+	if f.CycleID != "" || f.CycleName != "" {
+		base.CycleID = f.CycleID
+		base.CycleName = f.CycleName
+	}
+	if f.StateType != "" || len(f.States) > 0 || len(f.StateNames) > 0 {
+		base.StateType = f.StateType
+		base.States = f.States
+		base.StateNames = f.StateNames
+	}
+	if len(f.Labels) > 0 || len(f.LabelNames) > 0 {
+		base.Labels = f.Labels
+		base.LabelNames = f.LabelNames
+	}
+	if f.AssigneeID != "" || f.NoAssignee || f.AssigneeName != "" {
+		base.AssigneeID = f.AssigneeID
+		base.NoAssignee = f.NoAssignee
+		base.AssigneeName = f.AssigneeName
+	}
+	if len(f.Priorities) > 0 {
+		base.Priorities = f.Priorities
+	}
+	if !f.Due.IsZero() {
+		base.Due = f.Due
+	}
+	if !f.Created.IsZero() {
+		base.Created = f.Created
+	}
+	if !f.Updated.IsZero() {
+		base.Updated = f.Updated
+	}
+	if f.Query != "" {
+		base.Query = f.Query
+	}
+	base.Limit = f.Limit
+	base.After = f.After
+	return base
+}
+
+// filterKey identifies the user's filters in the issue list key
+func (m Model) filterKey() string {
+	f := m.filter
+	if filter.IsEmpty(f) {
+		return ""
+	}
+	parts := []string{
+		"team=" + f.TeamID,
+		"assignee=" + f.AssigneeID,
+		fmt.Sprintf("none=%t", f.NoAssignee),
+		"states=" + strings.Join(f.States, ","),
+		"labels=" + strings.Join(f.Labels, ","),
+		fmt.Sprintf("priorities=%v", f.Priorities),
+		"cycle=" + f.CycleID,
+		"due=" + filter.FormatDateRange(f.Due),
+		"created=" + filter.FormatDateRange(f.Created),
+		"updated=" + filter.FormatDateRange(f.Updated),
+	}
+	return ":filter:" + strings.Join(parts, ";")
+}
+
+// renderFilterChips renders the active filters under the tab bar
+func (m Model) renderFilterChips() string {
+	chips := filter.Chips(m.panelFilter(), m.filterOptions())
+	if len(chips) == 0 {
+		return ""
+	}
+	return filter.RenderChips(chips, m.width)
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+func TestMergeFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		base linear.IssueFilter
+		f    linear.IssueFilter
+		want linear.IssueFilter
+	}{
+		{
+			name: "empty fields keep the tab's scope",
+			base: linear.IssueFilter{AssigneeID: "viewer", CycleID: "c1", StateType: "started", TeamKey: "ENG"},
+			f:    linear.IssueFilter{LabelNames: []string{"bug"}, Limit: 100},
+			want: linear.IssueFilter{AssigneeID: "viewer", CycleID: "c1", StateType: "started", TeamKey: "ENG", LabelNames: []string{"bug"}, Limit: 100},
+		},
+		{
+			name: "names replace IDs of the same kind",
+			base: linear.IssueFilter{AssigneeID: "viewer", ProjectID: "p1", Labels: []string{"l1"}},
+			f:    linear.IssueFilter{AssigneeName: "jane", ProjectName: "Auth", LabelNames: []string{"bug"}},
+			want: linear.IssueFilter{AssigneeName: "jane", ProjectName: "Auth", LabelNames: []string{"bug"}},
+		},
+		{
+			name: "state names replace the tab's state type",
+			base: linear.IssueFilter{StateType: "backlog"},
+			f:    linear.IssueFilter{StateNames: []string{"In Review"}},
+			want: linear.IssueFilter{StateNames: []string{"In Review"}},
+		},
+		{
+			name: "no assignee replaces an assignee",
+			base: linear.IssueFilter{AssigneeName: "me", Priorities: []int{1}},
+			f:    linear.IssueFilter{NoAssignee: true, Query: "login"},
+			want: linear.IssueFilter{NoAssignee: true, Priorities: []int{1}, Query: "login"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeFilter(tt.base, tt.f); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeFilter() = %+v\nwant          %+v", got, tt.want)
+			}
+		})
+	}
+}
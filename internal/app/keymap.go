@@ -46,9 +46,11 @@ type KeyMap struct {
 	Project  key.Binding
 
 	// Utility
-	CopyBranch   key.Binding
-	OpenInLinear key.Binding
-	Comment      key.Binding
+	CopyBranch     key.Binding
+	CheckoutBranch key.Binding
+	OpenInLinear   key.Binding
+	Comment        key.Binding
+	AIDraft        key.Binding
 
 	// Views
 	Board    key.Binding
@@ -200,6 +202,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy branch"),
 		),
+		CheckoutBranch: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "checkout branch"),
+		),
 		OpenInLinear: key.NewBinding(
 			key.WithKeys("o"),
 			key.WithHelp("o", "open in linear"),
@@ -208,6 +214,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("C"),
 			key.WithHelp("C", "comment"),
 		),
+		AIDraft: key.NewBinding(
+			key.WithKeys("ctrl+g"),
+			key.WithHelp("ctrl+g", "ai draft"),
+		),
 
 		Board: key.NewBinding(
 			key.WithKeys("b"),
@@ -242,7 +252,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		// Actions
 		{k.Enter, k.Create, k.Edit, k.Delete, k.Refresh, k.Search},
 		// Issue actions
-		{k.Status, k.Assignee, k.Priority, k.Project, k.Labels, k.CopyBranch, k.OpenInLinear, k.WorkTask},
+		{k.Status, k.Assignee, k.Priority, k.Project, k.Labels, k.CopyBranch, k.CheckoutBranch, k.OpenInLinear, k.WorkTask},
 		// General
 		{k.Help, k.Back, k.Quit},
 	}
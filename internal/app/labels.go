@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openLabelPicker opens the label picker for an issue
+func (m Model) openLabelPicker(issue *linear.Issue) Model {
+	// Keep labels from other teams that are already on the issue
+	labels := m.labels[:len(m.labels):len(m.labels)]
+	var checked []string
+	for _, label := range issue.Labels {
+		checked = append(checked, label.ID)
+		if !issues.HasLabel(labels, label.ID) {
+			labels = append(labels, label)
+		}
+	}
+
+	m.labelPicker = components.NewMultiPickerModel("Labels: "+issue.Identifier, issues.LabelsToItems(labels), checked, m.width, m.height).EnableCreate()
+	m.currentIssue = issue
+	return m
+}
+
+// updateLabelPicker handles label picker interactions
+func (m Model) updateLabelPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.labelPicker = nil
+		return m, nil
+	}
+
+	// Ignore keys while a new label is being created
+	if m.labelPicker.PendingCreate() != "" {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.labelPicker, cmd = m.labelPicker.Update(msg)
+
+	if m.labelPicker.Confirmed() {
+		selected := m.labelPicker.SelectedIDs()
+		m.labelPicker = nil
+		if m.currentIssue == nil {
+			return m, nil
+		}
+
+		var before []string
+		for _, label := range m.currentIssue.Labels {
+			before = append(before, label.ID)
+		}
+		added, removed := issues.LabelChanges(before, selected)
+		if len(added) == 0 && len(removed) == 0 {
+			return m, nil
+		}
+		input := linear.IssueUpdateInput{AddedLabelIDs: added, RemovedLabelIDs: removed}
+		return m, m.updateIssue(m.currentIssue.ID, input)
+	}
+
+	if name := m.labelPicker.PendingCreate(); name != "" {
+		teamID := ""
+		if m.currentIssue != nil && m.currentIssue.Team != nil {
+			teamID = m.currentIssue.Team.ID
+		} else if len(m.teams) > 0 {
+			teamID = m.teams[0].ID
+		}
+		return m, m.createLabel(teamID, name)
+	}
+	return m, cmd
+}
+
+// createLabel creates a label requested from a label picker
+func (m Model) createLabel(teamID, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		label, err := m.client.CreateLabel(ctx, teamID, name)
+		return LabelCreatedMsg{Label: label, Err: err}
+	}
+}
+
+// handleLabelCreated adds a new label to the open label picker
+func (m Model) handleLabelCreated(msg LabelCreatedMsg) (tea.Model, tea.Cmd) {
+	label := msg.Label
+	if msg.Err != nil {
+		label = nil
+	} else if label != nil {
+		m.labels = append(m.labels, *label)
+	}
+
+	switch {
+	case m.labelPicker != nil:
+		var item *components.PickerItem
+		if label != nil {
+			i := issues.LabelItem(*label)
+			item = &i
+		}
+		m.labelPicker.ResolveCreate(item)
+	case m.view == ViewCreate:
+		m.createView = m.createView.AddLabel(label)
+	case m.view == ViewEdit:
+		m.editView = m.editView.AddLabel(label)
+	}
+
+	if msg.Err != nil {
+		return m.showError("Error creating label: ", msg.Err)
+	}
+	if label != nil {
+		m.statusMsg = "Created label " + label.Name
+		m.statusErr = false
+	}
+	return m, nil
+}
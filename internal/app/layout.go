@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/config"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// listLayout is how a tab's issue list is sorted and grouped
+type listLayout struct {
+	sort      issues.SortOrder
+	group     issues.GroupBy
+	collapsed map[string]bool // Collapsed group keys
+}
+
+// tabKey identifies the active tab. Saved and Linear views are keyed by
+// name and ID, so they keep their key when tabs move.
+func (m Model) tabKey() string {
+	if view, ok := m.viewForTab(m.activeTab); ok {
+		return "view:" + view.Name
+	}
+	if m.activeTab == TabLinearView && m.linearView != nil {
+		return "linear-view:" + m.linearView.ID
+	}
+	return fmt.Sprintf("tab:%d", m.activeTab)
+}
+
+// layoutName returns the name the active tab's layout is saved under in
+// ui.layouts, or "" for saved views, which keep their own
+func (m Model) layoutName() string {
+	if _, ok := m.viewForTab(m.activeTab); ok {
+		return ""
+	}
+	if m.activeTab == TabLinearView && m.linearView != nil {
+		return "linear-view-" + m.linearView.ID
+	}
+	return defaultViewNames[m.activeTab]
+}
+
+// listLayout returns the active tab's layout: the one last picked for it,
+// or else the saved view's or ui.layouts' sort and group settings
+func (m Model) listLayout() listLayout {
+	if layout, ok := m.layouts[m.tabKey()]; ok {
+		return layout
+	}
+	layout := listLayout{sort: issues.SortStatus, group: issues.GroupNone}
+	saved, ok := m.config.UI.Layouts[m.layoutName()]
+	if view, isView := m.viewForTab(m.activeTab); isView {
+		saved, ok = config.LayoutConfig{Sort: view.Sort, Group: view.Group}, true
+	}
+	if ok {
+		// Unknown names fall back to the defaults
+		layout.sort, _ = issues.ParseSortOrder(saved.Sort)
+		layout.group, _ = issues.ParseGroupBy(saved.Group)
+	}
+	return layout
+}
+
+// saveLayout saves the active tab's sort and grouping, to the view for a
+// saved view and to ui.layouts for other tabs
+func (m Model) saveLayout(layout listLayout) (Model, error) {
+	saved := config.LayoutConfig{Sort: string(layout.sort), Group: string(layout.group)}
+	if view, ok := m.viewForTab(m.activeTab); ok {
+		if view.Sort == saved.Sort && view.Group == saved.Group {
+			return m, nil
+		}
+		index := int(m.activeTab - TabView)
+		views := append([]config.ViewConfig(nil), m.views...)
+		views[index].Sort, views[index].Group = saved.Sort, saved.Group
+		if err := config.SaveViews(views); err != nil {
+			return m, err
+		}
+		m.views = views
+		return m, nil
+	}
+
+	name := m.layoutName()
+	if name == "" || m.config.UI.Layouts[name] == saved {
+		return m, nil
+	}
+	layouts := make(map[string]config.LayoutConfig, len(m.config.UI.Layouts)+1)
+	for tab, l := range m.config.UI.Layouts {
+		layouts[tab] = l
+	}
+	layouts[name] = saved
+	if err := config.SaveLayouts(layouts); err != nil {
+		return m, err
+	}
+	m.config.UI.Layouts = layouts
+	return m, nil
+}
+
+// openLayoutMenu opens the menu for sorting and grouping the list and
+// choosing its columns
+func (m Model) openLayoutMenu() Model {
+	layout := m.listLayout()
+	check := func(on bool) string {
+		if on {
+			return "✓"
+		}
+		return " "
+	}
+
+	var items []components.PickerItem
+	for _, order := range issues.SortOrders {
+		items = append(items, components.PickerItem{
+			ID:    "sort:" + string(order),
+			Label: "Sort by " + strings.ToLower(order.Label()),
+			Icon:  check(order == layout.sort),
+		})
+	}
+	for _, by := range issues.GroupBys {
+		label := "Group by " + strings.ToLower(by.Label())
+		if by == issues.GroupNone {
+			label = by.Label()
+		}
+		items = append(items, components.PickerItem{
+			ID:    "group:" + string(by),
+			Label: label,
+			Icon:  check(by == layout.group),
+		})
+	}
+
+	items = append(items, components.PickerItem{ID: "columns", Label: "Columns...", Icon: "▥"})
+
+	m.picker = components.NewPickerModelWithoutSearch("Display", items, m.width, m.height)
+	m.pickerType = "layout"
+	return m
+}
+
+// handleLayoutSelection applies the sort order or grouping picked in the
+// layout menu to the active tab
+func (m Model) handleLayoutSelection(item *components.PickerItem) (tea.Model, tea.Cmd) {
+	m.picker = nil
+	m.pickerType = ""
+
+	if item.ID == "columns" {
+		return m.openColumnMenu(), nil
+	}
+
+	layout := m.listLayout()
+	kind, value, _ := strings.Cut(item.ID, ":")
+	switch kind {
+	case "sort":
+		layout.sort = issues.SortOrder(value)
+	case "group":
+		layout.group = issues.GroupBy(value)
+	}
+	m.layouts[m.tabKey()] = layout
+	var err error
+	if m, err = m.saveLayout(layout); err != nil {
+		return m.showError("Failed to save layout: ", err)
+	}
+
+	var currentID string
+	if selected := m.listView.SelectedIssue(); selected != nil {
+		currentID = selected.ID
+	}
+	m.listView = m.listView.SetLayout(layout.sort, layout.group, layout.collapsed).MoveToIssue(currentID)
+	return m, nil
+}
+
+// rememberCollapsed records which groups are collapsed in the active tab
+func (m Model) rememberCollapsed() {
+	layout := m.listLayout()
+	layout.collapsed = m.listView.Collapsed()
+	m.layouts[m.tabKey()] = layout
+}
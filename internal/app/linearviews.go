@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadCustomViews loads the workspace's custom views from Linear
+func (m Model) loadCustomViews() tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		views, err := client.GetCustomViews(context.Background())
+		return CustomViewsLoadedMsg{Views: views, Err: err}
+	}
+}
+
+// openLinearViewPicker lists Linear's custom views, loading them first the
+// first time
+func (m Model) openLinearViewPicker() (tea.Model, tea.Cmd) {
+	if m.customViews == nil {
+		m.statusMsg = "Loading Linear views..."
+		m.statusErr = false
+		return m, m.loadCustomViews()
+	}
+	if len(m.customViews) == 0 {
+		m.statusMsg = "No custom views in this workspace"
+		m.statusErr = false
+		return m, nil
+	}
+
+	items := make([]components.PickerItem, len(m.customViews))
+	for i, view := range m.customViews {
+		icon := "◇"
+		if view.Favorite {
+			icon = "★"
+		}
+		desc := "workspace"
+		if view.Team != nil {
+			desc = view.Team.Key
+		}
+		if !view.Shared {
+			desc += ", personal"
+		}
+		items[i] = components.PickerItem{ID: view.ID, Label: view.Name, Icon: icon, Desc: desc}
+	}
+	m.picker = components.NewPickerModel("Linear Views", items, m.width, m.height)
+	m.pickerType = "linear-view"
+	return m, nil
+}
+
+// handleCustomViewsLoaded opens the view picker once the views are loaded
+func (m Model) handleCustomViewsLoaded(msg CustomViewsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m.showError("Failed to load Linear views: ", msg.Err)
+	}
+	m.statusMsg = ""
+	m.customViews = msg.Views
+	if m.customViews == nil {
+		m.customViews = []linear.CustomView{}
+	}
+	return m.openLinearViewPicker()
+}
+
+// handleLinearViewSelection shows the chosen Linear view in its own tab
+func (m Model) handleLinearViewSelection(item *components.PickerItem) (tea.Model, tea.Cmd) {
+	m.picker = nil
+	m.pickerType = ""
+	for i := range m.customViews {
+		if m.customViews[i].ID == item.ID {
+			m.linearView = &m.customViews[i]
+			break
+		}
+	}
+	if m.linearView == nil {
+		return m, nil
+	}
+	m.activeTab = TabLinearView
+	m.loading = true
+	return m, m.loadIssues()
+}
+
+// closeLinearView removes the Linear view tab
+func (m Model) closeLinearView() (tea.Model, tea.Cmd) {
+	m.linearView = nil
+	if m.activeTab != TabLinearView {
+		return m, nil
+	}
+	m.activeTab = TabMyIssues
+	m.loading = true
+	return m, m.loadIssues()
+}
@@ -1,6 +1,13 @@
 package app
 
-import "github.com/brandonli/lazyliner/internal/linear"
+import (
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/ai"
+	"github.com/brandonli/lazyliner/internal/git"
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/queue"
+)
 
 // Message types for the application
 
@@ -13,12 +20,28 @@ type DataLoadedMsg struct {
 	Err            error
 }
 
+// CachedDataLoadedMsg is sent when workspace data is read from the local cache
+type CachedDataLoadedMsg struct {
+	Viewer         *linear.Viewer
+	Teams          []linear.Team
+	Projects       []linear.Project
+	Users          []linear.User
+	States         []linear.WorkflowState
+	Labels         []linear.Label
+	Cycles         map[string][]linear.Cycle // By team ID
+	MatchedProject *linear.Project
+	SavedAt        time.Time
+}
+
 // IssuesLoadedMsg is sent when issues are loaded
 type IssuesLoadedMsg struct {
-	Issues   []linear.Issue
-	PageInfo linear.PageInfo
-	Append   bool
-	Err      error
+	Issues    []linear.Issue
+	PageInfo  linear.PageInfo
+	Append    bool
+	Key       string    // List key the issues were loaded for
+	FromCache bool      // Issues came from the local cache, not the API
+	CachedAt  time.Time // When cached issues were saved
+	Err       error
 }
 
 // IssueLoadedMsg is sent when a single issue is loaded
@@ -39,6 +62,22 @@ type IssueUpdatedMsg struct {
 	Err   error
 }
 
+// EditorClosedMsg is sent when $EDITOR exits after editing a draft. Target
+// is the draft it was editing: a new issue, an issue edit or a comment.
+type EditorClosedMsg struct {
+	Target string
+	Text   string
+	Err    error
+}
+
+// TaskToggleFailedMsg is sent when Linear rejects a checklist toggle.
+// Description is the issue's description from before the toggle.
+type TaskToggleFailedMsg struct {
+	IssueID     string
+	Description string
+	Err         error
+}
+
 // IssueDeletedMsg is sent when an issue is deleted
 type IssueDeletedMsg struct {
 	IssueID    string
@@ -46,6 +85,28 @@ type IssueDeletedMsg struct {
 	Err        error
 }
 
+// CommentsLoadedMsg is sent when a page of issue comments is loaded
+type CommentsLoadedMsg struct {
+	IssueID  string
+	Comments []linear.Comment
+	PageInfo linear.PageInfo
+	Append   bool
+	Err      error
+}
+
+// CommentSavedMsg is sent when a comment is created or edited
+type CommentSavedMsg struct {
+	Comment *linear.Comment
+	Created bool
+	Err     error
+}
+
+// CommentDeletedMsg is sent when a comment is deleted
+type CommentDeletedMsg struct {
+	CommentID string
+	Err       error
+}
+
 // WorkflowStatesLoadedMsg is sent when workflow states are loaded
 type WorkflowStatesLoadedMsg struct {
 	States []linear.WorkflowState
@@ -58,6 +119,19 @@ type LabelsLoadedMsg struct {
 	Err    error
 }
 
+// LabelCreatedMsg is sent when a label created from a label picker is saved
+type LabelCreatedMsg struct {
+	Label *linear.Label
+	Err   error
+}
+
+// CyclesLoadedMsg is sent when a team's current and upcoming cycles are loaded
+type CyclesLoadedMsg struct {
+	TeamID string
+	Cycles []linear.Cycle
+	Err    error
+}
+
 // UsersLoadedMsg is sent when users are loaded
 type UsersLoadedMsg struct {
 	Users []linear.User
@@ -137,3 +211,128 @@ type AllProjectIssuesLoadedMsg struct {
 type ProjectSelectedMsg struct {
 	Project *linear.Project // nil means "All Projects"
 }
+
+// AIDraftGeneratedMsg is sent when the AI provider returns a draft issue
+type AIDraftGeneratedMsg struct {
+	Seq      int // The request's draftSeq
+	Draft    *ai.GenerateIssueOutput
+	Provider string
+	Err      error
+}
+
+// IssueQueuedMsg is sent when a create or update was journaled because the API is unreachable
+type IssueQueuedMsg struct {
+	Mutation queue.Mutation
+	Err      error
+}
+
+// ConnectivityRetryMsg is sent periodically while offline to probe the API
+type ConnectivityRetryMsg struct{}
+
+// ConnectivityCheckedMsg is sent when a connectivity probe completes
+type ConnectivityCheckedMsg struct {
+	Err error
+}
+
+// QueueReplayedMsg is sent when journaled mutations have been replayed
+type QueueReplayedMsg struct {
+	Result queue.ReplayResult
+}
+
+// RateLimitTickMsg refreshes the rate-limit countdown in the status bar
+type RateLimitTickMsg struct{}
+
+// BulkItemDoneMsg is sent when a batch action finishes on one issue
+type BulkItemDoneMsg struct {
+	OpID       int
+	IssueID    string
+	Identifier string
+	Issue      *linear.Issue            // Updated issue, nil for deletes and queued edits
+	Queued     bool                     // Journaled for replay because the API was unreachable
+	Update     *linear.IssueUpdateInput // The queued edit, applied locally
+	Err        error
+}
+
+// BranchCheckedOutMsg is sent when an issue's git branch has been checked out
+type BranchCheckedOutMsg struct {
+	IssueID string
+	Branch  string
+	Created bool
+	Err     error
+}
+
+// StartedStateLoadedMsg is sent when the started state of a checked-out
+// issue's team is found
+type StartedStateLoadedMsg struct {
+	IssueID string
+	State   *linear.WorkflowState // Nil if the team has no started state
+	Err     error
+}
+
+// BranchIssueLoadedMsg is sent when the issue for the current git branch is loaded
+type BranchIssueLoadedMsg struct {
+	Issue *linear.Issue
+	Err   error
+}
+
+// AttachmentsLoadedMsg is sent when an issue's attachments are loaded
+type AttachmentsLoadedMsg struct {
+	IssueID     string
+	Attachments []linear.Attachment
+	Err         error
+}
+
+// LocalCommitsLoadedMsg is sent when the local commits mentioning an issue are found
+type LocalCommitsLoadedMsg struct {
+	IssueID string
+	Commits []git.Commit
+	Err     error
+}
+
+// HierarchyLoadedMsg is sent when an issue's parents and sub-issues are loaded
+type HierarchyLoadedMsg struct {
+	IssueID   string
+	Hierarchy *linear.Issue
+	Err       error
+}
+
+// LinkedIssueLoadedMsg is sent when a parent or sub-issue that wasn't loaded
+// locally has been fetched for navigation
+type LinkedIssueLoadedMsg struct {
+	Issue *linear.Issue
+	Err   error
+}
+
+// RelationsLoadedMsg is sent when an issue's relations are loaded. Issue is
+// the freshly fetched issue.
+type RelationsLoadedMsg struct {
+	IssueID string
+	Issue   *linear.Issue
+	Err     error
+}
+
+// RelationSearchMsg is sent when typing in the relation issue picker pauses
+type RelationSearchMsg struct {
+	Query string
+}
+
+// RelationSearchResultsMsg is sent when issues matching a relation picker
+// query are found
+type RelationSearchResultsMsg struct {
+	Query  string
+	Issues []linear.Issue
+	Err    error
+}
+
+// RelationChangedMsg is sent when a relation has been added or removed
+type RelationChangedMsg struct {
+	IssueID string
+	Added   bool
+	Err     error
+}
+
+// CustomViewsLoadedMsg is sent when the workspace's Linear views are loaded
+type CustomViewsLoadedMsg struct {
+	Views []linear.CustomView
+	Err   error
+}
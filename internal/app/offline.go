@@ -0,0 +1,391 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/queue"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// connectivityRetryInterval is how often the API is probed while offline
+const connectivityRetryInterval = 30 * time.Second
+
+// queuedIssuePrefix starts the IDs of placeholder rows for issues created
+// offline, which have no Linear ID until the queue is replayed
+const queuedIssuePrefix = "queued:"
+
+// newListView builds the issue list in the tab's layout and the configured
+// columns, marking issues with queued offline edits and keeping any
+// multi-selection that is still in the list
+func (m Model) newListView(list []linear.Issue, hasNextPage bool) issues.ListModel {
+	layout := m.listLayout()
+	return issues.NewListModelWithPagination(list, m.width, m.height-4, hasNextPage).
+		SetLayout(layout.sort, layout.group, layout.collapsed).
+		SetColumns(m.listColumns()).
+		SetSyncStates(m.syncStates()).
+		SetSelection(m.listView.SelectedIDs())
+}
+
+// issueListView builds the list of the loaded issues, with placeholder rows
+// for issues created offline
+func (m Model) issueListView() issues.ListModel {
+	return m.newListView(append(m.queuedIssues(), m.issues...), m.pageInfo.HasNextPage)
+}
+
+// queuedIssues returns placeholder issues for the creates waiting in the queue
+func (m Model) queuedIssues() []linear.Issue {
+	if m.queue == nil {
+		return nil
+	}
+	var list []linear.Issue
+	for _, mutation := range m.queue.Pending() {
+		if mutation.Kind == queue.KindCreate && mutation.Create != nil {
+			list = append(list, m.placeholderIssue(mutation))
+		}
+	}
+	return list
+}
+
+// placeholderIssue builds the local stand-in for a queued create, resolving
+// its IDs like an offline update
+func (m Model) placeholderIssue(mutation queue.Mutation) linear.Issue {
+	input := mutation.Create
+	issue := linear.Issue{
+		ID:          queuedIssuePrefix + mutation.ID,
+		Identifier:  "NEW",
+		Title:       input.Title,
+		Description: input.Description,
+		CreatedAt:   mutation.QueuedAt,
+		UpdatedAt:   mutation.QueuedAt,
+	}
+	for i := range m.teams {
+		if m.teams[i].ID == input.TeamID {
+			team := m.teams[i]
+			issue.Team = &team
+			issue.Identifier = team.Key + "-?"
+			break
+		}
+	}
+
+	update := linear.IssueUpdateInput{
+		Priority: &input.Priority,
+		Estimate: input.Estimate,
+		LabelIDs: input.LabelIDs,
+	}
+	for _, field := range []struct {
+		value string
+		dest  **string
+	}{
+		{input.StateID, &update.StateID},
+		{input.AssigneeID, &update.AssigneeID},
+		{input.ProjectID, &update.ProjectID},
+		{input.CycleID, &update.CycleID},
+		{input.DueDate, &update.DueDate},
+	} {
+		if field.value != "" {
+			value := field.value
+			*field.dest = &value
+		}
+	}
+	m.applyUpdate(&issue, update)
+	return issue
+}
+
+// isQueuedIssue reports whether an issue is a placeholder for a queued create
+func isQueuedIssue(issueID string) bool {
+	return strings.HasPrefix(issueID, queuedIssuePrefix)
+}
+
+// cacheIssues writes locally changed issues through to the cache, so queued
+// edits still show after a restart before they are replayed
+func (m Model) cacheIssues(list ...linear.Issue) tea.Cmd {
+	store := m.cache
+	if store == nil || len(list) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		store.PutIssues(list)
+		return nil
+	}
+}
+
+// syncStates returns the sync state of every issue with queued edits
+func (m Model) syncStates() map[string]issues.SyncState {
+	states := make(map[string]issues.SyncState)
+	if m.queue == nil {
+		return states
+	}
+	for id, conflict := range m.queue.Issues() {
+		if conflict {
+			states[id] = issues.SyncConflict
+		} else {
+			states[id] = issues.SyncPending
+		}
+	}
+	for _, issue := range m.queuedIssues() {
+		states[issue.ID] = issues.SyncPending
+	}
+	return states
+}
+
+// refreshSyncStates pushes the queue's current state to the list and detail views
+func (m *Model) refreshSyncStates() {
+	states := m.syncStates()
+	m.listView = m.listView.SetSyncStates(states)
+	if m.currentIssue != nil {
+		m.detailView = m.detailView.SetSyncState(states[m.currentIssue.ID])
+	}
+}
+
+// findIssue returns the loaded issue with the given ID
+func (m Model) findIssue(issueID string) *linear.Issue {
+	if m.currentIssue != nil && m.currentIssue.ID == issueID {
+		return m.currentIssue
+	}
+	for i := range m.issues {
+		if m.issues[i].ID == issueID {
+			return &m.issues[i]
+		}
+	}
+	return nil
+}
+
+// enqueueMutation journals a mutation and reports it as queued
+func enqueueMutation(q *queue.Queue, mutation queue.Mutation) tea.Msg {
+	if err := q.Enqueue(mutation); err != nil {
+		return IssueQueuedMsg{Mutation: mutation, Err: fmt.Errorf("failed to queue change: %w", err)}
+	}
+	return IssueQueuedMsg{Mutation: mutation}
+}
+
+// setOffline marks the app offline and schedules a connectivity probe
+func (m Model) setOffline() (Model, tea.Cmd) {
+	m.offline = true
+	if m.retryScheduled {
+		return m, nil
+	}
+	m.retryScheduled = true
+	return m, tea.Tick(connectivityRetryInterval, func(time.Time) tea.Msg {
+		return ConnectivityRetryMsg{}
+	})
+}
+
+// checkConnectivity probes the API with a cheap viewer query
+func (m Model) checkConnectivity() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		_, err := m.client.GetViewer(ctx)
+		if err != nil && !linear.IsNetworkError(err) {
+			// The API answered, so we're online even if the call failed
+			err = nil
+		}
+		return ConnectivityCheckedMsg{Err: err}
+	}
+}
+
+// replayQueue sends journaled mutations to Linear
+func (m Model) replayQueue() tea.Cmd {
+	mutations := m.queue
+	store := m.cache
+	return func() tea.Msg {
+		ctx := context.Background()
+		result := mutations.Replay(ctx, m.client)
+		if store != nil {
+			store.PutIssues(result.Updated)
+			store.PutIssues(result.Created)
+		}
+		return QueueReplayedMsg{Result: result}
+	}
+}
+
+// handleIssueQueued applies a journaled change locally so the UI reflects it
+// before it reaches the server
+func (m Model) handleIssueQueued(msg IssueQueuedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusMsg = "Error: " + msg.Err.Error()
+		m.statusErr = true
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+	var cmd tea.Cmd
+	m, cmd = m.setOffline()
+	cmds = append(cmds, cmd)
+	m.statusErr = false
+
+	switch msg.Mutation.Kind {
+	case queue.KindCreate:
+		m.listView = m.issueListView()
+		m.statusMsg = "Offline: new issue " + msg.Mutation.Label() + " queued"
+		m.view = m.createReturnView()
+
+	case queue.KindUpdate:
+		var changed *linear.Issue
+		for i := range m.issues {
+			if m.issues[i].ID == msg.Mutation.IssueID {
+				m.applyUpdate(&m.issues[i], *msg.Mutation.Update)
+				changed = &m.issues[i]
+				break
+			}
+		}
+		if m.currentIssue != nil && m.currentIssue.ID == msg.Mutation.IssueID {
+			issue := *m.currentIssue
+			m.applyUpdate(&issue, *msg.Mutation.Update)
+			m.currentIssue = &issue
+			m.detailView = m.detailView.SetIssue(m.currentIssue)
+			changed = m.currentIssue
+		}
+		if changed != nil {
+			cmds = append(cmds, m.cacheIssues(*changed))
+		}
+		m.issues = sortIssues(m.issues)
+		m.listView = m.issueListView()
+		m.statusMsg = "Offline: change to " + msg.Mutation.Label() + " queued"
+		if m.view == ViewEdit {
+			m.view = ViewDetail
+		}
+	}
+
+	m.refreshSyncStates()
+	return m, tea.Batch(cmds...)
+}
+
+// applyUpdate applies an update input to a local copy of an issue, resolving
+// IDs against the loaded states, users, projects and labels
+func (m Model) applyUpdate(issue *linear.Issue, input linear.IssueUpdateInput) {
+	if input.Title != nil {
+		issue.Title = *input.Title
+	}
+	if input.Description != nil {
+		issue.Description = *input.Description
+	}
+	if input.Priority != nil {
+		issue.Priority = *input.Priority
+	}
+	if input.Estimate != nil {
+		issue.Estimate = input.Estimate
+	}
+	if input.DueDate != nil {
+		issue.DueDate = input.DueDate
+	}
+	if input.StateID != nil {
+		for i := range m.states {
+			if m.states[i].ID == *input.StateID {
+				state := m.states[i]
+				issue.State = &state
+				break
+			}
+		}
+	}
+	if input.AssigneeID != nil {
+		issue.Assignee = nil
+		for i := range m.users {
+			if m.users[i].ID == *input.AssigneeID {
+				user := m.users[i]
+				issue.Assignee = &user
+				break
+			}
+		}
+	}
+	if input.ProjectID != nil {
+		issue.Project = nil
+		for i := range m.projects {
+			if m.projects[i].ID == *input.ProjectID {
+				project := m.projects[i]
+				issue.Project = &project
+				break
+			}
+		}
+	}
+	if input.CycleID != nil {
+		issue.Cycle = m.findCycle(*input.CycleID)
+	}
+	for _, id := range input.AddedLabelIDs {
+		for _, label := range m.labels {
+			if label.ID == id && !issues.HasLabel(issue.Labels, id) {
+				issue.Labels = append(issue.Labels, label)
+				break
+			}
+		}
+	}
+	for _, id := range input.RemovedLabelIDs {
+		for i, label := range issue.Labels {
+			if label.ID == id {
+				issue.Labels = append(issue.Labels[:i:i], issue.Labels[i+1:]...)
+				break
+			}
+		}
+	}
+	if input.LabelIDs != nil {
+		var labels []linear.Label
+		for _, id := range input.LabelIDs {
+			for _, label := range m.labels {
+				if label.ID == id {
+					labels = append(labels, label)
+					break
+				}
+			}
+		}
+		issue.Labels = labels
+	}
+}
+
+// handleQueueReplayed merges replayed changes and reports how the sync went
+func (m Model) handleQueueReplayed(msg QueueReplayedMsg) (tea.Model, tea.Cmd) {
+	m.replaying = false
+	result := msg.Result
+
+	for _, updated := range result.Updated {
+		for i := range m.issues {
+			if m.issues[i].ID == updated.ID {
+				m.issues[i] = updated
+				break
+			}
+		}
+		if m.currentIssue != nil && m.currentIssue.ID == updated.ID {
+			issue := updated
+			m.currentIssue = &issue
+			m.detailView = m.detailView.SetIssue(m.currentIssue)
+		}
+	}
+	m.issues = sortIssues(m.issues)
+	m.listView = m.issueListView()
+	m.refreshSyncStates()
+
+	var cmds []tea.Cmd
+	if len(result.Created) > 0 {
+		cmds = append(cmds, m.loadIssues())
+	}
+
+	switch {
+	case len(result.Dropped) > 0:
+		m.statusMsg = "Dropped offline changes Linear rejected: " + strings.Join(result.Dropped, "; ")
+		m.statusErr = true
+	case result.Err != nil:
+		m.statusMsg = fmt.Sprintf("Sync paused with %d change(s) still queued: %v", m.queue.Len(), result.Err)
+		m.statusErr = true
+	case len(result.Conflicts) > 0:
+		names := make([]string, len(result.Conflicts))
+		for i, c := range result.Conflicts {
+			names[i] = c.Label()
+		}
+		m.statusMsg = "Conflicts on " + strings.Join(names, ", ") + " - open to resolve"
+		m.statusErr = true
+	case result.Applied() > 0:
+		m.statusMsg = fmt.Sprintf("Synced %d offline change(s)", result.Applied())
+		m.statusErr = false
+	}
+
+	if result.Offline {
+		var cmd tea.Cmd
+		m, cmd = m.setOffline()
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// relationSearchDelay is how long typing must pause before issues are searched
+const relationSearchDelay = 300 * time.Millisecond
+
+// relationRemove is the relation picker option for removing a relation
+const relationRemove = "remove"
+
+// loadRelations fetches an issue's relations to other issues
+func (m Model) loadRelations(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := m.client.GetIssue(context.Background(), issueID)
+		if err != nil {
+			return RelationsLoadedMsg{IssueID: issueID, Err: err}
+		}
+		return RelationsLoadedMsg{IssueID: issueID, Issue: issue}
+	}
+}
+
+// handleRelationsLoaded shows the relations of the open issue and refreshes
+// its blockers in the list
+func (m Model) handleRelationsLoaded(msg RelationsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.currentIssue == nil || m.currentIssue.ID != msg.IssueID {
+		return m, nil
+	}
+	if msg.Err != nil {
+		if m.offline {
+			return m, nil
+		}
+		return m.showError("Error loading relations: ", msg.Err)
+	}
+
+	issue := *m.currentIssue
+	issue.InverseRelations = msg.Issue.InverseRelations
+	m.currentIssue = &issue
+	for i := range m.issues {
+		if m.issues[i].ID == issue.ID {
+			m.issues[i].InverseRelations = issue.InverseRelations
+		}
+	}
+	m.detailView = m.detailView.SetIssue(m.currentIssue).SetRelations(msg.Issue.RelatedIssues())
+	return m, nil
+}
+
+// openRelationPicker asks which kind of relation to add, or to remove one
+func (m Model) openRelationPicker() Model {
+	items := []components.PickerItem{
+		{ID: string(linear.RelationBlockedBy), Label: "Blocked by...", Icon: "⛔"},
+		{ID: string(linear.RelationBlocks), Label: "Blocks...", Icon: "⇥"},
+		{ID: string(linear.RelationRelated), Label: "Related to...", Icon: "↔"},
+		{ID: string(linear.RelationDuplicateOf), Label: "Mark as duplicate of...", Icon: "⧉"},
+	}
+	if len(m.detailView.Relations()) > 0 {
+		items = append(items, components.PickerItem{ID: relationRemove, Label: "Remove a relation...", Icon: "✕"})
+	}
+	m.picker = components.NewPickerModelWithoutSearch("Relations: "+m.currentIssue.Identifier, items, m.width, m.height)
+	m.pickerType = "relation-kind"
+	return m
+}
+
+// handleRelationPickerSelection handles the steps of the relation pickers
+func (m Model) handleRelationPickerSelection(item *components.PickerItem) (tea.Model, tea.Cmd) {
+	pickerType := m.pickerType
+	m.picker = nil
+	m.pickerType = ""
+	if m.currentIssue == nil {
+		return m, nil
+	}
+
+	switch pickerType {
+	case "relation-kind":
+		if item.ID == relationRemove {
+			title := "Remove relation: " + m.currentIssue.Identifier
+			m.picker = components.NewPickerModel(title, issues.RelationsToItems(m.detailView.Relations()), m.width, m.height)
+			m.pickerType = "relation-remove"
+			return m, nil
+		}
+		m.relationKind = linear.RelationKind(item.ID)
+		m.relationQuery = ""
+		title := issues.RelationLabels[m.relationKind] + ": " + m.currentIssue.Identifier
+		m.picker = components.NewPickerModel(title, issues.IssuesToItems(m.issues, m.currentIssue.ID), m.width, m.height)
+		m.pickerType = "relation-issue"
+		return m, nil
+
+	case "relation-issue":
+		return m, m.createRelation(m.currentIssue.ID, item.ID, m.relationKind)
+
+	case "relation-remove":
+		return m, m.deleteRelation(m.currentIssue.ID, item.ID)
+	}
+	return m, nil
+}
+
+// updateRelationSearch schedules a search when the relation issue picker's
+// query changes. Clearing the query shows the loaded issues again.
+func (m Model) updateRelationSearch() (Model, tea.Cmd) {
+	query := m.picker.Query()
+	if query == m.relationQuery || m.currentIssue == nil {
+		return m, nil
+	}
+	m.relationQuery = query
+	if query == "" {
+		m.picker.SetItems(issues.IssuesToItems(m.issues, m.currentIssue.ID))
+		return m, nil
+	}
+	return m, tea.Tick(relationSearchDelay, func(time.Time) tea.Msg {
+		return RelationSearchMsg{Query: query}
+	})
+}
+
+// handleRelationSearch searches issues if the query hasn't changed since the
+// search was scheduled
+func (m Model) handleRelationSearch(msg RelationSearchMsg) (tea.Model, tea.Cmd) {
+	if m.pickerType != "relation-issue" || m.picker.Query() != msg.Query {
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		results, err := m.client.SearchIssues(context.Background(), msg.Query, 20)
+		return RelationSearchResultsMsg{Query: msg.Query, Issues: results, Err: err}
+	}
+}
+
+// handleRelationSearchResults shows search results in the relation issue picker
+func (m Model) handleRelationSearchResults(msg RelationSearchResultsMsg) (tea.Model, tea.Cmd) {
+	if m.pickerType != "relation-issue" || m.picker.Query() != msg.Query || m.currentIssue == nil {
+		return m, nil
+	}
+	if msg.Err != nil {
+		if m.offline {
+			return m, nil
+		}
+		return m.showError("Error searching issues: ", msg.Err)
+	}
+	m.picker.SetItems(issues.IssuesToItems(msg.Issues, m.currentIssue.ID))
+	return m, nil
+}
+
+// createRelation relates issueID to otherID from issueID's point of view
+func (m Model) createRelation(issueID, otherID string, kind linear.RelationKind) tea.Cmd {
+	return func() tea.Msg {
+		from, to, relationType := issueID, otherID, linear.RelationTypeRelated
+		switch kind {
+		case linear.RelationBlocks:
+			relationType = linear.RelationTypeBlocks
+		case linear.RelationBlockedBy:
+			from, to, relationType = otherID, issueID, linear.RelationTypeBlocks
+		case linear.RelationDuplicateOf:
+			relationType = linear.RelationTypeDuplicate
+		}
+		_, err := m.client.CreateRelation(context.Background(), from, to, relationType)
+		return RelationChangedMsg{IssueID: issueID, Added: true, Err: err}
+	}
+}
+
+// deleteRelation removes one of issueID's relations
+func (m Model) deleteRelation(issueID, relationID string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.client.DeleteRelation(context.Background(), relationID)
+		return RelationChangedMsg{IssueID: issueID, Err: err}
+	}
+}
+
+// handleRelationChanged reloads relations and the list's blocked markers
+// after a relation was added or removed
+func (m Model) handleRelationChanged(msg RelationChangedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m.showError("Error updating relation: ", msg.Err)
+	}
+	if msg.Added {
+		m.statusMsg = "Relation added"
+	} else {
+		m.statusMsg = "Relation removed"
+	}
+	m.statusErr = false
+	return m, tea.Batch(m.loadRelations(msg.IssueID), m.loadIssuesWithCursor(""))
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/ui/views/filter"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchLimit is the number of issues a structured search returns
+const searchLimit = 100
+
+// parseSearch parses the search bar's query
+func (m Model) parseSearch() filter.Query {
+	return filter.ParseQuery(m.searchInput.Value(), m.filterOptions(), time.Now())
+}
+
+// searchCursor returns the byte offset of the search bar's cursor
+func (m Model) searchCursor() int {
+	value := m.searchInput.Value()
+	offset := 0
+	for i := 0; i < m.searchInput.Position() && offset < len(value); i++ {
+		_, size := utf8.DecodeRuneInString(value[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// completeSearch completes the search term before the cursor. Pressing tab
+// again right away cycles through the other candidates.
+func (m Model) completeSearch() Model {
+	value := m.searchInput.Value()
+	base, cursor, n := value, m.searchCursor(), 0
+	if m.completionResult != "" && value == m.completionResult {
+		base, cursor, n = m.completionBase, m.completionCursor, m.completionCount+1
+	}
+
+	completion, ok := filter.Complete(base, cursor, m.filterOptions(), n)
+	if !ok {
+		return m
+	}
+	m.completionBase = base
+	m.completionCursor = cursor
+	m.completionCount = n
+	m.completionResult = completion.Query
+	m.completionHints = completion.Candidates
+	m.searchInput.SetValue(completion.Query)
+	m.searchInput.SetCursor(utf8.RuneCountInString(completion.Query[:completion.Cursor]))
+	return m
+}
+
+// resetCompletion forgets the completion in progress
+func (m Model) resetCompletion() Model {
+	m.completionResult = ""
+	m.completionHints = nil
+	return m
+}
+
+// submitSearch runs a structured query against Linear within the active
+// tab and the user's filters. Fields the query sets replace theirs.
+func (m Model) submitSearch() (tea.Model, tea.Cmd) {
+	q := m.parseSearch()
+	if err := q.Err(); err != nil {
+		m.statusMsg = "Invalid query: " + err.Error()
+		m.statusErr = true
+		return m, nil
+	}
+	scope, err := m.tabIssueFilter(searchLimit, "")
+	if err != nil {
+		return m.showError("Search failed: ", err)
+	}
+
+	m.searchMode = false
+	m.searchInput.Blur()
+	m.allProjectIssues = nil
+	m.statusMsg = "Searching..."
+	m.statusErr = false
+
+	issueFilter := mergeFilter(scope, q.Filter)
+	issueFilter.Limit = searchLimit
+	query := m.searchQuery
+	client := m.client
+	return m, func() tea.Msg {
+		conn, err := client.GetIssues(context.Background(), issueFilter)
+		return SearchResultsMsg{Issues: conn.Nodes, Query: query, Err: err}
+	}
+}
+
+// handleSearchResults shows the issues a structured query matched
+func (m Model) handleSearchResults(msg SearchResultsMsg) (tea.Model, tea.Cmd) {
+	if msg.Query != m.searchQuery {
+		return m, nil
+	}
+	if msg.Err != nil {
+		return m.showError("Search failed: ", msg.Err)
+	}
+	m.statusMsg = ""
+	m.filteredIssues = sortIssues(msg.Issues)
+	m.listView = m.newListView(m.filteredIssues, false)
+	return m, nil
+}
+
+// renderSearchBar renders the search input bar, highlighting query fields
+// and invalid terms
+func (m Model) renderSearchBar() string {
+	prefix := theme.TextDimStyle.Render("/ ")
+	value := m.searchInput.Value()
+	if value == "" {
+		return theme.SearchBarStyle.Width(m.width).Render(prefix + m.searchInput.View())
+	}
+
+	q := m.parseSearch()
+	cursor := -1
+	if m.searchMode {
+		cursor = m.searchCursor()
+	}
+	input := filter.RenderQuery(value, cursor, q)
+
+	var info string
+	switch {
+	case len(q.Errors) > 0:
+		info = theme.ErrorStyle.Render("  " + q.Errors[0].Msg)
+	case len(m.completionHints) > 1:
+		// Show the values being completed, or the field names
+		hints := make([]string, len(m.completionHints))
+		for i, hint := range m.completionHints {
+			hint = strings.TrimSpace(hint)
+			if colon := strings.IndexByte(hint, ':'); colon < len(hint)-1 {
+				hint = hint[colon+1:]
+			}
+			hints[i] = strings.TrimSuffix(hint, ":")
+		}
+		info = theme.TextDimStyle.Render("  " + strings.Join(hints, " · "))
+	case q.Structured && m.searchMode:
+		info = theme.TextDimStyle.Render("  enter: search  tab: complete")
+	case m.searchQuery != "":
+		info = theme.TextDimStyle.Render(fmt.Sprintf(" (%d results)", len(m.filteredIssues)))
+	}
+
+	return theme.SearchBarStyle.Width(m.width).MaxHeight(1).Render(prefix + input + info)
+}
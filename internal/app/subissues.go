@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadHierarchy fetches an issue's parents and sub-issue tree
+func (m Model) loadHierarchy(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		hierarchy, err := m.client.GetIssueHierarchy(context.Background(), issueID)
+		return HierarchyLoadedMsg{IssueID: issueID, Hierarchy: hierarchy, Err: err}
+	}
+}
+
+// handleHierarchyLoaded shows the parents and sub-issues of the open issue
+func (m Model) handleHierarchyLoaded(msg HierarchyLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.currentIssue == nil || m.currentIssue.ID != msg.IssueID {
+		return m, nil
+	}
+	if msg.Err != nil {
+		if m.offline {
+			return m, nil
+		}
+		return m.showError("Error loading sub-issues: ", msg.Err)
+	}
+	m.detailView = m.detailView.SetHierarchy(msg.Hierarchy)
+	return m, nil
+}
+
+// openLinkedIssue navigates from the detail view to a parent or sub-issue,
+// fetching it first if it isn't loaded
+func (m Model) openLinkedIssue(issueID string) (tea.Model, tea.Cmd) {
+	if issue := m.findIssue(issueID); issue != nil {
+		return m.navigateTo(issue)
+	}
+	m.statusMsg = "Loading issue..."
+	m.statusErr = false
+	return m, func() tea.Msg {
+		issue, err := m.client.GetIssue(context.Background(), issueID)
+		return LinkedIssueLoadedMsg{Issue: issue, Err: err}
+	}
+}
+
+// handleLinkedIssueLoaded opens a fetched parent or sub-issue
+func (m Model) handleLinkedIssueLoaded(msg LinkedIssueLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		return m.showError("Error loading issue: ", msg.Err)
+	}
+	if m.view != ViewDetail {
+		return m, nil
+	}
+	m.statusMsg = ""
+	return m.navigateTo(msg.Issue)
+}
+
+// navigateTo opens issue in the detail view, remembering the current issue
+// so esc returns to it
+func (m Model) navigateTo(issue *linear.Issue) (tea.Model, tea.Cmd) {
+	history := m.detailHistory
+	if m.currentIssue != nil {
+		history = append(history, m.currentIssue)
+	}
+	m, cmd := m.openDetail(issue)
+	m.detailHistory = history
+	return m, cmd
+}
+
+// navigateBack returns to the previously viewed issue
+func (m Model) navigateBack() (tea.Model, tea.Cmd) {
+	last := len(m.detailHistory) - 1
+	previous, history := m.detailHistory[last], m.detailHistory[:last]
+	if issue := m.findIssue(previous.ID); issue != nil {
+		previous = issue
+	}
+	m, cmd := m.openDetail(previous)
+	m.detailHistory = history
+	return m, cmd
+}
+
+// createReturnView returns the view to show when the create form closes: the
+// parent's detail view for a sub-issue, the list otherwise
+func (m Model) createReturnView() View {
+	parent := m.createView.Parent()
+	if parent != nil && m.currentIssue != nil && m.currentIssue.ID == parent.ID {
+		return ViewDetail
+	}
+	return ViewList
+}
@@ -0,0 +1,295 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/config"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/views/filter"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultViewNames maps the built-in tabs to their defaults.view names
+var defaultViewNames = map[Tab]string{
+	TabProject:   "project",
+	TabMyIssues:  "my-issues",
+	TabAllIssues: "all",
+	TabActive:    "active",
+	TabBacklog:   "backlog",
+	TabCycle:     "cycle",
+}
+
+// startupTab returns the tab named by defaults.view. The Project tab only
+// exists once the repository's project is detected, so it starts on My Issues.
+func startupTab(cfg *config.Config) Tab {
+	name := strings.TrimSpace(cfg.Defaults.View)
+	for tab, tabName := range defaultViewNames {
+		if strings.EqualFold(name, tabName) && tab != TabProject {
+			return tab
+		}
+	}
+	for i, view := range cfg.Views {
+		if strings.EqualFold(name, view.Name) {
+			return TabView + Tab(i)
+		}
+	}
+	return TabMyIssues
+}
+
+// opensProjectTab reports whether a detected project's tab should open at
+// startup, which it does unless defaults.view names another tab
+func opensProjectTab(cfg *config.Config) bool {
+	switch strings.ToLower(strings.TrimSpace(cfg.Defaults.View)) {
+	case "", "my-issues", "project":
+		return true
+	}
+	return false
+}
+
+// viewForTab returns the saved view shown in tab, if it is a view tab
+func (m Model) viewForTab(tab Tab) (config.ViewConfig, bool) {
+	i := int(tab - TabView)
+	if tab < TabView || i >= len(m.views) {
+		return config.ViewConfig{}, false
+	}
+	return m.views[i], true
+}
+
+// tabTitle returns the label of a tab in the tab bar
+func (m Model) tabTitle(tab Tab) string {
+	if view, ok := m.viewForTab(tab); ok {
+		return view.Name
+	}
+	if tab == TabLinearView && m.linearView != nil {
+		return "◇ " + m.linearView.Name
+	}
+	return tabTitles[tab]
+}
+
+// openViewMenu opens the menu for saving and arranging views
+func (m Model) openViewMenu() Model {
+	var items []components.PickerItem
+	// A Linear view's own filter can't be saved, so only offer to save tabs
+	// whose filters lazyliner knows
+	if m.activeTab != TabLinearView {
+		items = append(items, components.PickerItem{ID: "save", Label: "Save as new view", Icon: "+", Desc: "current tab and filters"})
+	}
+	if _, ok := m.viewForTab(m.activeTab); ok {
+		items = append(items,
+			components.PickerItem{ID: "left", Label: "Move view left", Icon: "←"},
+			components.PickerItem{ID: "right", Label: "Move view right", Icon: "→"},
+			components.PickerItem{ID: "rename", Label: "Rename view", Icon: "✎"},
+			components.PickerItem{ID: "delete", Label: "Delete view", Icon: "✕"},
+		)
+	}
+	items = append(items, components.PickerItem{ID: "linear", Label: "Open Linear view...", Icon: "◇"})
+	if m.activeTab == TabLinearView {
+		items = append(items, components.PickerItem{ID: "close-linear", Label: "Close Linear view", Icon: "✕"})
+	} else {
+		items = append(items, components.PickerItem{ID: "default", Label: "Open this tab at startup", Icon: "★"})
+	}
+
+	m.picker = components.NewPickerModelWithoutSearch("Views", items, m.width, m.height)
+	m.pickerType = "view"
+	return m
+}
+
+// handleViewMenuSelection runs the action chosen in the view menu
+func (m Model) handleViewMenuSelection(item *components.PickerItem) (tea.Model, tea.Cmd) {
+	m.picker = nil
+	m.pickerType = ""
+
+	index := int(m.activeTab - TabView)
+	switch item.ID {
+	case "save":
+		m.prompt = components.NewPromptModel("Save View", "View name", "", m.width, m.height)
+		m.promptType = "view-save"
+	case "rename":
+		m.prompt = components.NewPromptModel("Rename View", "View name", m.views[index].Name, m.width, m.height)
+		m.promptType = "view-rename"
+	case "left":
+		if index > 0 {
+			return m.moveView(index, index-1)
+		}
+	case "right":
+		if index < len(m.views)-1 {
+			return m.moveView(index, index+1)
+		}
+	case "delete":
+		return m.deleteView(index)
+	case "default":
+		return m.setDefaultView()
+	case "linear":
+		return m.openLinearViewPicker()
+	case "close-linear":
+		return m.closeLinearView()
+	}
+	return m, nil
+}
+
+// updatePrompt handles keys while the text prompt is open
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompt = nil
+		m.promptType = ""
+		return m, nil
+	case "enter":
+		value := m.prompt.Value()
+		promptType := m.promptType
+		m.prompt = nil
+		m.promptType = ""
+		if value == "" {
+			return m, nil
+		}
+		switch promptType {
+		case "view-save":
+			return m.saveView(value)
+		case "view-rename":
+			return m.renameView(value)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.prompt, cmd = m.prompt.Update(msg)
+	return m, cmd
+}
+
+// viewExists reports whether a view other than index is called name
+func (m Model) viewExists(name string, index int) bool {
+	for i, view := range m.views {
+		if i != index && strings.EqualFold(view.Name, name) {
+			return true
+		}
+	}
+	for _, tabName := range tabTitles {
+		if strings.EqualFold(tabName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// saveView saves the current tab and filters as a new view and switches to it
+func (m Model) saveView(name string) (tea.Model, tea.Cmd) {
+	if m.viewExists(name, -1) {
+		m.statusMsg = "A tab called " + name + " already exists"
+		m.statusErr = true
+		return m, nil
+	}
+
+	f, err := m.tabIssueFilter(0, "")
+	if err != nil {
+		return m.showError("Failed to save view: ", err)
+	}
+	viewerID := ""
+	if m.viewer != nil {
+		viewerID = m.viewer.ID
+	}
+
+	layout := m.listLayout()
+	view := config.ViewConfig{
+		Name:   name,
+		Filter: filter.ToConfig(f, m.filterOptions(), viewerID),
+	}
+	if layout.sort != issues.SortStatus {
+		view.Sort = string(layout.sort)
+	}
+	if layout.group != issues.GroupNone {
+		view.Group = string(layout.group)
+	}
+
+	views := append(append([]config.ViewConfig(nil), m.views...), view)
+	if err := config.SaveViews(views); err != nil {
+		return m.showError("Failed to save view: ", err)
+	}
+	m.views = views
+
+	// The filters now live in the view
+	m.filter = filter.ClearAll(m.filter)
+	m.filterProject = nil
+
+	m.activeTab = TabView + Tab(len(views)-1)
+	m.layouts[m.tabKey()] = layout
+	m.statusMsg = "Saved view " + name
+	m.statusErr = false
+	m.loading = true
+	return m, m.loadIssues()
+}
+
+// renameView renames the active view
+func (m Model) renameView(name string) (tea.Model, tea.Cmd) {
+	index := int(m.activeTab - TabView)
+	if m.viewExists(name, index) {
+		m.statusMsg = "A tab called " + name + " already exists"
+		m.statusErr = true
+		return m, nil
+	}
+
+	layout := m.listLayout()
+	views := append([]config.ViewConfig(nil), m.views...)
+	oldName := views[index].Name
+	views[index].Name = name
+	if err := config.SaveViews(views); err != nil {
+		return m.showError("Failed to rename view: ", err)
+	}
+	m.views = views
+	m.layouts[m.tabKey()] = layout
+
+	// Keep opening the view at startup under its new name
+	if strings.EqualFold(m.config.Defaults.View, oldName) {
+		if err := config.SaveDefaultView(name); err != nil {
+			return m.showError("Failed to save default view: ", err)
+		}
+		m.config.Defaults.View = name
+	}
+
+	m.statusMsg = "Renamed view to " + name
+	m.statusErr = false
+	return m, m.loadIssues()
+}
+
+// moveView moves a view to another position in the tab bar
+func (m Model) moveView(from, to int) (tea.Model, tea.Cmd) {
+	views := append([]config.ViewConfig(nil), m.views...)
+	views[from], views[to] = views[to], views[from]
+	if err := config.SaveViews(views); err != nil {
+		return m.showError("Failed to move view: ", err)
+	}
+	m.views = views
+	m.activeTab = TabView + Tab(to)
+	return m, nil
+}
+
+// deleteView deletes a view and switches to My Issues
+func (m Model) deleteView(index int) (tea.Model, tea.Cmd) {
+	views := append(append([]config.ViewConfig(nil), m.views[:index]...), m.views[index+1:]...)
+	if err := config.SaveViews(views); err != nil {
+		return m.showError("Failed to delete view: ", err)
+	}
+	name := m.views[index].Name
+	m.views = views
+
+	m.activeTab = TabMyIssues
+	m.statusMsg = "Deleted view " + name
+	m.statusErr = false
+	m.loading = true
+	return m, m.loadIssues()
+}
+
+// setDefaultView makes the active tab open at startup
+func (m Model) setDefaultView() (tea.Model, tea.Cmd) {
+	name := defaultViewNames[m.activeTab]
+	if view, ok := m.viewForTab(m.activeTab); ok {
+		name = view.Name
+	}
+	if err := config.SaveDefaultView(name); err != nil {
+		return m.showError("Failed to save default view: ", err)
+	}
+	m.config.Defaults.View = name
+	m.statusMsg = m.tabTitle(m.activeTab) + " opens at startup"
+	m.statusErr = false
+	return m, nil
+}
@@ -0,0 +1,355 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// fileVersion is bumped whenever the on-disk layout changes incompatibly
+const fileVersion = 1
+
+// maxIssues caps how many issues are kept per workspace
+const maxIssues = 5000
+
+// Keys for workspace-level entries
+const (
+	KeyViewer   = "viewer"
+	KeyTeams    = "teams"
+	KeyProjects = "projects"
+	KeyUsers    = "users"
+)
+
+// StatesKey returns the entry key for a team's workflow states
+func StatesKey(teamID string) string {
+	return "states:" + teamID
+}
+
+// LabelsKey returns the entry key for a team's labels
+func LabelsKey(teamID string) string {
+	return "labels:" + teamID
+}
+
+// CyclesKey returns the entry key for a team's current and upcoming cycles
+func CyclesKey(teamID string) string {
+	return "cycles:" + teamID
+}
+
+// Store is a persistent on-disk cache of Linear data, keyed by workspace.
+// It is safe for concurrent use from tea.Cmd goroutines.
+type Store struct {
+	mu        sync.Mutex
+	dir       string
+	keyHash   string
+	workspace string
+	data      cacheFile
+}
+
+type cacheFile struct {
+	Version int                     `json:"version"`
+	Entries map[string]entry        `json:"entries"`
+	Issues  map[string]linear.Issue `json:"issues"`
+	Lists   map[string]issueList    `json:"lists"`
+}
+
+type entry struct {
+	SavedAt time.Time       `json:"savedAt"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type issueList struct {
+	SavedAt  time.Time       `json:"savedAt"`
+	IDs      []string        `json:"ids"`
+	PageInfo linear.PageInfo `json:"pageInfo"`
+}
+
+// Open opens the cache under dir for the workspace last seen with apiKey.
+// The API key itself is never written to disk, only a hash of it.
+func Open(dir, apiKey string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(apiKey))
+	s := &Store{
+		dir:     dir,
+		keyHash: hex.EncodeToString(sum[:8]),
+		data:    emptyFile(),
+	}
+
+	index := s.readIndex()
+	if workspace, ok := index[s.keyHash]; ok {
+		s.workspace = workspace
+		s.load()
+	}
+
+	return s, nil
+}
+
+// Workspace returns the ID of the workspace the cache is bound to
+func (s *Store) Workspace() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.workspace
+}
+
+// SetWorkspace binds the cache to a workspace (organization) ID, loading any
+// data previously stored for it
+func (s *Store) SetWorkspace(workspaceID string) {
+	if workspaceID == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.workspace == workspaceID {
+		return
+	}
+	s.workspace = workspaceID
+	s.data = emptyFile()
+	s.load()
+
+	index := s.readIndex()
+	index[s.keyHash] = workspaceID
+	if b, err := json.Marshal(index); err == nil {
+		_ = writeFileAtomic(filepath.Join(s.dir, "index.json"), b)
+	}
+}
+
+// Get decodes the entry stored under key into v and reports when it was saved
+func (s *Store) Get(key string, v interface{}) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.data.Entries[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, false
+	}
+	return e.SavedAt, true
+}
+
+// Put stores v under key and persists the cache
+func (s *Store) Put(key string, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.workspace == "" {
+		return
+	}
+	s.data.Entries[key] = entry{SavedAt: time.Now(), Data: b}
+	s.save()
+}
+
+// PutIssues merges issues into the cache. An issue already cached with a
+// newer updatedAt is kept, so out-of-order responses never regress data.
+func (s *Store) PutIssues(issues []linear.Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.workspace == "" {
+		return
+	}
+	s.mergeIssues(issues)
+	s.save()
+}
+
+// PutIssueList stores the result of an issue list query under key. When
+// appendPage is true the issues extend the previously stored list.
+func (s *Store) PutIssueList(key string, issues []linear.Issue, pageInfo linear.PageInfo, appendPage bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.workspace == "" {
+		return
+	}
+	s.mergeIssues(issues)
+
+	list := issueList{SavedAt: time.Now(), PageInfo: pageInfo}
+	if appendPage {
+		list.IDs = append(list.IDs, s.data.Lists[key].IDs...)
+	}
+	for _, issue := range issues {
+		list.IDs = append(list.IDs, issue.ID)
+	}
+	s.data.Lists[key] = list
+	s.save()
+}
+
+// IssueList returns the issues last stored under key
+func (s *Store) IssueList(key string) ([]linear.Issue, linear.PageInfo, time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, ok := s.data.Lists[key]
+	if !ok {
+		return nil, linear.PageInfo{}, time.Time{}, false
+	}
+
+	issues := make([]linear.Issue, 0, len(list.IDs))
+	for _, id := range list.IDs {
+		if issue, ok := s.data.Issues[id]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, list.PageInfo, list.SavedAt, true
+}
+
+// Issue returns a single cached issue by ID or identifier
+func (s *Store) Issue(idOrIdentifier string) (*linear.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if issue, ok := s.data.Issues[idOrIdentifier]; ok {
+		return &issue, true
+	}
+	for _, issue := range s.data.Issues {
+		if issue.Identifier == idOrIdentifier {
+			issue := issue
+			return &issue, true
+		}
+	}
+	return nil, false
+}
+
+// Issues returns every cached issue in the workspace
+func (s *Store) Issues() []linear.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issues := make([]linear.Issue, 0, len(s.data.Issues))
+	for _, issue := range s.data.Issues {
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// RemoveIssue drops an issue from the cache (e.g. after it was deleted)
+func (s *Store) RemoveIssue(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data.Issues[id]; !ok {
+		return
+	}
+	delete(s.data.Issues, id)
+	s.save()
+}
+
+// mergeIssues merges issues into memory, keeping whichever copy is newer.
+// Callers must hold s.mu.
+func (s *Store) mergeIssues(issues []linear.Issue) {
+	for _, issue := range issues {
+		if existing, ok := s.data.Issues[issue.ID]; ok && existing.UpdatedAt.After(issue.UpdatedAt) {
+			continue
+		}
+		s.data.Issues[issue.ID] = issue
+	}
+	s.prune()
+}
+
+// prune drops the least recently updated issues beyond maxIssues.
+// Callers must hold s.mu.
+func (s *Store) prune() {
+	if len(s.data.Issues) <= maxIssues {
+		return
+	}
+
+	all := make([]linear.Issue, 0, len(s.data.Issues))
+	for _, issue := range s.data.Issues {
+		all = append(all, issue)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].UpdatedAt.After(all[j].UpdatedAt)
+	})
+	for _, issue := range all[maxIssues:] {
+		delete(s.data.Issues, issue.ID)
+	}
+}
+
+func (s *Store) path() string {
+	return filepath.Join(s.dir, s.workspace+".json")
+}
+
+// load reads the workspace file into memory. Callers must hold s.mu.
+func (s *Store) load() {
+	b, err := os.ReadFile(s.path())
+	if err != nil {
+		return
+	}
+
+	var f cacheFile
+	if err := json.Unmarshal(b, &f); err != nil || f.Version != fileVersion {
+		return
+	}
+	if f.Entries == nil {
+		f.Entries = map[string]entry{}
+	}
+	if f.Issues == nil {
+		f.Issues = map[string]linear.Issue{}
+	}
+	if f.Lists == nil {
+		f.Lists = map[string]issueList{}
+	}
+	s.data = f
+}
+
+// save writes the workspace file to disk. Callers must hold s.mu.
+func (s *Store) save() {
+	b, err := json.Marshal(s.data)
+	if err != nil {
+		return
+	}
+	_ = writeFileAtomic(s.path(), b)
+}
+
+func (s *Store) readIndex() map[string]string {
+	index := map[string]string{}
+	if b, err := os.ReadFile(filepath.Join(s.dir, "index.json")); err == nil {
+		_ = json.Unmarshal(b, &index)
+	}
+	return index
+}
+
+func emptyFile() cacheFile {
+	return cacheFile{
+		Version: fileVersion,
+		Entries: map[string]entry{},
+		Issues:  map[string]linear.Issue{},
+		Lists:   map[string]issueList{},
+	}
+}
+
+// writeFileAtomic writes data to a temp file and renames it into place so a
+// crash mid-write never leaves a truncated cache behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -16,6 +16,7 @@ type Config struct {
 	Git      GitConfig      `mapstructure:"git"`
 	AI       AIConfig       `mapstructure:"ai"`
 	Opencode OpencodeConfig `mapstructure:"opencode"`
+	Views    []ViewConfig   `mapstructure:"views"`
 }
 
 // LinearConfig holds Linear API configuration
@@ -27,21 +28,64 @@ type LinearConfig struct {
 type DefaultsConfig struct {
 	Team    string `mapstructure:"team"`
 	Project string `mapstructure:"project"`
-	View    string `mapstructure:"view"`
+	View    string `mapstructure:"view"` // my-issues, all, active, backlog, cycle, project or a view name
+}
+
+// ViewConfig is a named issue view, shown as a tab after the built-in ones
+type ViewConfig struct {
+	Name    string           `mapstructure:"name"`
+	Filter  ViewFilterConfig `mapstructure:"filter"`
+	Sort    string           `mapstructure:"sort"`    // status, priority, due, created, updated, estimate, identifier or manual
+	Group   string           `mapstructure:"group"`   // state, assignee, project, cycle, label, priority or none
+	Columns []string         `mapstructure:"columns"` // column names in order; ui.columns if empty
+}
+
+// ViewFilterConfig filters the issues of a view. Names are matched ignoring
+// case; every field that is set must match.
+type ViewFilterConfig struct {
+	Team       string   `mapstructure:"team"`       // team key or name
+	Assignee   string   `mapstructure:"assignee"`   // name, display name or email; "me" or "none"
+	States     []string `mapstructure:"states"`     // state names or types (backlog, unstarted, started, completed, canceled)
+	Labels     []string `mapstructure:"labels"`     // label names; matches any of them
+	Priorities []int    `mapstructure:"priorities"` // 0 (none) to 4 (low)
+	Project    string   `mapstructure:"project"`    // project name
+	Cycle      string   `mapstructure:"cycle"`      // number or name, or current, next, previous
+	Due        string   `mapstructure:"due"`        // date range, e.g. "+7d" or "2024-01-01..2024-01-31"
+	Created    string   `mapstructure:"created"`
+	Updated    string   `mapstructure:"updated"`
 }
 
 // UIConfig holds UI preferences
 type UIConfig struct {
-	Theme      string `mapstructure:"theme"`
-	VimMode    bool   `mapstructure:"vim_mode"`
-	ShowIDs    bool   `mapstructure:"show_ids"`
-	DateFormat string `mapstructure:"date_format"`
+	Theme      string                  `mapstructure:"theme"`
+	VimMode    bool                    `mapstructure:"vim_mode"`
+	ShowIDs    bool                    `mapstructure:"show_ids"`
+	DateFormat string                  `mapstructure:"date_format"`
+	Columns    []ColumnConfig          `mapstructure:"columns"` // Issue list columns, in order
+	Layouts    map[string]LayoutConfig `mapstructure:"layouts"` // Sort and grouping of tabs that aren't saved views, by tab name
+}
+
+// LayoutConfig is how a tab's issue list is sorted and grouped
+type LayoutConfig struct {
+	Sort  string `mapstructure:"sort"`
+	Group string `mapstructure:"group"`
+}
+
+// ColumnConfig is a column of the issue list
+type ColumnConfig struct {
+	Name      string `mapstructure:"name"`       // identifier, title, state, priority, assignee, labels, project, cycle, estimate, due or updated
+	Width     int    `mapstructure:"width"`      // 0 for the default; the title fills the rest and this is its minimum
+	HideBelow int    `mapstructure:"hide_below"` // hide on terminals narrower than this
+	Hidden    bool   `mapstructure:"hidden"`     // turned off in the column menu
 }
 
 // GitConfig holds git integration settings
 type GitConfig struct {
-	BranchPrefix string `mapstructure:"branch_prefix"`
-	BranchFormat string `mapstructure:"branch_format"`
+	BranchPrefix    string            `mapstructure:"branch_prefix"`
+	BranchFormat    string            `mapstructure:"branch_format"`     // placeholders: {prefix}, {type}, {id}, {team}, {assignee}, {title}
+	BranchMaxLength int               `mapstructure:"branch_max_length"` // 0 means no limit
+	TypeLabels      map[string]string `mapstructure:"type_labels"`       // label name -> {type}
+	StartOnCheckout bool              `mapstructure:"start_on_checkout"` // move the issue to a started state when checking out its branch
 }
 
 // AIConfig holds AI provider configuration
@@ -137,6 +181,15 @@ func setDefaults(v *viper.Viper) {
 	// Git defaults
 	v.SetDefault("git.branch_prefix", "feature")
 	v.SetDefault("git.branch_format", "{prefix}/{id}-{title}")
+	v.SetDefault("git.branch_max_length", 60)
+	v.SetDefault("git.type_labels", map[string]string{
+		"bug":           "fix",
+		"feature":       "feat",
+		"improvement":   "feat",
+		"chore":         "chore",
+		"documentation": "docs",
+	})
+	v.SetDefault("git.start_on_checkout", false)
 
 	// AI defaults
 	v.SetDefault("ai.provider", "openai")
@@ -160,6 +213,11 @@ func ConfigDir() string {
 	return filepath.Join(os.Getenv("HOME"), ".config", "lazyliner")
 }
 
+// CacheDir returns the directory holding the local issue cache
+func CacheDir() string {
+	return filepath.Join(ConfigDir(), "cache")
+}
+
 // EnsureConfigDir creates the config directory if it doesn't exist
 func EnsureConfigDir() error {
 	return os.MkdirAll(ConfigDir(), 0755)
@@ -167,6 +225,103 @@ func EnsureConfigDir() error {
 
 // SaveProjectFilter saves the selected project filter to config file
 func SaveProjectFilter(projectID string) error {
+	return saveSetting("defaults.project", projectID)
+}
+
+// SaveDefaultView saves the view lazyliner opens at startup to config file
+func SaveDefaultView(view string) error {
+	return saveSetting("defaults.view", view)
+}
+
+// SaveViews saves the named views to config file, replacing the existing ones
+func SaveViews(views []ViewConfig) error {
+	values := make([]map[string]interface{}, len(views))
+	for i, view := range views {
+		values[i] = map[string]interface{}{
+			"name":   view.Name,
+			"filter": view.Filter.values(),
+		}
+		if view.Sort != "" {
+			values[i]["sort"] = view.Sort
+		}
+		if view.Group != "" {
+			values[i]["group"] = view.Group
+		}
+		if len(view.Columns) > 0 {
+			values[i]["columns"] = view.Columns
+		}
+	}
+	return saveSetting("views", values)
+}
+
+// SaveColumns saves the issue list columns to config file
+func SaveColumns(columns []ColumnConfig) error {
+	values := make([]map[string]interface{}, len(columns))
+	for i, col := range columns {
+		values[i] = map[string]interface{}{"name": col.Name}
+		if col.Width > 0 {
+			values[i]["width"] = col.Width
+		}
+		if col.HideBelow > 0 {
+			values[i]["hide_below"] = col.HideBelow
+		}
+		if col.Hidden {
+			values[i]["hidden"] = true
+		}
+	}
+	return saveSetting("ui.columns", values)
+}
+
+// SaveLayouts saves the sort and grouping of tabs to config file
+func SaveLayouts(layouts map[string]LayoutConfig) error {
+	values := make(map[string]interface{}, len(layouts))
+	for tab, layout := range layouts {
+		value := make(map[string]interface{})
+		if layout.Sort != "" {
+			value["sort"] = layout.Sort
+		}
+		if layout.Group != "" {
+			value["group"] = layout.Group
+		}
+		values[tab] = value
+	}
+	return saveSetting("ui.layouts", values)
+}
+
+// SaveShowIDs saves whether the issue list shows identifiers to config file
+func SaveShowIDs(show bool) error {
+	return saveSetting("ui.show_ids", show)
+}
+
+// values returns the filter's fields that are set, keyed as in config.yaml
+func (f ViewFilterConfig) values() map[string]interface{} {
+	values := make(map[string]interface{})
+	set := func(key, value string) {
+		if value != "" {
+			values[key] = value
+		}
+	}
+	set("team", f.Team)
+	set("assignee", f.Assignee)
+	set("project", f.Project)
+	set("cycle", f.Cycle)
+	set("due", f.Due)
+	set("created", f.Created)
+	set("updated", f.Updated)
+	if len(f.States) > 0 {
+		values["states"] = f.States
+	}
+	if len(f.Labels) > 0 {
+		values["labels"] = f.Labels
+	}
+	if len(f.Priorities) > 0 {
+		values["priorities"] = f.Priorities
+	}
+	return values
+}
+
+// saveSetting updates a single setting in the config file, keeping the rest
+func saveSetting(key string, value interface{}) error {
 	if err := EnsureConfigDir(); err != nil {
 		return err
 	}
@@ -179,8 +334,7 @@ func SaveProjectFilter(projectID string) error {
 	// Try to read existing config
 	_ = v.ReadInConfig()
 
-	// Update the project setting
-	v.Set("defaults.project", projectID)
+	v.Set(key, value)
 
 	// Write the config
 	configPath := filepath.Join(ConfigDir(), "config.yaml")
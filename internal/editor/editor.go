@@ -0,0 +1,113 @@
+// Package editor opens drafts in the user's text editor. Issue drafts carry
+// their fields as front matter above the markdown description.
+package editor
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Command returns the command that opens path in $VISUAL or $EDITOR,
+// falling back to vi. The variables may include arguments, as in
+// "code --wait".
+func Command(path string) *exec.Cmd {
+	editor := "vi"
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			editor = value
+			break
+		}
+	}
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], path)...)
+}
+
+// WriteTemp writes text to a new temporary markdown file and returns its path
+func WriteTemp(text string) (string, error) {
+	f, err := os.CreateTemp("", "lazyliner-*.md")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// Field is a front matter field
+type Field struct {
+	Key   string
+	Value string
+}
+
+// Format renders fields as front matter between "---" lines, followed by
+// body. Without fields it returns body alone.
+func Format(fields []Field, body string) string {
+	if len(fields) == 0 {
+		return body
+	}
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, f := range fields {
+		b.WriteString(strings.TrimSpace(f.Key+": "+f.Value) + "\n")
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(body)
+	return b.String()
+}
+
+// Parse splits text into its front matter fields, keyed by lowercased name,
+// and the body. Text that doesn't start with "---" is all body. Lines
+// starting with "#" in the front matter are comments.
+func Parse(text string) (map[string]string, string) {
+	text = strings.TrimPrefix(strings.ReplaceAll(text, "\r\n", "\n"), "\ufeff")
+	fields := make(map[string]string)
+
+	rest, ok := strings.CutPrefix(text, "---\n")
+	if !ok {
+		return fields, strings.TrimSpace(text)
+	}
+	front, body, ok := strings.Cut("\n"+rest, "\n---")
+	if !ok {
+		return fields, strings.TrimSpace(text)
+	}
+	// The closing line may hold nothing but the dashes
+	if end := strings.IndexByte(body, '\n'); end >= 0 {
+		body = body[end+1:]
+	} else {
+		body = ""
+	}
+
+	for _, line := range strings.Split(front, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		fields[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return fields, strings.TrimSpace(body)
+}
+
+// List splits a front matter list, written as "a, b" or "[a, b]"
+func List(value string) []string {
+	value = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(value), "["), "]")
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.Trim(strings.TrimSpace(item), `"'`)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
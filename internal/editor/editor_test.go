@@ -0,0 +1,118 @@
+package editor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+		body   string
+	}{
+		{
+			name: "fields and body",
+			fields: []Field{
+				{Key: "title", Value: "Fix login: redirect loops"},
+				{Key: "team", Value: "ENG"},
+				{Key: "priority", Value: "no priority"},
+				{Key: "labels", Value: "bug, regression"},
+			},
+			body: "Steps:\n\n- [ ] open /login\n\n---\n\nAfter a rule",
+		},
+		{
+			name:   "empty values",
+			fields: []Field{{Key: "title", Value: "Draft"}, {Key: "assignee", Value: ""}, {Key: "project", Value: ""}},
+		},
+		{
+			name: "no fields",
+			body: "Just a description",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, body := Parse(Format(tt.fields, tt.body))
+			want := make(map[string]string)
+			for _, f := range tt.fields {
+				want[f.Key] = f.Value
+			}
+			if !reflect.DeepEqual(fields, want) {
+				t.Errorf("fields = %v, want %v", fields, want)
+			}
+			if body != tt.body {
+				t.Errorf("body = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		fields map[string]string
+		body   string
+	}{
+		{
+			name:   "comments, quotes and key case",
+			text:   "---\n# A comment\nTitle: \"Quoted: title\"\nteam: 'ENG'\nnot a field\n---\nBody",
+			fields: map[string]string{"title": "Quoted: title", "team": "ENG"},
+			body:   "Body",
+		},
+		{
+			name:   "windows line endings and byte order mark",
+			text:   "\ufeff---\r\ntitle: Hello\r\n---\r\n\r\nBody\r\n",
+			fields: map[string]string{"title": "Hello"},
+			body:   "Body",
+		},
+		{
+			name:   "closing line only",
+			text:   "---\ntitle: Hello\n---",
+			fields: map[string]string{"title": "Hello"},
+		},
+		{
+			name:   "no front matter",
+			text:   "title: not a field\n\nBody",
+			fields: map[string]string{},
+			body:   "title: not a field\n\nBody",
+		},
+		{
+			name:   "unclosed front matter is body",
+			text:   "---\ntitle: Hello",
+			fields: map[string]string{},
+			body:   "---\ntitle: Hello",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, body := Parse(tt.text)
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+			if body != tt.body {
+				t.Errorf("body = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"bug, regression", []string{"bug", "regression"}},
+		{`["bug", 'ui']`, []string{"bug", "ui"}},
+		{"[]", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := List(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("List(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultBranchFormat is used when no branch format is configured
+const DefaultBranchFormat = "{prefix}/{id}-{title}"
+
+// BranchInfo holds the issue details a branch name template can use
+type BranchInfo struct {
+	Identifier string   // {id}, e.g. "ENG-123"
+	Title      string   // {title}
+	TeamKey    string   // {team}, e.g. "ENG"
+	Assignee   string   // {assignee}, the assignee's display name
+	Labels     []string // Label names, mapped to {type}
+}
+
+// BranchOptions controls how branch names are rendered
+type BranchOptions struct {
+	Format     string            // Template, e.g. "{prefix}/{id}-{title}"
+	Prefix     string            // {prefix}
+	MaxLength  int               // Maximum length in bytes; 0 means no limit
+	TypeLabels map[string]string // Label name -> {type}; {type} falls back to Prefix
+}
+
+var (
+	slugInvalid    = regexp.MustCompile(`[^a-z0-9]+`)
+	repeatedDashes = regexp.MustCompile(`-{2,}`)
+	emptySegments  = regexp.MustCompile(`/[-/]*/|/-+|-+/`)
+)
+
+// Slugify lowercases s, strips accents and replaces everything but ASCII
+// letters and digits with single dashes
+func Slugify(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(strings.ToLower(s)))
+	s = slugInvalid.ReplaceAllString(s, "-")
+	return strings.Trim(s, "-")
+}
+
+// IssueType returns the {type} value for an issue's labels: the mapping of the
+// first label found in typeLabels, or "" if none match
+func IssueType(labels []string, typeLabels map[string]string) string {
+	for _, label := range labels {
+		for name, typ := range typeLabels {
+			if strings.EqualFold(label, name) {
+				return typ
+			}
+		}
+	}
+	return ""
+}
+
+// BranchName renders a branch name from the template in opts. The title is
+// shortened at a word boundary to keep the name within opts.MaxLength.
+func BranchName(info BranchInfo, opts BranchOptions) string {
+	format := opts.Format
+	if format == "" {
+		format = DefaultBranchFormat
+	}
+
+	typ := IssueType(info.Labels, opts.TypeLabels)
+	if typ == "" {
+		typ = opts.Prefix
+	}
+
+	render := func(title string) string {
+		name := strings.NewReplacer(
+			"{prefix}", Slugify(opts.Prefix),
+			"{type}", Slugify(typ),
+			"{id}", Slugify(info.Identifier),
+			"{team}", Slugify(info.TeamKey),
+			"{assignee}", Slugify(info.Assignee),
+			"{title}", title,
+		).Replace(format)
+		return cleanBranchName(name)
+	}
+
+	title := Slugify(info.Title)
+	name := render(title)
+	if opts.MaxLength <= 0 || len(name) <= opts.MaxLength {
+		return name
+	}
+
+	// Drop title words until the name fits
+	for len(name) > opts.MaxLength && title != "" {
+		if i := strings.LastIndex(title, "-"); i > 0 {
+			title = title[:i]
+		} else {
+			title = ""
+		}
+		name = render(title)
+	}
+	if len(name) > opts.MaxLength {
+		name = cleanBranchName(name[:opts.MaxLength])
+	}
+	return name
+}
+
+// cleanBranchName tidies separators left behind by empty placeholders
+func cleanBranchName(name string) string {
+	name = repeatedDashes.ReplaceAllString(name, "-")
+	for emptySegments.MatchString(name) {
+		name = emptySegments.ReplaceAllString(name, "/")
+	}
+	return strings.Trim(name, "-/.")
+}
+
+// identifierPattern matches a Linear issue identifier such as "ENG-123"
+const identifierPattern = `[A-Za-z][A-Za-z0-9]{0,9}-[0-9]+`
+
+// looseIdentifier finds an identifier delimited by the start or end of the
+// branch or a separator, as in Linear's own "user/eng-123-title" names
+var looseIdentifier = regexp.MustCompile(`(?:^|[/_.-])(` + identifierPattern + `)(?:$|[/_.-])`)
+
+// prefixedIdentifier finds an identifier starting the segment after the first
+// slash, where Linear's own branch names put it
+var prefixedIdentifier = regexp.MustCompile(`^[^/]+/(` + identifierPattern + `)(?:$|[/_.-])`)
+
+// placeholderPattern matches a {placeholder} in a branch format
+var placeholderPattern = regexp.MustCompile(`\{[a-z]+\}`)
+
+// IssueIdentifier extracts the issue identifier (e.g. "ENG-123") from a
+// branch name. Names following format are matched exactly. If teamKeys is
+// non-empty, any identifier-like segment of those teams also matches.
+// Without team keys, names like "release-2024" can't be told apart from
+// identifiers, so only Linear's own "user/eng-123-title" names match besides
+// format.
+func IssueIdentifier(branch, format string, teamKeys []string) (string, bool) {
+	if format == "" {
+		format = DefaultBranchFormat
+	}
+
+	var candidates []string
+	if re := formatPattern(format); re != nil {
+		if m := re.FindStringSubmatch(branch); m != nil {
+			candidates = append(candidates, m[1])
+		}
+	}
+	if len(teamKeys) == 0 {
+		if m := prefixedIdentifier.FindStringSubmatch(branch); m != nil {
+			candidates = append(candidates, m[1])
+		}
+	} else {
+		// Matches share separators, so search again from the end of each
+		for rest := branch; ; {
+			loc := looseIdentifier.FindStringSubmatchIndex(rest)
+			if loc == nil {
+				break
+			}
+			candidates = append(candidates, rest[loc[2]:loc[3]])
+			rest = rest[loc[3]:]
+		}
+	}
+
+	for _, id := range candidates {
+		id = strings.ToUpper(id)
+		if len(teamKeys) == 0 {
+			return id, true
+		}
+		key := id[:strings.LastIndex(id, "-")]
+		for _, teamKey := range teamKeys {
+			if strings.EqualFold(key, teamKey) {
+				return id, true
+			}
+		}
+	}
+	return "", false
+}
+
+// formatPattern compiles a branch format into a regexp capturing {id}, or
+// nil if the format has no {id}
+func formatPattern(format string) *regexp.Regexp {
+	if !strings.Contains(format, "{id}") {
+		return nil
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range placeholderPattern.FindAllStringIndex(format, -1) {
+		pattern.WriteString(regexp.QuoteMeta(format[last:loc[0]]))
+		switch format[loc[0]:loc[1]] {
+		case "{id}":
+			pattern.WriteString("(" + identifierPattern + ")")
+		case "{title}":
+			pattern.WriteString(".*?")
+		default:
+			pattern.WriteString("[^/]*?")
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(format[last:]))
+	pattern.WriteString("$")
+
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// BranchExists reports whether a local branch exists
+func BranchExists(name string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+name)
+	return cmd.Run() == nil
+}
+
+// CheckoutBranch switches to a branch, creating it from HEAD if it doesn't
+// exist. It reports whether the branch was created.
+func CheckoutBranch(name string) (bool, error) {
+	if err := runGit("check-ref-format", "--branch", name); err != nil {
+		return false, fmt.Errorf("invalid branch name %q", name)
+	}
+
+	created := !BranchExists(name)
+	args := []string{"checkout", name}
+	if created {
+		args = []string{"checkout", "-b", name}
+	}
+	if err := runGit(args...); err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// runGit runs a git command, returning git's error output on failure
+func runGit(args ...string) error {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return nil
+}
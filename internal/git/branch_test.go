@@ -0,0 +1,194 @@
+package git
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Fix login redirect", "fix-login-redirect"},
+		{"  API: 500 on /issues?page=2  ", "api-500-on-issues-page-2"},
+		{"Café crème déjà vu", "cafe-creme-deja-vu"},
+		{"日本語のタイトル", ""},
+		{"emoji 🚀 launch", "emoji-launch"},
+		{"--already-slugged--", "already-slugged"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBranchName(t *testing.T) {
+	info := BranchInfo{
+		Identifier: "ENG-123",
+		Title:      "Fix the login redirect loop",
+		TeamKey:    "ENG",
+		Assignee:   "Jane Doe",
+		Labels:     []string{"Bug"},
+	}
+
+	tests := []struct {
+		name string
+		info BranchInfo
+		opts BranchOptions
+		want string
+	}{
+		{
+			name: "default format",
+			info: info,
+			opts: BranchOptions{Prefix: "feature"},
+			want: "feature/eng-123-fix-the-login-redirect-loop",
+		},
+		{
+			name: "team, id and title",
+			info: info,
+			opts: BranchOptions{Format: "{team}/{id}/{title}"},
+			want: "eng/eng-123/fix-the-login-redirect-loop",
+		},
+		{
+			name: "assignee",
+			info: info,
+			opts: BranchOptions{Format: "{assignee}/{id}-{title}"},
+			want: "jane-doe/eng-123-fix-the-login-redirect-loop",
+		},
+		{
+			name: "type from labels",
+			info: info,
+			opts: BranchOptions{Format: "{type}/{id}", Prefix: "feature", TypeLabels: map[string]string{"bug": "fix"}},
+			want: "fix/eng-123",
+		},
+		{
+			name: "type falls back to prefix",
+			info: info,
+			opts: BranchOptions{Format: "{type}/{id}", Prefix: "feature", TypeLabels: map[string]string{"chore": "chore"}},
+			want: "feature/eng-123",
+		},
+		{
+			name: "empty placeholders leave no separators",
+			info: BranchInfo{Identifier: "ENG-123", Title: "Fix"},
+			opts: BranchOptions{Format: "{prefix}/{assignee}/{id}-{title}"},
+			want: "eng-123-fix",
+		},
+		{
+			name: "empty title",
+			info: BranchInfo{Identifier: "ENG-123", Title: "日本語"},
+			opts: BranchOptions{Prefix: "feature"},
+			want: "feature/eng-123",
+		},
+		{
+			name: "unicode title",
+			info: BranchInfo{Identifier: "ENG-7", Title: "Résumé upload fails"},
+			opts: BranchOptions{Format: "{id}-{title}"},
+			want: "eng-7-resume-upload-fails",
+		},
+		{
+			name: "title shortened at a word boundary",
+			info: info,
+			opts: BranchOptions{Prefix: "feature", MaxLength: 30},
+			want: "feature/eng-123-fix-the-login",
+		},
+		{
+			name: "title dropped when nothing else fits",
+			info: info,
+			opts: BranchOptions{Prefix: "feature", MaxLength: 16},
+			want: "feature/eng-123",
+		},
+		{
+			name: "cut when even the id doesn't fit",
+			info: info,
+			opts: BranchOptions{Prefix: "feature", MaxLength: 10},
+			want: "feature/en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BranchName(tt.info, tt.opts)
+			if got != tt.want {
+				t.Errorf("BranchName() = %q, want %q", got, tt.want)
+			}
+			if tt.opts.MaxLength > 0 && len(got) > tt.opts.MaxLength {
+				t.Errorf("BranchName() is %d bytes, max %d", len(got), tt.opts.MaxLength)
+			}
+		})
+	}
+}
+
+func TestFormatPattern(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		branch string
+		want   string // Captured identifier; "" if the branch doesn't match
+	}{
+		{"default format", DefaultBranchFormat, "feature/eng-123-fix-login", "eng-123"},
+		{"team, id and title", "{team}/{id}/{title}", "eng/eng-123/fix-login", "eng-123"},
+		{"title containing an id", "{id}-{title}", "eng-1-revert-eng-2", "eng-1"},
+		{"id only", "{id}", "eng-42", "eng-42"},
+		{"literal text is matched", "issue/{id}", "feature/eng-42", ""},
+		{"placeholders don't span segments", "{prefix}/{id}", "a/b/eng-42", ""},
+		{"metacharacters are literal", "{prefix}.{id}", "featurexeng-42", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := formatPattern(tt.format)
+			if re == nil {
+				t.Fatalf("formatPattern(%q) = nil", tt.format)
+			}
+			got := ""
+			if m := re.FindStringSubmatch(tt.branch); m != nil {
+				got = m[1]
+			}
+			if got != tt.want {
+				t.Errorf("formatPattern(%q) matched %q in %q, want %q", tt.format, got, tt.branch, tt.want)
+			}
+		})
+	}
+
+	if re := formatPattern("{prefix}/{title}"); re != nil {
+		t.Errorf("formatPattern() without {id} = %v, want nil", re)
+	}
+}
+
+func TestIssueIdentifier(t *testing.T) {
+	teamKeys := []string{"ENG", "Ops"}
+
+	tests := []struct {
+		name     string
+		branch   string
+		format   string
+		teamKeys []string
+		want     string // "" if no issue is found
+	}{
+		{name: "default format", branch: "feature/eng-123-fix-login", want: "ENG-123"},
+		{name: "configured format", branch: "eng-123-fix-login", format: "{id}-{title}", want: "ENG-123"},
+		{name: "configured format with team", branch: "eng/eng-7/fix", format: "{team}/{id}/{title}", want: "ENG-7"},
+		{name: "linear branch name", branch: "jane/eng-123-fix-login", format: "{id}", want: "ENG-123"},
+		{name: "linear branch name without title", branch: "jane/ops-9", format: "{id}", want: "OPS-9"},
+		{name: "release branch", branch: "release-2024"},
+		{name: "hotfix branch", branch: "hotfix-1"},
+		{name: "identifier further along", branch: "jane/wip/eng-123"},
+		{name: "plain branch", branch: "main"},
+
+		{name: "known team anywhere", branch: "wip/fix-eng-123", teamKeys: teamKeys, want: "ENG-123"},
+		{name: "team keys ignore case", branch: "OPS-12-deploy", teamKeys: teamKeys, want: "OPS-12"},
+		{name: "unknown team", branch: "release-2024", teamKeys: teamKeys},
+		{name: "known team after unknown", branch: "hotfix-1/eng-5", teamKeys: teamKeys, want: "ENG-5"},
+		{name: "format match of unknown team", branch: "feature/web-1-fix", teamKeys: teamKeys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := IssueIdentifier(tt.branch, tt.format, tt.teamKeys)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("IssueIdentifier(%q) = %q, %v, want %q", tt.branch, got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Commit is a commit in the local repository
+type Commit struct {
+	Hash      string
+	ShortHash string
+	Subject   string
+	Author    string
+	Date      time.Time
+}
+
+// commitFieldSep separates fields in the git log format below
+const commitFieldSep = "\x1f"
+
+// CommitsMentioning returns up to limit commits on local branches whose
+// message mentions the issue identifier, newest first
+func CommitsMentioning(identifier string, limit int) ([]Commit, error) {
+	// Match ENG-12 but not ENG-123
+	pattern := regexp.QuoteMeta(identifier) + "([^0-9]|$)"
+	cmd := exec.Command("git", "log", "--branches",
+		"--regexp-ignore-case", "--extended-regexp", "--grep="+pattern,
+		fmt.Sprintf("--max-count=%d", limit),
+		"--format=%H%x1f%h%x1f%s%x1f%an%x1f%aI",
+	)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git log: %s", msg)
+		}
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		fields := strings.Split(line, commitFieldSep)
+		if len(fields) != 5 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[4])
+		commits = append(commits, Commit{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Subject:   fields[2],
+			Author:    fields[3],
+			Date:      date,
+		})
+	}
+	return commits, nil
+}
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"syscall"
 	"time"
 )
 
@@ -17,13 +22,16 @@ const (
 // Client is a Linear GraphQL API client
 type Client struct {
 	apiKey     string
+	endpoint   string
 	httpClient *http.Client
+	limiter    rateLimiter
 }
 
 // NewClient creates a new Linear API client
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
+		apiKey:   apiKey,
+		endpoint: apiURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -42,12 +50,46 @@ type graphQLResponse struct {
 	Errors []graphQLError  `json:"errors,omitempty"`
 }
 
-type graphQLError struct {
-	Message string `json:"message"`
-	Path    []any  `json:"path,omitempty"`
+// statusError is returned when the API responds with a non-200 status
+type statusError struct {
+	Status     int
+	Body       string
+	RetryAfter time.Duration
 }
 
-// execute executes a GraphQL query
+func (e *statusError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.Status, e.Body)
+}
+
+// IsNetworkError reports whether err is a transport failure rather than an
+// API-level error: the API could not be reached, or the connection broke or
+// timed out before a response came back. In the latter case the request may
+// still have been carried out; IsUnsent tells the two apart. Requests
+// canceled by the caller are not network errors.
+func IsNetworkError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsUnsent reports whether a request failed before reaching the API (DNS
+// failure, refused connection), so sending it again can't repeat its effect
+func IsUnsent(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// execute executes a GraphQL query. Transient failures are retried with
+// exponential backoff, and requests are paced to stay under the rate limit.
 func (c *Client) execute(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
 	reqBody := graphQLRequest{
 		Query:     query,
@@ -59,9 +101,39 @@ func (c *Client) execute(ctx context.Context, query string, variables map[string
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonBody))
+	// Mutations that may have reached the server are not safe to repeat
+	idempotent := !isMutation(query)
+
+	for attempt := 0; ; attempt++ {
+		if err := c.throttle(ctx); err != nil {
+			return err
+		}
+
+		data, err := c.send(ctx, jsonBody)
+		if err == nil {
+			if result != nil {
+				if err := json.Unmarshal(data, result); err != nil {
+					return fmt.Errorf("failed to unmarshal data: %w", err)
+				}
+			}
+			return nil
+		}
+
+		wait, ok := retryDelay(err, attempt, idempotent)
+		if !ok || attempt >= maxRetries || ctx.Err() != nil {
+			return err
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// send performs a single GraphQL request and returns the response data
+func (c *Client) send(ctx context.Context, jsonBody []byte) (json.RawMessage, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(jsonBody))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -69,35 +141,50 @@ func (c *Client) execute(ctx context.Context, query string, variables map[string
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	c.limiter.update(resp.Header)
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	var gqlResp graphQLResponse
+	decodeErr := json.Unmarshal(body, &gqlResp)
+
+	var apiErr *APIError
+	if decodeErr == nil && len(gqlResp.Errors) > 0 {
+		apiErr = newAPIError(resp.StatusCode, gqlResp.Errors)
 	}
 
-	var gqlResp graphQLResponse
-	if err := json.Unmarshal(body, &gqlResp); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+	if resp.StatusCode == http.StatusTooManyRequests || (apiErr != nil && apiErr.Has(ErrorRateLimited)) {
+		return nil, &RateLimitError{ResetAt: c.limiter.resetAt(retryAfter(resp.Header))}
 	}
 
-	if len(gqlResp.Errors) > 0 {
-		return fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+	// Linear reports most request errors as GraphQL errors on a 400
+	if apiErr != nil && resp.StatusCode < http.StatusInternalServerError {
+		return nil, apiErr
 	}
 
-	if result != nil {
-		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
-			return fmt.Errorf("failed to unmarshal data: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		if apiErr := statusAPIError(resp.StatusCode); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, &statusError{
+			Status:     resp.StatusCode,
+			Body:       string(body),
+			RetryAfter: retryAfter(resp.Header),
 		}
 	}
 
-	return nil
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", decodeErr)
+	}
+
+	return gqlResp.Data, nil
 }
 
 // GetViewer returns the currently authenticated user
@@ -110,6 +197,11 @@ func (c *Client) GetViewer(ctx context.Context) (*Viewer, error) {
 				displayName
 				email
 				active
+				organization {
+					id
+					name
+					urlKey
+				}
 			}
 		}
 	`
@@ -252,6 +344,47 @@ func (c *Client) GetLabels(ctx context.Context, teamID string) ([]Label, error)
 	return result.IssueLabels.Nodes, nil
 }
 
+// GetCycles returns the current and upcoming cycles for a team
+func (c *Client) GetCycles(ctx context.Context, teamID string) ([]Cycle, error) {
+	query := `
+		query Cycles($teamId: ID!) {
+			cycles(filter: { team: { id: { eq: $teamId } }, isPast: { eq: false } }) {
+				nodes {
+					id
+					number
+					name
+					startsAt
+					endsAt
+					progress
+					isActive
+					isFuture
+					isPast
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"teamId": teamID,
+	}
+
+	var result struct {
+		Cycles struct {
+			Nodes []Cycle `json:"nodes"`
+		} `json:"cycles"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	cycles := result.Cycles.Nodes
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].StartsAt.Before(cycles[j].StartsAt)
+	})
+	return cycles, nil
+}
+
 // GetUsers returns all users in the organization
 func (c *Client) GetUsers(ctx context.Context) ([]User, error) {
 	query := `
@@ -0,0 +1,120 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// testClient returns a client that sends its requests to handler
+func testClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := NewClient("test-key")
+	c.endpoint = server.URL
+	return c
+}
+
+func TestExecuteRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		status    int // Status of the first response; later ones succeed
+		header    map[string]string
+		body      string
+		wantCalls int32
+		wantCode  ErrorCode // Code of the returned error, if any
+	}{
+		{
+			name:      "429 with Retry-After is retried",
+			query:     "query { viewer { id } }",
+			status:    http.StatusTooManyRequests,
+			header:    map[string]string{"Retry-After": "1"},
+			wantCalls: 2,
+		},
+		{
+			name:      "429 is retried for mutations",
+			query:     "mutation { issueDelete(id: \"1\") { success } }",
+			status:    http.StatusTooManyRequests,
+			header:    map[string]string{"Retry-After": "1"},
+			wantCalls: 2,
+		},
+		{
+			name:      "503 is retried",
+			query:     "query { viewer { id } }",
+			status:    http.StatusServiceUnavailable,
+			wantCalls: 2,
+		},
+		{
+			name:      "400 is not retried",
+			query:     "query { viewer { id } }",
+			status:    http.StatusBadRequest,
+			body:      `{"errors":[{"message":"Argument Validation Error","extensions":{"code":"INPUT_ERROR"}}]}`,
+			wantCalls: 1,
+			wantCode:  ErrorValidation,
+		},
+		{
+			name:      "400 without errors is not retried",
+			query:     "query { viewer { id } }",
+			status:    http.StatusBadRequest,
+			wantCalls: 1,
+			wantCode:  ErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					for k, v := range tt.header {
+						w.Header().Set(k, v)
+					}
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+					return
+				}
+				w.Write([]byte(`{"data":{"viewer":{"id":"u1"}}}`))
+			})
+
+			err := c.execute(context.Background(), tt.query, nil, nil)
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("sent %d requests, want %d", got, tt.wantCalls)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("execute() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("execute() succeeded, want an error")
+			}
+			if got := ErrorCodeOf(err); got != tt.wantCode {
+				t.Errorf("ErrorCodeOf() = %q, want %q", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestExecuteRateLimitedTooLong(t *testing.T) {
+	var calls atomic.Int32
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := c.execute(context.Background(), "query { viewer { id } }", nil, nil)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("execute() error = %v, want a RateLimitError", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
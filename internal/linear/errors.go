@@ -0,0 +1,198 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrorCode classifies an error returned by the Linear API
+type ErrorCode string
+
+const (
+	ErrorUnknown        ErrorCode = "unknown"
+	ErrorAuthentication ErrorCode = "authentication"
+	ErrorForbidden      ErrorCode = "forbidden"
+	ErrorNotFound       ErrorCode = "not_found"
+	ErrorValidation     ErrorCode = "validation"
+	ErrorRateLimited    ErrorCode = "rate_limited"
+)
+
+// GraphQLError is a single entry of a GraphQL response's errors array
+type GraphQLError struct {
+	Message     string
+	UserMessage string // Linear's userPresentableMessage, if any
+	Code        ErrorCode
+	RawCode     string // extensions.code exactly as sent
+	Path        []any
+	Field       string // Input field the error refers to (e.g. "title"), if known
+}
+
+// Presentable returns a message suitable for showing to users
+func (e GraphQLError) Presentable() string {
+	if e.UserMessage != "" {
+		return e.UserMessage
+	}
+	return e.Message
+}
+
+// APIError is returned when Linear answers a request with one or more errors
+type APIError struct {
+	Status int // HTTP status of the response
+	Errors []GraphQLError
+}
+
+func (e *APIError) Error() string {
+	return "GraphQL error: " + e.Presentable()
+}
+
+// Presentable returns the user-presentable messages of all errors
+func (e *APIError) Presentable() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, ge := range e.Errors {
+		msgs = append(msgs, ge.Presentable())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Code returns the code of the first error
+func (e *APIError) Code() ErrorCode {
+	if len(e.Errors) == 0 {
+		return ErrorUnknown
+	}
+	return e.Errors[0].Code
+}
+
+// Has reports whether any of the errors has the given code
+func (e *APIError) Has(code ErrorCode) bool {
+	for _, ge := range e.Errors {
+		if ge.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldErrors returns validation messages keyed by input field name
+func (e *APIError) FieldErrors() map[string]string {
+	fields := make(map[string]string)
+	for _, ge := range e.Errors {
+		if ge.Field != "" && ge.Code == ErrorValidation {
+			if _, ok := fields[ge.Field]; !ok {
+				fields[ge.Field] = ge.Presentable()
+			}
+		}
+	}
+	return fields
+}
+
+// ErrorCodeOf returns the code of an error from the Linear API, or
+// ErrorUnknown for anything else
+func ErrorCodeOf(err error) ErrorCode {
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		return ErrorRateLimited
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code()
+	}
+	return ErrorUnknown
+}
+
+// FieldErrors returns the per-field validation messages carried by err
+func FieldErrors(err error) map[string]string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.FieldErrors()
+	}
+	return nil
+}
+
+// graphQLError is the wire format of an error entry
+type graphQLError struct {
+	Message    string `json:"message"`
+	Path       []any  `json:"path,omitempty"`
+	Extensions struct {
+		Code                   string `json:"code"`
+		Type                   string `json:"type"`
+		UserPresentableMessage string `json:"userPresentableMessage"`
+		ArgumentPath           []any  `json:"argumentPath"`
+	} `json:"extensions"`
+}
+
+// inputFieldPattern finds input field references such as `"input.title"`
+var inputFieldPattern = regexp.MustCompile(`input\.([A-Za-z]+)`)
+
+// toGraphQLError converts an error entry from the wire format
+func (e graphQLError) toGraphQLError() GraphQLError {
+	ge := GraphQLError{
+		Message:     e.Message,
+		UserMessage: e.Extensions.UserPresentableMessage,
+		RawCode:     e.Extensions.Code,
+		Path:        e.Path,
+		Code:        classifyError(e.Extensions.Code, e.Extensions.Type, e.Message),
+	}
+
+	if n := len(e.Extensions.ArgumentPath); n > 0 {
+		if field, ok := e.Extensions.ArgumentPath[n-1].(string); ok {
+			ge.Field = field
+		}
+	}
+	if ge.Field == "" {
+		if m := inputFieldPattern.FindStringSubmatch(e.Message); m != nil {
+			ge.Field = m[1]
+		}
+	}
+	return ge
+}
+
+// classifyError maps Linear's extension code and type to an ErrorCode
+func classifyError(code, typ, message string) ErrorCode {
+	code = strings.ToUpper(code)
+	typ = strings.ToLower(typ)
+
+	switch {
+	case code == "RATELIMITED" || typ == "ratelimited":
+		return ErrorRateLimited
+	case code == "AUTHENTICATION_ERROR" || code == "UNAUTHENTICATED" || strings.Contains(typ, "authentication"):
+		return ErrorAuthentication
+	case code == "FORBIDDEN" || strings.Contains(typ, "forbidden"):
+		return ErrorForbidden
+	case strings.Contains(code, "NOT_FOUND") || strings.Contains(typ, "not found") ||
+		strings.Contains(strings.ToLower(message), "entity not found"):
+		return ErrorNotFound
+	case code == "INPUT_ERROR" || code == "BAD_USER_INPUT" || code == "GRAPHQL_VALIDATION_FAILED" ||
+		strings.Contains(typ, "invalid input") || strings.Contains(typ, "validation"):
+		return ErrorValidation
+	}
+	return ErrorUnknown
+}
+
+// newAPIError builds an APIError from a response's errors array
+func newAPIError(status int, wire []graphQLError) *APIError {
+	apiErr := &APIError{Status: status}
+	for _, e := range wire {
+		apiErr.Errors = append(apiErr.Errors, e.toGraphQLError())
+	}
+	return apiErr
+}
+
+// statusAPIError builds an APIError for an error status without a GraphQL
+// errors body. It returns nil for statuses that don't map to an ErrorCode.
+func statusAPIError(status int) *APIError {
+	ge := GraphQLError{Message: fmt.Sprintf("API request failed with status %d", status)}
+	switch status {
+	case http.StatusUnauthorized:
+		ge.Code = ErrorAuthentication
+		ge.UserMessage = "Linear rejected the API key"
+	case http.StatusForbidden:
+		ge.Code = ErrorForbidden
+		ge.UserMessage = "You don't have access to this resource"
+	default:
+		return nil
+	}
+	return &APIError{Status: status, Errors: []GraphQLError{ge}}
+}
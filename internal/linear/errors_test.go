@@ -0,0 +1,127 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"syscall"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		typ     string
+		message string
+		want    ErrorCode
+	}{
+		{name: "rate limited code", code: "RATELIMITED", want: ErrorRateLimited},
+		{name: "rate limited type", typ: "Ratelimited", want: ErrorRateLimited},
+		{name: "authentication code", code: "AUTHENTICATION_ERROR", want: ErrorAuthentication},
+		{name: "unauthenticated code", code: "unauthenticated", want: ErrorAuthentication},
+		{name: "authentication type", typ: "authentication error", want: ErrorAuthentication},
+		{name: "forbidden", code: "FORBIDDEN", want: ErrorForbidden},
+		{name: "forbidden type", typ: "forbidden", want: ErrorForbidden},
+		{name: "not found code", code: "ENTITY_NOT_FOUND", want: ErrorNotFound},
+		{name: "not found message", message: "Entity not found: Issue", want: ErrorNotFound},
+		{name: "input error", code: "INPUT_ERROR", want: ErrorValidation},
+		{name: "bad user input", code: "BAD_USER_INPUT", want: ErrorValidation},
+		{name: "query validation", code: "GRAPHQL_VALIDATION_FAILED", want: ErrorValidation},
+		{name: "invalid input type", typ: "invalid input", want: ErrorValidation},
+		{name: "unknown", code: "INTERNAL_SERVER_ERROR", message: "Something went wrong", want: ErrorUnknown},
+		{name: "empty", want: ErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyError(tt.code, tt.typ, tt.message); got != tt.want {
+				t.Errorf("classifyError(%q, %q, %q) = %q, want %q", tt.code, tt.typ, tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorCodeOf(t *testing.T) {
+	var wire graphQLError
+	wire.Message = "Argument Validation Error: input.title"
+	wire.Extensions.Code = "INPUT_ERROR"
+	validation := newAPIError(http.StatusBadRequest, []graphQLError{wire})
+
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{name: "rate limit", err: &RateLimitError{}, want: ErrorRateLimited},
+		{name: "wrapped rate limit", err: fmt.Errorf("loading issues: %w", &RateLimitError{}), want: ErrorRateLimited},
+		{name: "unauthorized status", err: statusAPIError(http.StatusUnauthorized), want: ErrorAuthentication},
+		{name: "forbidden status", err: statusAPIError(http.StatusForbidden), want: ErrorForbidden},
+		{name: "GraphQL error", err: validation, want: ErrorValidation},
+		{name: "status error", err: &statusError{Status: http.StatusInternalServerError}, want: ErrorUnknown},
+		{name: "network error", err: &url.Error{Op: "Post", URL: apiURL, Err: syscall.ECONNREFUSED}, want: ErrorUnknown},
+		{name: "nil", want: ErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCodeOf(tt.err); got != tt.want {
+				t.Errorf("ErrorCodeOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraphQLErrorField(t *testing.T) {
+	var wire graphQLError
+	wire.Message = "Argument Validation Error"
+	wire.Extensions.Code = "INPUT_ERROR"
+	wire.Extensions.ArgumentPath = []any{"input", "title"}
+	wire.Extensions.UserPresentableMessage = "Title is required"
+
+	apiErr := newAPIError(http.StatusBadRequest, []graphQLError{wire})
+	want := map[string]string{"title": "Title is required"}
+	if got := apiErr.FieldErrors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldErrors() = %v, want %v", got, want)
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	post := func(err error) error {
+		return fmt.Errorf("failed to execute request: %w", &url.Error{Op: "Post", URL: apiURL, Err: err})
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		want   bool
+		unsent bool
+	}{
+		{name: "connection refused", err: post(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}), want: true, unsent: true},
+		{name: "DNS failure", err: post(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "api.linear.app"}}), want: true, unsent: true},
+		{name: "connection reset", err: post(&net.OpError{Op: "read", Err: syscall.ECONNRESET}), want: true},
+		{name: "timeout", err: post(&net.DNSError{Err: "timeout", IsTimeout: true}), want: true},
+		{name: "canceled request", err: post(context.Canceled)},
+		{name: "context canceled", err: context.Canceled},
+		{name: "rate limit", err: &RateLimitError{}},
+		{name: "GraphQL error", err: &APIError{Status: http.StatusBadRequest}},
+		{name: "status error", err: &statusError{Status: http.StatusServiceUnavailable}},
+		{name: "other error", err: errors.New("failed to unmarshal data")},
+		{name: "nil"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNetworkError(tt.err); got != tt.want {
+				t.Errorf("IsNetworkError() = %v, want %v", got, tt.want)
+			}
+			if got := IsUnsent(tt.err); got != tt.unsent {
+				t.Errorf("IsUnsent() = %v, want %v", got, tt.unsent)
+			}
+		})
+	}
+}
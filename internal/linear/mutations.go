@@ -2,6 +2,8 @@ package linear
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 )
 
 // CreateIssue creates a new issue
@@ -40,6 +42,13 @@ func (c *Client) CreateIssue(ctx context.Context, input IssueCreateInput) (*Issu
 						id
 						name
 					}
+					cycle {
+						id
+						number
+						name
+						startsAt
+						endsAt
+					}
 					labels {
 						nodes {
 							id
@@ -47,6 +56,21 @@ func (c *Client) CreateIssue(ctx context.Context, input IssueCreateInput) (*Issu
 							color
 						}
 					}
+					inverseRelations(first: 10) {
+						nodes {
+							id
+							type
+							issue {
+								id
+								identifier
+								title
+								state {
+									name
+									type
+								}
+							}
+						}
+					}
 				}
 			}
 		}
@@ -111,6 +135,13 @@ func (c *Client) UpdateIssue(ctx context.Context, issueID string, input IssueUpd
 						id
 						name
 					}
+					cycle {
+						id
+						number
+						name
+						startsAt
+						endsAt
+					}
 					labels {
 						nodes {
 							id
@@ -118,6 +149,21 @@ func (c *Client) UpdateIssue(ctx context.Context, issueID string, input IssueUpd
 							color
 						}
 					}
+					inverseRelations(first: 10) {
+						nodes {
+							id
+							type
+							issue {
+								id
+								identifier
+								title
+								state {
+									name
+									type
+								}
+							}
+						}
+					}
 				}
 			}
 		}
@@ -125,7 +171,7 @@ func (c *Client) UpdateIssue(ctx context.Context, issueID string, input IssueUpd
 
 	variables := map[string]interface{}{
 		"id":    issueID,
-		"input": input,
+		"input": updateInputVariable(input),
 	}
 
 	var result struct {
@@ -147,6 +193,21 @@ func (c *Client) UpdateIssue(ctx context.Context, issueID string, input IssueUpd
 	return &issues[0], nil
 }
 
+// updateInputVariable encodes an update for the API. Linear clears a
+// reference when it is null, so IDs set to "" (no assignee, project, cycle or
+// parent) are sent as null.
+func updateInputVariable(input IssueUpdateInput) map[string]interface{} {
+	data, _ := json.Marshal(input)
+	var fields map[string]interface{}
+	_ = json.Unmarshal(data, &fields)
+	for _, key := range []string{"assigneeId", "projectId", "cycleId", "parentId", "dueDate"} {
+		if fields[key] == "" {
+			fields[key] = nil
+		}
+	}
+	return fields
+}
+
 // UpdateIssueState updates only the state of an issue
 func (c *Client) UpdateIssueState(ctx context.Context, issueID string, stateID string) (*Issue, error) {
 	return c.UpdateIssue(ctx, issueID, IssueUpdateInput{
@@ -245,3 +306,219 @@ func (c *Client) DeleteIssue(ctx context.Context, issueID string) error {
 
 	return c.execute(ctx, query, variables, &result)
 }
+
+// commentFields is the GraphQL selection for comments returned by mutations
+const commentFields = `
+	id
+	body
+	createdAt
+	updatedAt
+	user {
+		id
+		name
+		displayName
+	}
+`
+
+// CreateLabel creates a new issue label in a team
+func (c *Client) CreateLabel(ctx context.Context, teamID, name string) (*Label, error) {
+	query := `
+		mutation CreateLabel($input: IssueLabelCreateInput!) {
+			issueLabelCreate(input: $input) {
+				success
+				issueLabel {
+					id
+					name
+					description
+					color
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"teamId": teamID,
+			"name":   name,
+		},
+	}
+
+	var result struct {
+		IssueLabelCreate struct {
+			Success    bool   `json:"success"`
+			IssueLabel *Label `json:"issueLabel"`
+		} `json:"issueLabelCreate"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	return result.IssueLabelCreate.IssueLabel, nil
+}
+
+// CreateComment posts a new comment on an issue
+func (c *Client) CreateComment(ctx context.Context, issueID, body string) (*Comment, error) {
+	query := fmt.Sprintf(`
+		mutation CreateComment($input: CommentCreateInput!) {
+			commentCreate(input: $input) {
+				success
+				comment {
+					%s
+				}
+			}
+		}
+	`, commentFields)
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId": issueID,
+			"body":    body,
+		},
+	}
+
+	var result struct {
+		CommentCreate struct {
+			Success bool     `json:"success"`
+			Comment *Comment `json:"comment"`
+		} `json:"commentCreate"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	return result.CommentCreate.Comment, nil
+}
+
+// UpdateComment replaces the body of an existing comment
+func (c *Client) UpdateComment(ctx context.Context, commentID, body string) (*Comment, error) {
+	query := fmt.Sprintf(`
+		mutation UpdateComment($id: String!, $input: CommentUpdateInput!) {
+			commentUpdate(id: $id, input: $input) {
+				success
+				comment {
+					%s
+				}
+			}
+		}
+	`, commentFields)
+
+	variables := map[string]interface{}{
+		"id": commentID,
+		"input": map[string]interface{}{
+			"body": body,
+		},
+	}
+
+	var result struct {
+		CommentUpdate struct {
+			Success bool     `json:"success"`
+			Comment *Comment `json:"comment"`
+		} `json:"commentUpdate"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	return result.CommentUpdate.Comment, nil
+}
+
+// DeleteComment deletes a comment
+func (c *Client) DeleteComment(ctx context.Context, commentID string) error {
+	query := `
+		mutation DeleteComment($id: String!) {
+			commentDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": commentID,
+	}
+
+	var result struct {
+		CommentDelete struct {
+			Success bool `json:"success"`
+		} `json:"commentDelete"`
+	}
+
+	return c.execute(ctx, query, variables, &result)
+}
+
+// CreateRelation relates two issues. relationType is one of the
+// RelationType constants: issueID blocks, duplicates or relates to
+// relatedIssueID.
+func (c *Client) CreateRelation(ctx context.Context, issueID, relatedIssueID, relationType string) (*IssueRelation, error) {
+	query := `
+		mutation CreateRelation($input: IssueRelationCreateInput!) {
+			issueRelationCreate(input: $input) {
+				success
+				issueRelation {
+					id
+					type
+					issue {
+						id
+						identifier
+						title
+					}
+					relatedIssue {
+						id
+						identifier
+						title
+						state {
+							name
+							type
+						}
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"issueId":        issueID,
+			"relatedIssueId": relatedIssueID,
+			"type":           relationType,
+		},
+	}
+
+	var result struct {
+		IssueRelationCreate struct {
+			Success       bool           `json:"success"`
+			IssueRelation *IssueRelation `json:"issueRelation"`
+		} `json:"issueRelationCreate"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	return result.IssueRelationCreate.IssueRelation, nil
+}
+
+// DeleteRelation removes a relation between two issues
+func (c *Client) DeleteRelation(ctx context.Context, relationID string) error {
+	query := `
+		mutation DeleteRelation($id: String!) {
+			issueRelationDelete(id: $id) {
+				success
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": relationID,
+	}
+
+	var result struct {
+		IssueRelationDelete struct {
+			Success bool `json:"success"`
+		} `json:"issueRelationDelete"`
+	}
+
+	return c.execute(ctx, query, variables, &result)
+}
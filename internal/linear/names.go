@@ -0,0 +1,47 @@
+package linear
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// priorityNames maps priority names to Linear's priority numbers
+var priorityNames = map[string]int{"none": 0, "no priority": 0, "urgent": 1, "high": 2, "medium": 3, "low": 4}
+
+// ParsePriority parses a priority given as a number from 0 to 4 or by name:
+// none, urgent, high, medium or low
+func ParsePriority(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, ok := priorityNames[s]; ok {
+		return n, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 4 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("invalid priority %q (0-4 or none, urgent, high, medium, low)", s)
+}
+
+// FindUser returns the user with the given name, display name or email,
+// ignoring case, or nil if there's none
+func FindUser(users []User, name string) *User {
+	name = strings.TrimSpace(name)
+	for i, u := range users {
+		if strings.EqualFold(u.Name, name) || strings.EqualFold(u.DisplayName, name) || strings.EqualFold(u.Email, name) {
+			return &users[i]
+		}
+	}
+	return nil
+}
+
+// FindLabel returns the label with the given name, ignoring case, or nil if
+// there's none
+func FindLabel(labels []Label, name string) *Label {
+	name = strings.TrimSpace(name)
+	for i, label := range labels {
+		if strings.EqualFold(label.Name, name) {
+			return &labels[i]
+		}
+	}
+	return nil
+}
@@ -3,6 +3,10 @@ package linear
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // GetMyIssues returns issues assigned to the current user with pagination support
@@ -22,6 +26,7 @@ func (c *Client) GetMyIssues(ctx context.Context, limit int, after string) (Issu
 						description
 						priority
 						estimate
+						sortOrder
 						createdAt
 						updatedAt
 						startedAt
@@ -59,6 +64,13 @@ func (c *Client) GetMyIssues(ctx context.Context, limit int, after string) (Issu
 							icon
 							color
 						}
+						cycle {
+							id
+							number
+							name
+							startsAt
+							endsAt
+						}
 						labels {
 							nodes {
 								id
@@ -66,6 +78,21 @@ func (c *Client) GetMyIssues(ctx context.Context, limit int, after string) (Issu
 								color
 							}
 						}
+						inverseRelations(first: 10) {
+							nodes {
+								id
+								type
+								issue {
+									id
+									identifier
+									title
+									state {
+										name
+										type
+									}
+								}
+							}
+						}
 					}
 					pageInfo {
 						hasNextPage
@@ -120,6 +147,7 @@ func (c *Client) GetIssues(ctx context.Context, filter IssueFilter) (IssueConnec
 					description
 					priority
 					estimate
+					sortOrder
 					createdAt
 					updatedAt
 					startedAt
@@ -157,6 +185,13 @@ func (c *Client) GetIssues(ctx context.Context, filter IssueFilter) (IssueConnec
 						icon
 						color
 					}
+					cycle {
+						id
+						number
+						name
+						startsAt
+						endsAt
+					}
 					labels {
 						nodes {
 							id
@@ -164,6 +199,21 @@ func (c *Client) GetIssues(ctx context.Context, filter IssueFilter) (IssueConnec
 							color
 						}
 					}
+					inverseRelations(first: 10) {
+						nodes {
+							id
+							type
+							issue {
+								id
+								identifier
+								title
+								state {
+									name
+									type
+								}
+							}
+						}
+					}
 				}
 				pageInfo {
 					hasNextPage
@@ -212,6 +262,7 @@ func (c *Client) GetIssue(ctx context.Context, idOrIdentifier string) (*Issue, e
 				description
 				priority
 				estimate
+				sortOrder
 				createdAt
 				updatedAt
 				startedAt
@@ -249,6 +300,13 @@ func (c *Client) GetIssue(ctx context.Context, idOrIdentifier string) (*Issue, e
 					icon
 					color
 				}
+				cycle {
+					id
+					number
+					name
+					startsAt
+					endsAt
+				}
 				labels {
 					nodes {
 						id
@@ -256,6 +314,36 @@ func (c *Client) GetIssue(ctx context.Context, idOrIdentifier string) (*Issue, e
 						color
 					}
 				}
+				relations(first: 25) {
+					nodes {
+						id
+						type
+						relatedIssue {
+							id
+							identifier
+							title
+							state {
+								name
+								type
+							}
+						}
+					}
+				}
+				inverseRelations(first: 25) {
+					nodes {
+						id
+						type
+						issue {
+							id
+							identifier
+							title
+							state {
+								name
+								type
+							}
+						}
+					}
+				}
 				parent {
 					id
 					identifier
@@ -346,12 +434,204 @@ func (c *Client) SearchIssues(ctx context.Context, query string, limit int) ([]I
 	return convertIssues(result.SearchIssues.Nodes), nil
 }
 
-// rawIssue is the raw issue structure from the API with labels as connection
+// GetComments returns a page of comments on an issue. Pages aren't in date
+// order, so callers sort the comments once they have the pages they need.
+func (c *Client) GetComments(ctx context.Context, issueID string, limit int, after string) (CommentConnection, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	query := `
+		query IssueComments($id: String!, $limit: Int!, $after: String) {
+			issue(id: $id) {
+				comments(first: $limit, after: $after) {
+					nodes {
+						id
+						body
+						createdAt
+						updatedAt
+						user {
+							id
+							name
+							displayName
+						}
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id":    issueID,
+		"limit": limit,
+	}
+	if after != "" {
+		variables["after"] = after
+	}
+
+	var result struct {
+		Issue *struct {
+			Comments CommentConnection `json:"comments"`
+		} `json:"issue"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return CommentConnection{}, err
+	}
+
+	if result.Issue == nil {
+		return CommentConnection{}, fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	return result.Issue.Comments, nil
+}
+
+// GetAttachments returns the pull requests, commits and links attached to an issue
+func (c *Client) GetAttachments(ctx context.Context, issueID string) ([]Attachment, error) {
+	query := `
+		query IssueAttachments($id: String!) {
+			issue(id: $id) {
+				attachments {
+					nodes {
+						id
+						title
+						subtitle
+						url
+						sourceType
+						metadata
+						createdAt
+						updatedAt
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var result struct {
+		Issue *struct {
+			Attachments struct {
+				Nodes []Attachment `json:"nodes"`
+			} `json:"attachments"`
+		} `json:"issue"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Issue == nil {
+		return nil, fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	attachments := result.Issue.Attachments.Nodes
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+// subIssueFields are the fields fetched for issues in a sub-issue tree
+const subIssueFields = `
+	id
+	identifier
+	title
+	priority
+	state {
+		id
+		name
+		color
+		type
+		position
+	}
+	assignee {
+		id
+		name
+		displayName
+	}
+`
+
+// GetIssueHierarchy returns an issue's ancestors (through Parent) and its
+// sub-issues up to three levels deep (through Children). Other fields of
+// the returned issues are left empty.
+func (c *Client) GetIssueHierarchy(ctx context.Context, issueID string) (*Issue, error) {
+	query := fmt.Sprintf(`
+		query IssueHierarchy($id: String!) {
+			issue(id: $id) {
+				id
+				parent {
+					%[1]s
+					parent {
+						%[1]s
+						parent {
+							%[1]s
+						}
+					}
+				}
+				children(first: 50) {
+					nodes {
+						%[1]s
+						children(first: 25) {
+							nodes {
+								%[1]s
+								children(first: 10) {
+									nodes {
+										%[1]s
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	`, subIssueFields)
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var result struct {
+		Issue *rawIssue `json:"issue"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Issue == nil {
+		return nil, fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	issues := convertIssues([]rawIssue{*result.Issue})
+	return &issues[0], nil
+}
+
+// rawIssue is the raw issue structure from the API with labels and
+// sub-issues as connections
 type rawIssue struct {
 	Issue
 	Labels struct {
 		Nodes []Label `json:"nodes"`
 	} `json:"labels"`
+	Children struct {
+		Nodes []rawIssue `json:"nodes"`
+	} `json:"children"`
+	Relations struct {
+		Nodes []IssueRelation `json:"nodes"`
+	} `json:"relations"`
+	InverseRelations struct {
+		Nodes []IssueRelation `json:"nodes"`
+	} `json:"inverseRelations"`
 }
 
 // rawIssueConnection is the raw issue connection structure from the API
@@ -366,10 +646,143 @@ func convertIssues(raw []rawIssue) []Issue {
 	for i, r := range raw {
 		issues[i] = r.Issue
 		issues[i].Labels = r.Labels.Nodes
+		issues[i].Relations = r.Relations.Nodes
+		issues[i].InverseRelations = r.InverseRelations.Nodes
+		if len(r.Children.Nodes) > 0 {
+			issues[i].Children = convertIssues(r.Children.Nodes)
+		}
 	}
 	return issues
 }
 
+// GetCustomViews returns the workspace's custom views that deal with issues,
+// the viewer's favorites first and then by name
+func (c *Client) GetCustomViews(ctx context.Context) ([]CustomView, error) {
+	query := `
+		query CustomViews {
+			customViews(first: 100) {
+				nodes {
+					id
+					name
+					description
+					icon
+					color
+					shared
+					modelName
+					team {
+						id
+						name
+						key
+					}
+				}
+			}
+			favorites(first: 100) {
+				nodes {
+					customView {
+						id
+					}
+				}
+			}
+		}
+	`
+
+	var result struct {
+		CustomViews struct {
+			Nodes []struct {
+				CustomView
+				ModelName string `json:"modelName"`
+			} `json:"nodes"`
+		} `json:"customViews"`
+		Favorites struct {
+			Nodes []struct {
+				CustomView *struct {
+					ID string `json:"id"`
+				} `json:"customView"`
+			} `json:"nodes"`
+		} `json:"favorites"`
+	}
+
+	if err := c.execute(ctx, query, nil, &result); err != nil {
+		return nil, err
+	}
+
+	favorites := make(map[string]bool)
+	for _, f := range result.Favorites.Nodes {
+		if f.CustomView != nil {
+			favorites[f.CustomView.ID] = true
+		}
+	}
+
+	var views []CustomView
+	for _, node := range result.CustomViews.Nodes {
+		// Project views list projects rather than issues
+		if node.ModelName != "" && node.ModelName != "Issue" {
+			continue
+		}
+		view := node.CustomView
+		view.Favorite = favorites[view.ID]
+		views = append(views, view)
+	}
+
+	sort.SliceStable(views, func(i, j int) bool {
+		if views[i].Favorite != views[j].Favorite {
+			return views[i].Favorite
+		}
+		return strings.ToLower(views[i].Name) < strings.ToLower(views[j].Name)
+	})
+	return views, nil
+}
+
+// GetCustomViewIssues returns the issues of a custom view, through the
+// view's own filter. filter narrows them down further.
+func (c *Client) GetCustomViewIssues(ctx context.Context, viewID string, filter IssueFilter) (IssueConnection, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	query := fmt.Sprintf(`
+		query CustomViewIssues($id: String!, $limit: Int!, $after: String, $filter: IssueFilter) {
+			customView(id: $id) {
+				issues(first: $limit, after: $after, filter: $filter, orderBy: updatedAt) {
+					nodes {
+						%s
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}
+	`, issueFields)
+
+	variables := map[string]interface{}{
+		"id":     viewID,
+		"limit":  filter.Limit,
+		"filter": buildIssueFilter(filter),
+	}
+	if filter.After != "" {
+		variables["after"] = filter.After
+	}
+
+	var result struct {
+		CustomView struct {
+			Issues rawIssueConnection `json:"issues"`
+		} `json:"customView"`
+	}
+
+	if err := c.execute(ctx, query, variables, &result); err != nil {
+		return IssueConnection{}, err
+	}
+
+	return IssueConnection{
+		Nodes:    convertIssues(result.CustomView.Issues.Nodes),
+		PageInfo: result.CustomView.Issues.PageInfo,
+	}, nil
+}
+
 // GetProjectIssues returns issues for a specific project with pagination support.
 // By default excludes completed/canceled issues unless includeCompleted is true.
 func (c *Client) GetProjectIssues(ctx context.Context, projectID string, limit int, includeCompleted bool, after string) (IssueConnection, error) {
@@ -387,6 +800,7 @@ func (c *Client) GetProjectIssues(ctx context.Context, projectID string, limit i
 					description
 					priority
 					estimate
+					sortOrder
 					createdAt
 					updatedAt
 					startedAt
@@ -424,6 +838,13 @@ func (c *Client) GetProjectIssues(ctx context.Context, projectID string, limit i
 						icon
 						color
 					}
+					cycle {
+						id
+						number
+						name
+						startsAt
+						endsAt
+					}
 					labels {
 						nodes {
 							id
@@ -431,6 +852,21 @@ func (c *Client) GetProjectIssues(ctx context.Context, projectID string, limit i
 							color
 						}
 					}
+					inverseRelations(first: 10) {
+						nodes {
+							id
+							type
+							issue {
+								id
+								identifier
+								title
+								state {
+									name
+									type
+								}
+							}
+						}
+					}
 				}
 				pageInfo {
 					hasNextPage
@@ -496,21 +932,192 @@ func buildIssueFilter(filter IssueFilter) map[string]interface{} {
 		}
 	}
 
-	if filter.AssigneeID != "" {
+	if filter.NoAssignee {
+		f["assignee"] = map[string]interface{}{"null": true}
+	} else if filter.AssigneeID != "" {
 		f["assignee"] = map[string]interface{}{
 			"id": map[string]interface{}{"eq": filter.AssigneeID},
 		}
 	}
 
-	if filter.StateType != "" {
-		f["state"] = map[string]interface{}{
-			"type": map[string]interface{}{"eq": filter.StateType},
+	if filter.CycleID != "" {
+		f["cycle"] = map[string]interface{}{
+			"id": map[string]interface{}{"eq": filter.CycleID},
+		}
+	}
+
+	if filter.StateType != "" || len(filter.States) > 0 {
+		state := make(map[string]interface{})
+		if filter.StateType != "" {
+			state["type"] = map[string]interface{}{"eq": filter.StateType}
+		}
+		if len(filter.States) > 0 {
+			state["id"] = map[string]interface{}{"in": filter.States}
+		}
+		f["state"] = state
+	}
+
+	if len(filter.Labels) > 0 {
+		f["labels"] = map[string]interface{}{
+			"some": map[string]interface{}{
+				"id": map[string]interface{}{"in": filter.Labels},
+			},
 		}
 	}
 
+	if len(filter.Priorities) > 0 {
+		f["priority"] = map[string]interface{}{"in": filter.Priorities}
+	}
+
+	// Due dates have no time of day; created and updated are timestamps
+	if !filter.Due.IsZero() {
+		f["dueDate"] = dateComparator(filter.Due, false)
+	}
+	if !filter.Created.IsZero() {
+		f["createdAt"] = dateComparator(filter.Created, true)
+	}
+	if !filter.Updated.IsZero() {
+		f["updatedAt"] = dateComparator(filter.Updated, true)
+	}
+
+	if filter.Query != "" {
+		f["title"] = map[string]interface{}{"containsIgnoreCase": filter.Query}
+	}
+
+	addNameFilters(f, filter)
+
 	return f
 }
 
+// addNameFilters adds the name-based filters, combining them with any
+// ID-based filter on the same field
+func addNameFilters(f map[string]interface{}, filter IssueFilter) {
+	if filter.TeamKey != "" {
+		addCondition(f, "team", anyOf(
+			nameIs("key", filter.TeamKey),
+			nameIs("name", filter.TeamKey),
+		))
+	}
+
+	if strings.EqualFold(filter.AssigneeName, "me") {
+		addCondition(f, "assignee", map[string]interface{}{
+			"isMe": map[string]interface{}{"eq": true},
+		})
+	} else if filter.AssigneeName != "" {
+		addCondition(f, "assignee", anyOf(
+			nameIs("name", filter.AssigneeName),
+			nameIs("displayName", filter.AssigneeName),
+			nameIs("email", filter.AssigneeName),
+		))
+	}
+
+	if len(filter.StateNames) > 0 {
+		var conds []map[string]interface{}
+		for _, name := range filter.StateNames {
+			conds = append(conds, nameIs("name", name))
+			if isStateType(name) {
+				conds = append(conds, map[string]interface{}{
+					"type": map[string]interface{}{"eq": strings.ToLower(name)},
+				})
+			}
+		}
+		addCondition(f, "state", anyOf(conds...))
+	}
+
+	if len(filter.LabelNames) > 0 {
+		var conds []map[string]interface{}
+		for _, name := range filter.LabelNames {
+			conds = append(conds, nameIs("name", name))
+		}
+		addCondition(f, "labels", map[string]interface{}{"some": anyOf(conds...)})
+	}
+
+	if filter.ProjectName != "" {
+		addCondition(f, "project", nameIs("name", filter.ProjectName))
+	}
+
+	if filter.CycleName != "" {
+		addCondition(f, "cycle", cycleCondition(filter.CycleName))
+	}
+}
+
+// cycleCondition matches a cycle by number or name, or the current, next or
+// previous cycle
+func cycleCondition(name string) map[string]interface{} {
+	flag := func(field string) map[string]interface{} {
+		return map[string]interface{}{field: map[string]interface{}{"eq": true}}
+	}
+	switch strings.ToLower(name) {
+	case "current", "active":
+		return flag("isActive")
+	case "next":
+		return flag("isNext")
+	case "previous":
+		return flag("isPrevious")
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		return map[string]interface{}{"number": map[string]interface{}{"eq": n}}
+	}
+	return nameIs("name", name)
+}
+
+// isStateType reports whether name is a workflow state type
+func isStateType(name string) bool {
+	switch strings.ToLower(name) {
+	case "triage", "backlog", "unstarted", "started", "completed", "canceled":
+		return true
+	}
+	return false
+}
+
+// nameIs matches a string field ignoring case
+func nameIs(field, value string) map[string]interface{} {
+	return map[string]interface{}{
+		field: map[string]interface{}{"eqIgnoreCase": value},
+	}
+}
+
+// anyOf matches any of conds
+func anyOf(conds ...map[string]interface{}) map[string]interface{} {
+	if len(conds) == 1 {
+		return conds[0]
+	}
+	return map[string]interface{}{"or": conds}
+}
+
+// addCondition sets the filter on field, or if field is already filtered,
+// adds cond alongside it so both must match
+func addCondition(f map[string]interface{}, field string, cond map[string]interface{}) {
+	if _, ok := f[field]; !ok {
+		f[field] = cond
+		return
+	}
+	and, _ := f["and"].([]map[string]interface{})
+	f["and"] = append(and, map[string]interface{}{field: cond})
+}
+
+// dateComparator builds a comparator for a range of days. For timestamps
+// the range runs from the start of From to the end of To.
+func dateComparator(r DateRange, timestamps bool) map[string]interface{} {
+	layout := "2006-01-02"
+	if timestamps {
+		layout = time.RFC3339
+	}
+
+	c := make(map[string]interface{})
+	if !r.From.IsZero() {
+		c["gte"] = r.From.Format(layout)
+	}
+	if !r.To.IsZero() {
+		to := r.To
+		if timestamps {
+			to = to.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		c["lte"] = to.Format(layout)
+	}
+	return c
+}
+
 // issueFields is the common GraphQL fragment for issue fields
 const issueFields = `
 	id
@@ -519,6 +1126,7 @@ const issueFields = `
 	description
 	priority
 	estimate
+	sortOrder
 	createdAt
 	updatedAt
 	startedAt
@@ -556,6 +1164,13 @@ const issueFields = `
 		icon
 		color
 	}
+	cycle {
+		id
+		number
+		name
+		startsAt
+		endsAt
+	}
 	labels {
 		nodes {
 			id
@@ -563,6 +1178,21 @@ const issueFields = `
 			color
 		}
 	}
+	inverseRelations(first: 10) {
+		nodes {
+			id
+			type
+			issue {
+				id
+				identifier
+				title
+				state {
+					name
+					type
+				}
+			}
+		}
+	}
 `
 
 // GetMyIssuesWithPagination returns issues assigned to the current user with pagination support
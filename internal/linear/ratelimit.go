@@ -0,0 +1,283 @@
+package linear
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// maxRetries is how many times a transient failure is retried
+	maxRetries = 3
+	// baseBackoff is the first retry delay; it doubles on every attempt
+	baseBackoff = 500 * time.Millisecond
+	// maxBackoff caps a single retry delay
+	maxBackoff = 8 * time.Second
+	// maxThrottle caps how long a request is delayed to stay under the limit
+	maxThrottle = 5 * time.Second
+	// throttleRatio is the fraction of the budget left at which requests
+	// start being spread out over the remaining window
+	throttleRatio = 0.1
+)
+
+// RateLimitError is returned when Linear rejects a request for exceeding the
+// rate limit and it could not be retried in time
+type RateLimitError struct {
+	ResetAt time.Time // When the limit resets; zero if unknown
+}
+
+func (e *RateLimitError) Error() string {
+	if wait := e.RetryAfter(); wait > 0 {
+		return fmt.Sprintf("rate limited by Linear, resets in %s", wait.Round(time.Second))
+	}
+	return "rate limited by Linear"
+}
+
+// RetryAfter returns how long until the limit resets
+func (e *RateLimitError) RetryAfter() time.Duration {
+	if e.ResetAt.IsZero() {
+		return 0
+	}
+	return time.Until(e.ResetAt)
+}
+
+// RateLimitStatus is the request and complexity budget last reported by Linear
+type RateLimitStatus struct {
+	RequestsLimit       int
+	RequestsRemaining   int
+	RequestsReset       time.Time
+	ComplexityLimit     int
+	ComplexityRemaining int
+	ComplexityReset     time.Time
+}
+
+// rateLimiter tracks Linear's rate-limit headers across requests
+type rateLimiter struct {
+	mu     sync.Mutex
+	status RateLimitStatus
+	known  bool
+}
+
+// update records the budget reported in a response's headers
+func (r *rateLimiter) update(h http.Header) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if v, ok := headerInt(h, "X-RateLimit-Requests-Limit"); ok {
+		r.status.RequestsLimit = v
+		r.known = true
+	}
+	if v, ok := headerInt(h, "X-RateLimit-Requests-Remaining"); ok {
+		r.status.RequestsRemaining = v
+	}
+	if t, ok := headerTime(h, "X-RateLimit-Requests-Reset"); ok {
+		r.status.RequestsReset = t
+	}
+	if v, ok := headerInt(h, "X-RateLimit-Complexity-Limit"); ok {
+		r.status.ComplexityLimit = v
+		r.known = true
+	}
+	if v, ok := headerInt(h, "X-RateLimit-Complexity-Remaining"); ok {
+		r.status.ComplexityRemaining = v
+	}
+	if t, ok := headerTime(h, "X-RateLimit-Complexity-Reset"); ok {
+		r.status.ComplexityReset = t
+	}
+}
+
+// delay returns how long to wait before the next request so the remaining
+// budget lasts until it resets. It returns a RateLimitError when the budget
+// is exhausted and won't reset within maxThrottle.
+func (r *rateLimiter) delay() (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.known {
+		return 0, nil
+	}
+
+	now := time.Now()
+	var wait time.Duration
+	budgets := []struct {
+		limit, remaining int
+		reset            time.Time
+	}{
+		{r.status.RequestsLimit, r.status.RequestsRemaining, r.status.RequestsReset},
+		{r.status.ComplexityLimit, r.status.ComplexityRemaining, r.status.ComplexityReset},
+	}
+	for _, b := range budgets {
+		if b.limit <= 0 || !b.reset.After(now) {
+			continue
+		}
+		untilReset := b.reset.Sub(now)
+		if b.remaining <= 0 {
+			if untilReset > maxThrottle {
+				return 0, &RateLimitError{ResetAt: b.reset}
+			}
+			wait = max(wait, untilReset)
+			continue
+		}
+		if float64(b.remaining) < float64(b.limit)*throttleRatio {
+			wait = max(wait, untilReset/time.Duration(b.remaining+1))
+		}
+	}
+	return min(wait, maxThrottle), nil
+}
+
+// resetAt returns when an exhausted budget resets, falling back to the
+// server's Retry-After hint when the headers don't say
+func (r *rateLimiter) resetAt(fallback time.Duration) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var reset time.Time
+	if r.status.RequestsLimit > 0 && r.status.RequestsRemaining <= 0 {
+		reset = r.status.RequestsReset
+	}
+	if r.status.ComplexityLimit > 0 && r.status.ComplexityRemaining <= 0 && r.status.ComplexityReset.After(reset) {
+		reset = r.status.ComplexityReset
+	}
+	if reset.After(time.Now()) {
+		return reset
+	}
+	if fallback > 0 {
+		return time.Now().Add(fallback)
+	}
+	return time.Time{}
+}
+
+// RateLimit returns the rate-limit budget last reported by Linear
+func (c *Client) RateLimit() RateLimitStatus {
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+	return c.limiter.status
+}
+
+// throttle waits as long as the rate limiter asks before sending a request
+func (c *Client) throttle(ctx context.Context) error {
+	wait, err := c.limiter.delay()
+	if err != nil || wait <= 0 {
+		return err
+	}
+	return sleep(ctx, wait)
+}
+
+// backoff returns the delay before retry attempt n (0-based), using
+// exponential backoff with full jitter
+func backoff(attempt int) time.Duration {
+	d := baseBackoff << attempt
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
+
+// retryAfter parses a Retry-After header given in seconds
+func retryAfter(h http.Header) time.Duration {
+	if v, ok := headerInt(h, "Retry-After"); ok && v > 0 {
+		return time.Duration(v) * time.Second
+	}
+	return 0
+}
+
+// isTransient reports whether a transport error is worth retrying. Requests
+// that may have reached the server (timeouts, resets) are only retried when
+// the request is safe to repeat.
+func isTransient(err error, idempotent bool) bool {
+	if IsUnsent(err) {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTransientStatus reports whether an HTTP status is worth retrying
+func isTransientStatus(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// The request never reached Linear's API servers
+		return true
+	}
+	return idempotent && status >= 500
+}
+
+// retryDelay reports whether a failed request should be retried and how long
+// to wait first
+func retryDelay(err error, attempt int, idempotent bool) (time.Duration, bool) {
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) {
+		// Rate-limited requests were rejected before running, so they are
+		// safe to repeat; only wait if the limit resets soon
+		wait := rateErr.RetryAfter()
+		if wait > maxThrottle {
+			return 0, false
+		}
+		return max(wait, backoff(attempt)), true
+	}
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		if !isTransientStatus(statusErr.Status, idempotent) {
+			return 0, false
+		}
+		return max(statusErr.RetryAfter, backoff(attempt)), true
+	}
+
+	if isTransient(err, idempotent) {
+		return backoff(attempt), true
+	}
+	return 0, false
+}
+
+// isMutation reports whether a GraphQL document is a mutation
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func headerInt(h http.Header, key string) (int, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
+
+// headerTime parses a reset header, given by Linear as epoch milliseconds
+func headerTime(h http.Header, key string) (time.Time, bool) {
+	v := h.Get(key)
+	if v == "" {
+		return time.Time{}, false
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(ms), true
+}
@@ -0,0 +1,248 @@
+package linear
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	unsent := &url.Error{Op: "Post", URL: apiURL, Err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}}
+	reset := fmt.Errorf("failed to execute request: %w", syscall.ECONNRESET)
+
+	tests := []struct {
+		name       string
+		err        error
+		attempt    int
+		idempotent bool
+		ok         bool
+		min, max   time.Duration
+	}{
+		{
+			name: "rate limit resetting soon",
+			err:  &RateLimitError{ResetAt: time.Now().Add(2 * time.Second)},
+			ok:   true,
+			min:  time.Second,
+			max:  2 * time.Second,
+		},
+		{
+			name: "rate limit resetting later",
+			err:  &RateLimitError{ResetAt: time.Now().Add(time.Minute)},
+		},
+		{
+			name: "rate limit without reset time",
+			err:  &RateLimitError{},
+			ok:   true,
+			max:  baseBackoff,
+		},
+		{
+			name: "server error is retried for queries",
+			err:  &statusError{Status: http.StatusInternalServerError},
+			ok:   true, idempotent: true,
+			max: baseBackoff,
+		},
+		{
+			name: "server error is not retried for mutations",
+			err:  &statusError{Status: http.StatusInternalServerError},
+		},
+		{
+			name: "gateway error is retried for mutations",
+			err:  &statusError{Status: http.StatusBadGateway},
+			ok:   true,
+			max:  baseBackoff,
+		},
+		{
+			name: "retry-after is honored",
+			err:  &statusError{Status: http.StatusServiceUnavailable, RetryAfter: 3 * time.Second},
+			ok:   true,
+			min:  3 * time.Second,
+			max:  3 * time.Second,
+		},
+		{
+			name:       "bad request",
+			err:        &statusError{Status: http.StatusBadRequest},
+			idempotent: true,
+		},
+		{
+			name:       "GraphQL error",
+			err:        &APIError{Status: http.StatusBadRequest, Errors: []GraphQLError{{Code: ErrorValidation}}},
+			idempotent: true,
+		},
+		{
+			name: "unsent request is retried for mutations",
+			err:  unsent,
+			ok:   true,
+			max:  baseBackoff,
+		},
+		{
+			name: "reset connection is not retried for mutations",
+			err:  reset,
+		},
+		{
+			name:       "reset connection is retried for queries",
+			err:        reset,
+			idempotent: true,
+			ok:         true,
+			max:        baseBackoff,
+		},
+		{
+			name:       "backoff grows with attempts",
+			err:        reset,
+			attempt:    2,
+			idempotent: true,
+			ok:         true,
+			max:        4 * baseBackoff,
+		},
+		{
+			name:       "backoff is capped",
+			err:        reset,
+			attempt:    10,
+			idempotent: true,
+			ok:         true,
+			max:        maxBackoff,
+		},
+		{
+			name:       "other errors",
+			err:        errors.New("failed to unmarshal data"),
+			idempotent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := retryDelay(tt.err, tt.attempt, tt.idempotent)
+			if ok != tt.ok {
+				t.Fatalf("retryDelay() ok = %v, want %v", ok, tt.ok)
+			}
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("retryDelay() = %s, want between %s and %s", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestIsTransientStatus(t *testing.T) {
+	tests := []struct {
+		status     int
+		idempotent bool
+		want       bool
+	}{
+		{http.StatusBadGateway, false, true},
+		{http.StatusServiceUnavailable, false, true},
+		{http.StatusGatewayTimeout, false, true},
+		{http.StatusInternalServerError, false, false},
+		{http.StatusInternalServerError, true, true},
+		{http.StatusBadRequest, true, false},
+		{http.StatusNotFound, true, false},
+	}
+
+	for _, tt := range tests {
+		if got := isTransientStatus(tt.status, tt.idempotent); got != tt.want {
+			t.Errorf("isTransientStatus(%d, %v) = %v, want %v", tt.status, tt.idempotent, got, tt.want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"0", 0},
+		{"-1", 0},
+		{"soon", 0},
+		{"Wed, 21 Oct 2026 07:28:00 GMT", 0},
+	}
+
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set("Retry-After", tt.value)
+		}
+		if got := retryAfter(h); got != tt.want {
+			t.Errorf("retryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimiterDelay(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		status   RateLimitStatus
+		known    bool
+		min, max time.Duration
+		wantErr  bool
+	}{
+		{
+			name:   "no headers seen",
+			status: RateLimitStatus{RequestsLimit: 100, RequestsReset: now.Add(time.Hour)},
+		},
+		{
+			name:   "plenty left",
+			status: RateLimitStatus{RequestsLimit: 1000, RequestsRemaining: 900, RequestsReset: now.Add(time.Hour)},
+			known:  true,
+		},
+		{
+			name:   "low budget is spread over the window",
+			status: RateLimitStatus{RequestsLimit: 1000, RequestsRemaining: 9, RequestsReset: now.Add(10 * time.Second)},
+			known:  true,
+			min:    900 * time.Millisecond,
+			max:    time.Second,
+		},
+		{
+			name:   "low complexity budget",
+			status: RateLimitStatus{ComplexityLimit: 1000, ComplexityRemaining: 4, ComplexityReset: now.Add(5 * time.Second)},
+			known:  true,
+			min:    900 * time.Millisecond,
+			max:    time.Second,
+		},
+		{
+			name:   "spreading is capped",
+			status: RateLimitStatus{RequestsLimit: 1000, RequestsRemaining: 1, RequestsReset: now.Add(time.Minute)},
+			known:  true,
+			min:    maxThrottle,
+			max:    maxThrottle,
+		},
+		{
+			name:   "exhausted and resetting soon",
+			status: RateLimitStatus{RequestsLimit: 1000, RequestsRemaining: 0, RequestsReset: now.Add(2 * time.Second)},
+			known:  true,
+			min:    time.Second,
+			max:    2 * time.Second,
+		},
+		{
+			name:    "exhausted and resetting later",
+			status:  RateLimitStatus{RequestsLimit: 1000, RequestsRemaining: 0, RequestsReset: now.Add(time.Minute)},
+			known:   true,
+			wantErr: true,
+		},
+		{
+			name:   "reset already passed",
+			status: RateLimitStatus{RequestsLimit: 1000, RequestsRemaining: 0, RequestsReset: now.Add(-time.Second)},
+			known:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &rateLimiter{status: tt.status, known: tt.known}
+			wait, err := r.delay()
+			var rateErr *RateLimitError
+			if tt.wantErr != errors.As(err, &rateErr) {
+				t.Fatalf("delay() error = %v, want rate limit error %v", err, tt.wantErr)
+			}
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("delay() = %s, want between %s and %s", wait, tt.min, tt.max)
+			}
+		})
+	}
+}
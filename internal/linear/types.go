@@ -1,6 +1,10 @@
 package linear
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
 
 // Issue represents a Linear issue
 type Issue struct {
@@ -10,6 +14,7 @@ type Issue struct {
 	Description string     `json:"description"`
 	Priority    int        `json:"priority"`
 	Estimate    *int       `json:"estimate"`
+	SortOrder   float64    `json:"sortOrder"`
 	CreatedAt   time.Time  `json:"createdAt"`
 	UpdatedAt   time.Time  `json:"updatedAt"`
 	StartedAt   *time.Time `json:"startedAt"`
@@ -27,7 +32,106 @@ type Issue struct {
 	Project  *Project       `json:"project"`
 	Cycle    *Cycle         `json:"cycle"`
 	Parent   *Issue         `json:"parent"`
+	Children []Issue        `json:"children"`
 	Labels   []Label        `json:"labels"`
+
+	// Relations to other issues. InverseRelations are the relations other
+	// issues have to this one; list queries only fetch those.
+	Relations        []IssueRelation `json:"relations"`
+	InverseRelations []IssueRelation `json:"inverseRelations"`
+}
+
+// IsBlocked reports whether an unfinished issue blocks this one
+func (i Issue) IsBlocked() bool {
+	for _, rel := range i.InverseRelations {
+		if rel.Type == RelationTypeBlocks && rel.Issue != nil && !rel.Issue.IsFinished() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinished reports whether the issue is completed or canceled
+func (i Issue) IsFinished() bool {
+	return i.State != nil && (i.State.Type == "completed" || i.State.Type == "canceled")
+}
+
+// RelatedIssues returns the issue's relations from its own point of view,
+// in the order blocked by, blocks, duplicate of, duplicated by, related
+func (i Issue) RelatedIssues() []RelatedIssue {
+	var related []RelatedIssue
+	for _, rel := range i.Relations {
+		if rel.RelatedIssue == nil {
+			continue
+		}
+		kind := RelationRelated
+		switch rel.Type {
+		case RelationTypeBlocks:
+			kind = RelationBlocks
+		case RelationTypeDuplicate:
+			kind = RelationDuplicateOf
+		}
+		related = append(related, RelatedIssue{RelationID: rel.ID, Kind: kind, Issue: rel.RelatedIssue})
+	}
+	for _, rel := range i.InverseRelations {
+		if rel.Issue == nil {
+			continue
+		}
+		kind := RelationRelated
+		switch rel.Type {
+		case RelationTypeBlocks:
+			kind = RelationBlockedBy
+		case RelationTypeDuplicate:
+			kind = RelationDuplicatedBy
+		}
+		related = append(related, RelatedIssue{RelationID: rel.ID, Kind: kind, Issue: rel.Issue})
+	}
+
+	order := map[RelationKind]int{
+		RelationBlockedBy:    0,
+		RelationBlocks:       1,
+		RelationDuplicateOf:  2,
+		RelationDuplicatedBy: 3,
+		RelationRelated:      4,
+	}
+	sort.SliceStable(related, func(a, b int) bool {
+		return order[related[a].Kind] < order[related[b].Kind]
+	})
+	return related
+}
+
+// Issue relation types as stored by Linear: Issue blocks, duplicates or
+// relates to RelatedIssue
+const (
+	RelationTypeBlocks    = "blocks"
+	RelationTypeDuplicate = "duplicate"
+	RelationTypeRelated   = "related"
+)
+
+// IssueRelation represents a relation between two issues
+type IssueRelation struct {
+	ID           string `json:"id"`
+	Type         string `json:"type"` // blocks, duplicate, related, similar
+	Issue        *Issue `json:"issue"`
+	RelatedIssue *Issue `json:"relatedIssue"`
+}
+
+// RelationKind describes a relation from one issue's point of view
+type RelationKind string
+
+const (
+	RelationBlocks       RelationKind = "blocks"
+	RelationBlockedBy    RelationKind = "blocked_by"
+	RelationDuplicateOf  RelationKind = "duplicate_of"
+	RelationDuplicatedBy RelationKind = "duplicated_by"
+	RelationRelated      RelationKind = "related"
+)
+
+// RelatedIssue is another issue an issue is related to
+type RelatedIssue struct {
+	RelationID string
+	Kind       RelationKind
+	Issue      *Issue
 }
 
 // WorkflowState represents an issue state
@@ -71,6 +175,18 @@ type Project struct {
 	URL         string  `json:"url"`
 }
 
+// CustomView is a saved view from Linear's web app
+type CustomView struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Color       string `json:"color"`
+	Shared      bool   `json:"shared"`
+	Team        *Team  `json:"team"`     // Nil for workspace views
+	Favorite    bool   `json:"favorite"` // Favorited by the viewer
+}
+
 // Cycle represents a Linear cycle (sprint)
 type Cycle struct {
 	ID       string    `json:"id"`
@@ -101,13 +217,71 @@ type Comment struct {
 	User      *User     `json:"user"`
 }
 
+// Attachment is a link attached to an issue, such as a pull request, commit
+// or any other URL
+type Attachment struct {
+	ID         string                 `json:"id"`
+	Title      string                 `json:"title"`
+	Subtitle   string                 `json:"subtitle"`
+	URL        string                 `json:"url"`
+	SourceType string                 `json:"sourceType"` // e.g. github, gitlab, slack
+	Metadata   map[string]interface{} `json:"metadata"`
+	CreatedAt  time.Time              `json:"createdAt"`
+	UpdatedAt  time.Time              `json:"updatedAt"`
+}
+
+// AttachmentKind classifies an attachment
+type AttachmentKind string
+
+const (
+	AttachmentPullRequest AttachmentKind = "pull_request"
+	AttachmentCommit      AttachmentKind = "commit"
+	AttachmentLink        AttachmentKind = "link"
+)
+
+// Kind returns whether the attachment is a pull/merge request, a commit or
+// a plain link, based on its URL
+func (a Attachment) Kind() AttachmentKind {
+	switch {
+	case strings.Contains(a.URL, "/pull/") || strings.Contains(a.URL, "/merge_requests/"):
+		return AttachmentPullRequest
+	case strings.Contains(a.URL, "/commit/") || strings.Contains(a.URL, "/commits/"):
+		return AttachmentCommit
+	}
+	return AttachmentLink
+}
+
+// Status returns the pull request status reported by the integration
+// (e.g. "open", "draft", "merged", "closed"), or "" if there is none
+func (a Attachment) Status() string {
+	if draft, ok := a.Metadata["draft"].(bool); ok && draft {
+		if status, _ := a.Metadata["status"].(string); status == "" || strings.EqualFold(status, "open") {
+			return "draft"
+		}
+	}
+	for _, key := range []string{"status", "state"} {
+		if status, ok := a.Metadata[key].(string); ok && status != "" {
+			return strings.ToLower(status)
+		}
+	}
+	return ""
+}
+
 // Viewer represents the currently authenticated user
 type Viewer struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	DisplayName string `json:"displayName"`
-	Email       string `json:"email"`
-	Active      bool   `json:"active"`
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	DisplayName  string        `json:"displayName"`
+	Email        string        `json:"email"`
+	Active       bool          `json:"active"`
+	Organization *Organization `json:"organization"`
+}
+
+// Organization represents a Linear workspace
+type Organization struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	URLKey string `json:"urlKey"`
 }
 
 // IssueCreateInput represents input for creating an issue
@@ -139,6 +313,11 @@ type IssueUpdateInput struct {
 	LabelIDs    []string `json:"labelIds,omitempty"`
 	ParentID    *string  `json:"parentId,omitempty"`
 	DueDate     *string  `json:"dueDate,omitempty"`
+
+	// AddedLabelIDs and RemovedLabelIDs change labels without replacing
+	// the existing ones
+	AddedLabelIDs   []string `json:"addedLabelIds,omitempty"`
+	RemovedLabelIDs []string `json:"removedLabelIds,omitempty"`
 }
 
 // IssueFilter represents filters for querying issues
@@ -146,12 +325,41 @@ type IssueFilter struct {
 	TeamID     string
 	ProjectID  string
 	AssigneeID string
-	StateType  string // backlog, unstarted, started, completed, canceled
-	States     []string
-	Labels     []string
-	Query      string
-	Limit      int
-	After      string // Cursor for pagination (endCursor from previous page)
+	NoAssignee bool // Only unassigned issues; takes precedence over AssigneeID
+	CycleID    string
+	StateType  string   // backlog, unstarted, started, completed, canceled
+	States     []string // State IDs; matches any of them
+	Labels     []string // Label IDs; matches issues with any of them
+	Priorities []int    // 0 (none) to 4 (low); matches any of them
+	Due        DateRange
+	Created    DateRange
+	Updated    DateRange
+	Query      string // Text the title must contain
+
+	// Filters by name rather than ID, matched by Linear ignoring case.
+	// Views defined in config use these, so they work without looking up
+	// IDs first.
+	TeamKey      string   // Team key or name
+	AssigneeName string   // Name, display name or email; "me" for the viewer
+	StateNames   []string // State names or types; matches any of them
+	LabelNames   []string // Matches issues with any of them
+	ProjectName  string
+	CycleName    string // Cycle number or name, or "current", "next" or "previous"
+
+	Limit int
+	After string // Cursor for pagination (endCursor from previous page)
+}
+
+// DateRange is an inclusive range of days. A zero From or To leaves that
+// end of the range open.
+type DateRange struct {
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// IsZero reports whether the range is unbounded on both ends
+func (r DateRange) IsZero() bool {
+	return r.From.IsZero() && r.To.IsZero()
 }
 
 // Connection types for pagination
@@ -161,6 +369,11 @@ type IssueConnection struct {
 	TotalCount int      `json:"totalCount,omitempty"`
 }
 
+type CommentConnection struct {
+	Nodes    []Comment `json:"nodes"`
+	PageInfo PageInfo  `json:"pageInfo"`
+}
+
 type LabelConnection struct {
 	Nodes []Label `json:"nodes"`
 }
@@ -0,0 +1,211 @@
+package queue
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// Kind is the type of a journaled mutation
+type Kind string
+
+const (
+	KindCreate Kind = "create"
+	KindUpdate Kind = "update"
+)
+
+// Mutation is an issue change that could not be sent to Linear yet
+type Mutation struct {
+	ID         string                   `json:"id"`
+	Kind       Kind                     `json:"kind"`
+	IssueID    string                   `json:"issueId,omitempty"`
+	Identifier string                   `json:"identifier,omitempty"`
+	Create     *linear.IssueCreateInput `json:"create,omitempty"`
+	Update     *linear.IssueUpdateInput `json:"update,omitempty"`
+	QueuedAt   time.Time                `json:"queuedAt"`
+
+	// BaseUpdatedAt is the issue's updatedAt when the edit was made. If the
+	// server copy has moved past it by replay time, the edit is a conflict.
+	BaseUpdatedAt time.Time `json:"baseUpdatedAt,omitempty"`
+
+	// Conflict is set when replay found the issue changed on the server.
+	// Conflicting mutations are held until resolved with Force or Discard.
+	Conflict bool `json:"conflict,omitempty"`
+	// Force skips the conflict check on the next replay
+	Force bool `json:"force,omitempty"`
+}
+
+// Label returns a short human-readable name for the mutation's target
+func (m Mutation) Label() string {
+	if m.Identifier != "" {
+		return m.Identifier
+	}
+	if m.Create != nil {
+		return fmt.Sprintf("%q", m.Create.Title)
+	}
+	return m.IssueID
+}
+
+// Queue is an ordered, on-disk journal of pending mutations.
+// It is safe for concurrent use from tea.Cmd goroutines.
+type Queue struct {
+	mu    sync.Mutex
+	path  string
+	items []Mutation
+	seq   int
+}
+
+// Open opens the journal for apiKey under dir. Only a hash of the key is
+// written to disk, so journals of different workspaces never mix.
+func Open(dir, apiKey string) (*Queue, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(apiKey))
+	q := &Queue{path: filepath.Join(dir, "queue-"+hex.EncodeToString(sum[:8])+".json")}
+
+	b, err := os.ReadFile(q.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return q, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &q.items); err != nil {
+		return nil, fmt.Errorf("failed to read mutation queue: %w", err)
+	}
+	return q, nil
+}
+
+// Enqueue appends a mutation to the journal and persists it
+func (q *Queue) Enqueue(m Mutation) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	m.ID = fmt.Sprintf("%d-%d", time.Now().UnixNano(), q.seq)
+	if m.QueuedAt.IsZero() {
+		m.QueuedAt = time.Now()
+	}
+	q.items = append(q.items, m)
+	return q.save()
+}
+
+// Pending returns a copy of all journaled mutations in order
+func (q *Queue) Pending() []Mutation {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items := make([]Mutation, len(q.items))
+	copy(items, q.items)
+	return items
+}
+
+// Len returns the number of journaled mutations
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Issues returns the IDs of issues with pending updates, mapped to whether
+// any of them is in conflict
+func (q *Queue) Issues() map[string]bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	issues := make(map[string]bool)
+	for _, m := range q.items {
+		if m.IssueID != "" {
+			issues[m.IssueID] = issues[m.IssueID] || m.Conflict
+		}
+	}
+	return issues
+}
+
+// Remove drops a mutation once it has been applied
+func (q *Queue) Remove(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.items {
+		if q.items[i].ID == id {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return q.save()
+		}
+	}
+	return nil
+}
+
+// MarkConflict flags a mutation as conflicting with server changes
+func (q *Queue) MarkConflict(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.items {
+		if q.items[i].ID == id {
+			q.items[i].Conflict = true
+			return q.save()
+		}
+	}
+	return nil
+}
+
+// Force clears conflicts on an issue's mutations so the next replay applies
+// them over the server's changes
+func (q *Queue) Force(issueID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.items {
+		if q.items[i].IssueID == issueID {
+			q.items[i].Conflict = false
+			q.items[i].Force = true
+		}
+	}
+	return q.save()
+}
+
+// Discard drops every pending mutation for an issue
+func (q *Queue) Discard(issueID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	kept := q.items[:0]
+	for _, m := range q.items {
+		if m.IssueID != issueID {
+			kept = append(kept, m)
+		}
+	}
+	q.items = kept
+	return q.save()
+}
+
+// save persists the journal. Callers must hold q.mu.
+func (q *Queue) save() error {
+	if len(q.items) == 0 {
+		if err := os.Remove(q.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	b, err := json.MarshalIndent(q.items, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := q.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.path)
+}
@@ -0,0 +1,180 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// Client is the part of the Linear client that replay needs
+type Client interface {
+	CreateIssue(ctx context.Context, input linear.IssueCreateInput) (*linear.Issue, error)
+	GetIssue(ctx context.Context, idOrIdentifier string) (*linear.Issue, error)
+	UpdateIssue(ctx context.Context, issueID string, input linear.IssueUpdateInput) (*linear.Issue, error)
+}
+
+// ReplayResult summarizes a replay of the journal
+type ReplayResult struct {
+	Updated   []linear.Issue
+	Created   []linear.Issue
+	Conflicts []Mutation
+	Dropped   []string // Mutations dropped because the API rejected them
+	Offline   bool     // Replay stopped because the API is still unreachable
+
+	// Err stopped the replay with the rest still queued: a rate limit,
+	// an outage or an authentication failure
+	Err error
+}
+
+// Applied returns the number of mutations that reached the server
+func (r ReplayResult) Applied() int {
+	return len(r.Updated) + len(r.Created)
+}
+
+// rejected reports whether the API refused a mutation outright, so sending it
+// again can't succeed
+func rejected(err error) bool {
+	code := linear.ErrorCodeOf(err)
+	return code == linear.ErrorValidation || code == linear.ErrorNotFound
+}
+
+// Replay sends journaled mutations to Linear in the order they were made.
+// It stops at the first network error, rate limit or outage, leaving the
+// rest queued; only mutations the API rejects as invalid are dropped. An
+// update is held as a conflict when the issue changed on the server after
+// the edit was made; later updates to the same issue wait behind it to
+// preserve order.
+func (q *Queue) Replay(ctx context.Context, client Client) ReplayResult {
+	var result ReplayResult
+	blocked := make(map[string]bool)
+
+	for _, pending := range q.Pending() {
+		// Replaying an earlier edit may have rebased this one
+		m, ok := q.get(pending.ID)
+		if !ok {
+			continue
+		}
+		if m.IssueID != "" && (m.Conflict || blocked[m.IssueID]) {
+			blocked[m.IssueID] = true
+			continue
+		}
+
+		switch m.Kind {
+		case KindCreate:
+			if m.Create == nil {
+				_ = q.Remove(m.ID)
+				continue
+			}
+			issue, err := client.CreateIssue(ctx, *m.Create)
+			switch {
+			case err == nil:
+				_ = q.Remove(m.ID)
+				if issue != nil {
+					result.Created = append(result.Created, *issue)
+				}
+			case linear.IsUnsent(err):
+				result.Offline = true
+				return result
+			case linear.IsNetworkError(err):
+				// The issue may exist already; sending it again could
+				// create a duplicate
+				_ = q.Remove(m.ID)
+				result.Dropped = append(result.Dropped,
+					fmt.Sprintf("create %s: no answer from Linear, check whether it was created (%v)", m.Label(), err))
+			case rejected(err):
+				_ = q.Remove(m.ID)
+				result.Dropped = append(result.Dropped, fmt.Sprintf("create %s: %v", m.Label(), err))
+			default:
+				result.Err = err
+				return result
+			}
+
+		case KindUpdate:
+			if m.Update == nil {
+				_ = q.Remove(m.ID)
+				continue
+			}
+			if !m.Force && !m.BaseUpdatedAt.IsZero() {
+				current, err := client.GetIssue(ctx, m.IssueID)
+				if err != nil {
+					if q.updateFailed(&result, m, err) {
+						return result
+					}
+					blocked[m.IssueID] = true
+					continue
+				}
+				if current.UpdatedAt.After(m.BaseUpdatedAt) {
+					_ = q.MarkConflict(m.ID)
+					m.Conflict = true
+					result.Conflicts = append(result.Conflicts, m)
+					blocked[m.IssueID] = true
+					continue
+				}
+			}
+
+			issue, err := client.UpdateIssue(ctx, m.IssueID, *m.Update)
+			if err != nil {
+				if q.updateFailed(&result, m, err) {
+					return result
+				}
+				blocked[m.IssueID] = true
+				continue
+			}
+			_ = q.Remove(m.ID)
+			if issue != nil {
+				result.Updated = append(result.Updated, *issue)
+				// Our own write moved updatedAt; later edits to the same
+				// issue build on it and must not be flagged as conflicts
+				_ = q.rebase(m.IssueID, issue.UpdatedAt)
+			}
+		}
+	}
+
+	return result
+}
+
+// updateFailed handles an update that failed to send and reports whether
+// replay has to stop. Updates are safe to send twice, so network errors, rate
+// limits and outages leave it queued; an update the API rejects is dropped.
+func (q *Queue) updateFailed(result *ReplayResult, m Mutation, err error) bool {
+	switch {
+	case linear.IsNetworkError(err):
+		result.Offline = true
+		return true
+	case rejected(err):
+		_ = q.Remove(m.ID)
+		result.Dropped = append(result.Dropped, fmt.Sprintf("update %s: %v", m.Label(), err))
+		return false
+	default:
+		result.Err = err
+		return true
+	}
+}
+
+// get returns the journaled mutation with the given ID
+func (q *Queue) get(id string) (Mutation, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, m := range q.items {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return Mutation{}, false
+}
+
+// rebase moves the base updatedAt of an issue's remaining mutations forward
+func (q *Queue) rebase(issueID string, updatedAt time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := range q.items {
+		if q.items[i].IssueID == issueID {
+			q.items[i].BaseUpdatedAt = updatedAt
+		}
+	}
+	return q.save()
+}
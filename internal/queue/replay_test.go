@@ -0,0 +1,240 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+var base = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// fakeClient stands in for Linear. Each successful update moves the issue's
+// updatedAt forward, as the API does.
+type fakeClient struct {
+	updatedAt time.Time
+	createErr error
+	getErr    error
+	updateErr error
+
+	creates int
+	updates int
+}
+
+func (c *fakeClient) CreateIssue(ctx context.Context, input linear.IssueCreateInput) (*linear.Issue, error) {
+	c.creates++
+	if c.createErr != nil {
+		return nil, c.createErr
+	}
+	return &linear.Issue{ID: "new", Title: input.Title}, nil
+}
+
+func (c *fakeClient) GetIssue(ctx context.Context, id string) (*linear.Issue, error) {
+	if c.getErr != nil {
+		return nil, c.getErr
+	}
+	return &linear.Issue{ID: id, UpdatedAt: c.updatedAt}, nil
+}
+
+func (c *fakeClient) UpdateIssue(ctx context.Context, id string, input linear.IssueUpdateInput) (*linear.Issue, error) {
+	c.updates++
+	if c.updateErr != nil {
+		return nil, c.updateErr
+	}
+	c.updatedAt = c.updatedAt.Add(time.Minute)
+	return &linear.Issue{ID: id, UpdatedAt: c.updatedAt}, nil
+}
+
+func update(issueID string) Mutation {
+	title := "New title"
+	return Mutation{
+		Kind:          KindUpdate,
+		IssueID:       issueID,
+		Update:        &linear.IssueUpdateInput{Title: &title},
+		BaseUpdatedAt: base,
+	}
+}
+
+func create() Mutation {
+	return Mutation{Kind: KindCreate, Create: &linear.IssueCreateInput{Title: "Offline issue", TeamID: "team"}}
+}
+
+func apiError(code linear.ErrorCode) error {
+	return &linear.APIError{Status: 400, Errors: []linear.GraphQLError{{Message: string(code), Code: code}}}
+}
+
+var (
+	refused = &url.Error{Op: "Post", URL: "https://api.linear.app/graphql",
+		Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	timedOut = &url.Error{Op: "Post", URL: "https://api.linear.app/graphql", Err: context.DeadlineExceeded}
+)
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutations []Mutation
+		client    fakeClient
+
+		wantUpdated   int
+		wantCreated   int
+		wantConflicts int
+		wantDropped   int
+		wantQueued    int
+		wantOffline   bool
+		wantErr       bool
+		wantCreates   int
+	}{
+		{
+			name:        "edits to the same issue build on each other",
+			mutations:   []Mutation{update("a"), update("a"), update("a")},
+			client:      fakeClient{updatedAt: base},
+			wantUpdated: 3,
+		},
+		{
+			name:          "server change holds the issue's edits",
+			mutations:     []Mutation{update("a"), update("a")},
+			client:        fakeClient{updatedAt: base.Add(time.Hour)},
+			wantConflicts: 1,
+			wantQueued:    2,
+		},
+		{
+			name:        "create is sent",
+			mutations:   []Mutation{create()},
+			wantCreated: 1,
+			wantCreates: 1,
+		},
+		{
+			name:        "unreachable API keeps everything queued",
+			mutations:   []Mutation{create(), update("a")},
+			client:      fakeClient{createErr: refused},
+			wantQueued:  2,
+			wantOffline: true,
+			wantCreates: 1,
+		},
+		{
+			name:        "create that may have reached Linear is not resent",
+			mutations:   []Mutation{create(), update("a")},
+			client:      fakeClient{updatedAt: base, createErr: timedOut},
+			wantDropped: 1,
+			wantUpdated: 1,
+			wantCreates: 1,
+		},
+		{
+			name:        "update timeout stays queued",
+			mutations:   []Mutation{update("a")},
+			client:      fakeClient{updatedAt: base, updateErr: timedOut},
+			wantQueued:  1,
+			wantOffline: true,
+		},
+		{
+			name:       "rate limit stays queued",
+			mutations:  []Mutation{update("a"), update("b")},
+			client:     fakeClient{updatedAt: base, updateErr: &linear.RateLimitError{}},
+			wantQueued: 2,
+			wantErr:    true,
+		},
+		{
+			name:        "outage stays queued",
+			mutations:   []Mutation{create()},
+			client:      fakeClient{createErr: errors.New("API request failed with status 500")},
+			wantQueued:  1,
+			wantErr:     true,
+			wantCreates: 1,
+		},
+		{
+			name:       "authentication failure stays queued",
+			mutations:  []Mutation{update("a")},
+			client:     fakeClient{getErr: apiError(linear.ErrorAuthentication)},
+			wantQueued: 1,
+			wantErr:    true,
+		},
+		{
+			name:        "validation error drops the edit",
+			mutations:   []Mutation{update("a"), update("b")},
+			client:      fakeClient{updatedAt: base, updateErr: apiError(linear.ErrorValidation)},
+			wantDropped: 2,
+		},
+		{
+			name:        "deleted issue drops its edit",
+			mutations:   []Mutation{update("a")},
+			client:      fakeClient{getErr: apiError(linear.ErrorNotFound)},
+			wantDropped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Open(t.TempDir(), "key")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range tt.mutations {
+				if err := q.Enqueue(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			client := tt.client
+			result := q.Replay(context.Background(), &client)
+
+			if got := len(result.Updated); got != tt.wantUpdated {
+				t.Errorf("updated %d, want %d", got, tt.wantUpdated)
+			}
+			if got := len(result.Created); got != tt.wantCreated {
+				t.Errorf("created %d, want %d", got, tt.wantCreated)
+			}
+			if got := len(result.Conflicts); got != tt.wantConflicts {
+				t.Errorf("conflicts %d, want %d", got, tt.wantConflicts)
+			}
+			if got := len(result.Dropped); got != tt.wantDropped {
+				t.Errorf("dropped %v, want %d", result.Dropped, tt.wantDropped)
+			}
+			if got := q.Len(); got != tt.wantQueued {
+				t.Errorf("%d still queued, want %d", got, tt.wantQueued)
+			}
+			if result.Offline != tt.wantOffline {
+				t.Errorf("offline = %v, want %v", result.Offline, tt.wantOffline)
+			}
+			if (result.Err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error: %v", result.Err, tt.wantErr)
+			}
+			if client.creates != tt.wantCreates {
+				t.Errorf("sent %d creates, want %d", client.creates, tt.wantCreates)
+			}
+		})
+	}
+}
+
+func TestReplayResumes(t *testing.T) {
+	dir := t.TempDir()
+	q, err := Open(dir, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []Mutation{update("a"), update("a")} {
+		if err := q.Enqueue(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	offline := &fakeClient{updatedAt: base, updateErr: refused}
+	if result := q.Replay(context.Background(), offline); !result.Offline {
+		t.Fatalf("replay while offline: %+v", result)
+	}
+
+	// The journal survives a restart
+	q, err = Open(dir, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	online := &fakeClient{updatedAt: base}
+	result := q.Replay(context.Background(), online)
+	if len(result.Updated) != 2 || len(result.Conflicts) != 0 || q.Len() != 0 {
+		t.Errorf("replay once online: %+v, %d still queued", result, q.Len())
+	}
+}
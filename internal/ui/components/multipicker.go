@@ -0,0 +1,267 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// MultiPickerModel is a modal picker for toggling any number of items, with
+// search/filter support and optionally creating new items from the query.
+// The owner closes it on esc and reads SelectedIDs once Confirmed is true.
+type MultiPickerModel struct {
+	title         string
+	items         []PickerItem
+	filteredItems []PickerItem
+	checked       map[string]bool
+	cursor        int
+	width         int
+	height        int
+	searchInput   textinput.Model
+	allowCreate   bool
+	pendingCreate string
+	confirmed     bool
+}
+
+// NewMultiPickerModel creates a multi-select picker with checkedIDs selected
+func NewMultiPickerModel(title string, items []PickerItem, checkedIDs []string, width, height int) *MultiPickerModel {
+	ti := textinput.New()
+	ti.Placeholder = "Type to search..."
+	ti.Focus()
+	ti.CharLimit = 50
+	ti.Width = 34
+
+	checked := make(map[string]bool, len(checkedIDs))
+	for _, id := range checkedIDs {
+		checked[id] = true
+	}
+
+	return &MultiPickerModel{
+		title:         title,
+		items:         items,
+		filteredItems: items,
+		checked:       checked,
+		width:         width,
+		height:        height,
+		searchInput:   ti,
+	}
+}
+
+// EnableCreate offers to create a new item when the query matches none
+func (m *MultiPickerModel) EnableCreate() *MultiPickerModel {
+	m.allowCreate = true
+	return m
+}
+
+// query returns the trimmed search query
+func (m *MultiPickerModel) query() string {
+	return strings.TrimSpace(m.searchInput.Value())
+}
+
+// canCreate reports whether the create row is shown
+func (m *MultiPickerModel) canCreate() bool {
+	query := m.query()
+	if !m.allowCreate || query == "" || m.pendingCreate != "" {
+		return false
+	}
+	for _, item := range m.items {
+		if strings.EqualFold(item.Label, query) {
+			return false
+		}
+	}
+	return true
+}
+
+// rowCount returns the number of rows, including the create row
+func (m *MultiPickerModel) rowCount() int {
+	if m.canCreate() {
+		return len(m.filteredItems) + 1
+	}
+	return len(m.filteredItems)
+}
+
+// filterItems filters items based on search query
+func (m *MultiPickerModel) filterItems() {
+	query := strings.ToLower(m.query())
+	if query == "" {
+		m.filteredItems = m.items
+	} else {
+		var filtered []PickerItem
+		for _, item := range m.items {
+			if strings.Contains(strings.ToLower(item.Label), query) ||
+				strings.Contains(strings.ToLower(item.Desc), query) {
+				filtered = append(filtered, item)
+			}
+		}
+		m.filteredItems = filtered
+	}
+
+	if m.cursor >= m.rowCount() {
+		m.cursor = 0
+	}
+}
+
+// toggle flips the item under the cursor
+func (m *MultiPickerModel) toggle() {
+	if m.cursor < len(m.filteredItems) {
+		id := m.filteredItems[m.cursor].ID
+		m.checked[id] = !m.checked[id]
+	}
+}
+
+// Update handles messages
+func (m *MultiPickerModel) Update(msg tea.Msg) (*MultiPickerModel, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "up", "ctrl+k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "ctrl+j":
+		if m.cursor < m.rowCount()-1 {
+			m.cursor++
+		}
+	case "tab":
+		m.toggle()
+	case " ":
+		// Space toggles until a search is typed, then it's part of the query
+		if m.query() == "" {
+			m.toggle()
+			break
+		}
+		return m.updateSearch(keyMsg)
+	case "enter":
+		if m.canCreate() && m.cursor == len(m.filteredItems) {
+			m.pendingCreate = m.query()
+			break
+		}
+		m.confirmed = true
+	default:
+		return m.updateSearch(keyMsg)
+	}
+	return m, nil
+}
+
+// updateSearch forwards a key to the search input
+func (m *MultiPickerModel) updateSearch(msg tea.KeyMsg) (*MultiPickerModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	m.filterItems()
+	return m, cmd
+}
+
+// Confirmed returns true once the user has finished picking
+func (m *MultiPickerModel) Confirmed() bool {
+	return m.confirmed
+}
+
+// SelectedIDs returns the IDs of the checked items, in item order
+func (m *MultiPickerModel) SelectedIDs() []string {
+	var ids []string
+	for _, item := range m.items {
+		if m.checked[item.ID] {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// PendingCreate returns the name of an item the user asked to create, until
+// ResolveCreate is called
+func (m *MultiPickerModel) PendingCreate() string {
+	return m.pendingCreate
+}
+
+// ResolveCreate finishes a pending create. A created item is added to the
+// list already checked; nil means creating it failed.
+func (m *MultiPickerModel) ResolveCreate(item *PickerItem) {
+	m.pendingCreate = ""
+	if item == nil {
+		return
+	}
+	m.items = append(m.items, *item)
+	m.checked[item.ID] = true
+	m.searchInput.SetValue("")
+	m.filterItems()
+	m.cursor = len(m.filteredItems) - 1
+}
+
+// View renders the picker
+func (m *MultiPickerModel) View() string {
+	modalWidth := 44
+	maxVisibleItems := m.height - 10
+	if maxVisibleItems < 5 {
+		maxVisibleItems = 5
+	}
+
+	title := m.title
+	if n := len(m.SelectedIDs()); n > 0 {
+		title = fmt.Sprintf("%s (%d selected)", title, n)
+	}
+	titleView := theme.ModalTitleStyle.Render(title)
+	searchBar := theme.InputStyle.Width(modalWidth - 6).Render(m.searchInput.View())
+
+	rows := m.rowCount()
+	startIdx, endIdx := scrollWindow(m.cursor, rows, maxVisibleItems)
+
+	var items string
+	if rows == 0 {
+		items = theme.TextMutedStyle.Render("  No matches found\n")
+	}
+	for i := startIdx; i < endIdx; i++ {
+		cursor := "  "
+		style := theme.ListItemStyle
+		if i == m.cursor {
+			cursor = "> "
+			style = theme.ListItemSelectedStyle
+		}
+
+		if i == len(m.filteredItems) {
+			items += style.Render(fmt.Sprintf("%s+ Create %q", cursor, m.query())) + "\n"
+			continue
+		}
+
+		item := m.filteredItems[i]
+		check := "[ ] "
+		if m.checked[item.ID] {
+			check = "[✓] "
+		}
+		items += renderItem(style, cursor+check, item) + "\n"
+	}
+
+	scrollIndicator := scrollHint(startIdx, endIdx, rows)
+
+	helpText := "tab: toggle  enter: done  esc: cancel"
+	if m.pendingCreate != "" {
+		helpText = fmt.Sprintf("Creating %q...", m.pendingCreate)
+	}
+	help := theme.HelpStyle.Render(helpText)
+
+	contentParts := []string{titleView, searchBar, "", items}
+	if scrollIndicator != "" {
+		contentParts = append(contentParts, scrollIndicator)
+	}
+	contentParts = append(contentParts, help)
+
+	content := lipgloss.JoinVertical(lipgloss.Left, contentParts...)
+
+	modal := theme.ModalStyle.
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		modal,
+	)
+}
@@ -15,6 +15,7 @@ type PickerItem struct {
 	Label string
 	Icon  string
 	Desc  string
+	Color string // Hex color shown as a swatch instead of the icon, if set
 }
 
 // PickerModel is a modal picker for selecting items with search/filter support
@@ -122,6 +123,21 @@ func (m *PickerModel) Update(msg tea.Msg) (*PickerModel, tea.Cmd) {
 	return m, nil
 }
 
+// Query returns the trimmed search query
+func (m *PickerModel) Query() string {
+	return strings.TrimSpace(m.searchInput.Value())
+}
+
+// SetItems replaces the items with ones the owner already matched against
+// the query, such as remote search results. They are shown unfiltered.
+func (m *PickerModel) SetItems(items []PickerItem) {
+	m.items = items
+	m.filteredItems = items
+	if m.cursor >= len(m.filteredItems) {
+		m.cursor = 0
+	}
+}
+
 // Selected returns the selected item ID
 func (m *PickerModel) Selected() string {
 	return m.selected
@@ -152,24 +168,7 @@ func (m *PickerModel) View() string {
 		searchBar = theme.InputStyle.Width(modalWidth - 6).Render(m.searchInput.View())
 	}
 
-	// Calculate scroll window
-	startIdx := 0
-	endIdx := len(m.filteredItems)
-	if len(m.filteredItems) > maxVisibleItems {
-		// Keep cursor centered when possible
-		halfWindow := maxVisibleItems / 2
-		if m.cursor > halfWindow {
-			startIdx = m.cursor - halfWindow
-		}
-		endIdx = startIdx + maxVisibleItems
-		if endIdx > len(m.filteredItems) {
-			endIdx = len(m.filteredItems)
-			startIdx = endIdx - maxVisibleItems
-			if startIdx < 0 {
-				startIdx = 0
-			}
-		}
-	}
+	startIdx, endIdx := scrollWindow(m.cursor, len(m.filteredItems), maxVisibleItems)
 
 	// Items
 	var items string
@@ -185,26 +184,11 @@ func (m *PickerModel) View() string {
 				style = theme.ListItemSelectedStyle
 			}
 
-			icon := ""
-			if item.Icon != "" {
-				icon = item.Icon + " "
-			}
-
-			items += style.Render(cursor+icon+item.Label) + "\n"
+			items += renderItem(style, cursor, item) + "\n"
 		}
 	}
 
-	// Scroll indicators
-	var scrollIndicator string
-	if len(m.filteredItems) > maxVisibleItems {
-		if startIdx > 0 && endIdx < len(m.filteredItems) {
-			scrollIndicator = theme.TextMutedStyle.Render("  ▲ ▼ scroll for more")
-		} else if startIdx > 0 {
-			scrollIndicator = theme.TextMutedStyle.Render("  ▲ scroll up for more")
-		} else if endIdx < len(m.filteredItems) {
-			scrollIndicator = theme.TextMutedStyle.Render("  ▼ scroll down for more")
-		}
-	}
+	scrollIndicator := scrollHint(startIdx, endIdx, len(m.filteredItems))
 
 	// Help text
 	var helpText string
@@ -242,3 +226,48 @@ func (m *PickerModel) View() string {
 		modal,
 	)
 }
+
+// scrollWindow returns the range of rows to show so the cursor stays visible,
+// centered when possible
+func scrollWindow(cursor, total, maxVisible int) (start, end int) {
+	if total <= maxVisible {
+		return 0, total
+	}
+	halfWindow := maxVisible / 2
+	if cursor > halfWindow {
+		start = cursor - halfWindow
+	}
+	end = start + maxVisible
+	if end > total {
+		end = total
+		start = max(end-maxVisible, 0)
+	}
+	return start, end
+}
+
+// scrollHint renders the indicator for rows hidden above or below the window
+func scrollHint(start, end, total int) string {
+	switch {
+	case start > 0 && end < total:
+		return theme.TextMutedStyle.Render("  ▲ ▼ scroll for more")
+	case start > 0:
+		return theme.TextMutedStyle.Render("  ▲ scroll up for more")
+	case end < total:
+		return theme.TextMutedStyle.Render("  ▼ scroll down for more")
+	}
+	return ""
+}
+
+// renderItem renders a picker row. Color swatches are rendered separately so
+// they keep the row's background.
+func renderItem(style lipgloss.Style, prefix string, item PickerItem) string {
+	if item.Color == "" {
+		icon := ""
+		if item.Icon != "" {
+			icon = item.Icon + " "
+		}
+		return style.Render(prefix + icon + item.Label)
+	}
+	swatch := style.UnsetPadding().Foreground(lipgloss.Color(item.Color)).Render("●")
+	return style.PaddingRight(0).Render(prefix) + swatch + style.PaddingLeft(0).Render(" "+item.Label)
+}
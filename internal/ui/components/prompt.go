@@ -0,0 +1,72 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PromptModel is a modal asking for a single line of text
+type PromptModel struct {
+	title  string
+	input  textinput.Model
+	width  int
+	height int
+}
+
+// NewPromptModel creates a prompt with value pre-filled
+func NewPromptModel(title, placeholder, value string, width, height int) *PromptModel {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 50
+	ti.Width = 34
+	ti.SetValue(value)
+	ti.CursorEnd()
+	ti.Focus()
+
+	return &PromptModel{
+		title:  title,
+		input:  ti,
+		width:  width,
+		height: height,
+	}
+}
+
+// Update handles messages
+func (m *PromptModel) Update(msg tea.Msg) (*PromptModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Value returns the entered text, trimmed
+func (m *PromptModel) Value() string {
+	return strings.TrimSpace(m.input.Value())
+}
+
+// View renders the prompt
+func (m *PromptModel) View() string {
+	modalWidth := 44
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		theme.ModalTitleStyle.Render(m.title),
+		theme.InputFocusedStyle.Width(modalWidth-6).Render(m.input.View()),
+		"",
+		theme.HelpStyle.Render("enter: confirm  esc: cancel"),
+	)
+
+	modal := theme.ModalStyle.
+		Width(modalWidth).
+		Render(content)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		modal,
+	)
+}
@@ -0,0 +1,111 @@
+package markdown
+
+import "strings"
+
+// blockKind is the kind of a block of markdown
+type blockKind int
+
+const (
+	blankBlock blockKind = iota
+	codeBlock
+	headingBlock
+	ruleBlock
+	quoteBlock
+	tableBlock
+	listBlock
+	paragraphBlock
+)
+
+// block is a run of source lines that render together. Rendering and task
+// indexing both scan with scanBlocks, so they agree on which lines are list
+// items and which are code.
+type block struct {
+	kind  blockKind
+	start int      // Index of the block's first line
+	lines []string // Lines without quote markers for quotes, between the fences for code
+	lang  string   // Language of a code block
+}
+
+// scanBlocks splits lines of markdown into blocks
+func scanBlocks(lines []string) []block {
+	var blocks []block
+	for i := 0; i < len(lines); {
+		b := block{start: i}
+		trimmed := strings.TrimSpace(lines[i])
+
+		switch {
+		case trimmed == "":
+			b.kind = blankBlock
+			i++
+
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence := trimmed[:3]
+			b.kind = codeBlock
+			b.lang = strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1]))
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				b.lines = append(b.lines, lines[i])
+			}
+			i++ // Closing fence
+
+		case headingRe.MatchString(trimmed):
+			b.kind = headingBlock
+			b.lines = lines[i : i+1]
+			i++
+
+		case ruleRe.MatchString(trimmed):
+			b.kind = ruleBlock
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			b.kind = quoteBlock
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				q := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				b.lines = append(b.lines, strings.TrimPrefix(q, " "))
+			}
+
+		case i+1 < len(lines) && strings.Contains(trimmed, "|") && tableSepRe.MatchString(strings.TrimSpace(lines[i+1])):
+			b.kind = tableBlock
+			for ; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				b.lines = append(b.lines, strings.TrimSpace(lines[i]))
+			}
+
+		case listItemRe.MatchString(lines[i]):
+			b.kind = listBlock
+			for ; i < len(lines); i++ {
+				l := lines[i]
+				isItem := listItemRe.MatchString(l)
+				// An indented line continues the item above
+				if !isItem && (strings.TrimSpace(l) == "" || (l[0] != ' ' && l[0] != '\t')) {
+					break
+				}
+				b.lines = append(b.lines, l)
+			}
+
+		default:
+			b.kind = paragraphBlock
+			b.lines = lines[i : i+1]
+			i++
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+// listItem is an item of a list block with the lines continuing it joined on
+type listItem struct {
+	line int // Index of the item's first line in the block
+	text string
+}
+
+// listItems returns the items of a list block
+func (b block) listItems() []listItem {
+	var items []listItem
+	for n, l := range b.lines {
+		if listItemRe.MatchString(l) {
+			items = append(items, listItem{line: n, text: l})
+		} else {
+			items[len(items)-1].text += " " + strings.TrimSpace(l)
+		}
+	}
+	return items
+}
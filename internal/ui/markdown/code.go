@@ -0,0 +1,163 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// syntax describes enough of a language to highlight it
+type syntax struct {
+	comments []string // Line comment markers
+	keywords map[string]bool
+}
+
+// words builds a keyword set
+func words(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		set[w] = true
+	}
+	return set
+}
+
+var (
+	cLike  = []string{"//"}
+	hashes = []string{"#"}
+
+	goSyntax = syntax{cLike, words(`break case chan const continue default defer else fallthrough for func go goto if
+		import interface map package range return select struct switch type var nil true false iota error string int bool byte rune any`)}
+	jsSyntax = syntax{cLike, words(`async await break case catch class const continue debugger default delete do else export
+		extends false finally for from function if import in instanceof interface let new null return static super switch this
+		throw true try type typeof undefined var void while yield`)}
+	pySyntax = syntax{hashes, words(`and as assert async await break class continue def del elif else except False finally
+		for from global if import in is lambda None nonlocal not or pass raise return True try while with yield self`)}
+	rustSyntax = syntax{cLike, words(`as async await break const continue crate else enum extern false fn for if impl in let
+		loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while`)}
+	shSyntax = syntax{hashes, words(`if then else elif fi for while until do done case esac function in return export local
+		echo cd exit set unset source`)}
+	sqlSyntax = syntax{[]string{"--"}, words(`select from where and or not insert into values update set delete create table
+		drop alter join left right inner outer on group by order having limit as null is in like distinct union
+		SELECT FROM WHERE AND OR NOT INSERT INTO VALUES UPDATE SET DELETE CREATE TABLE DROP ALTER JOIN LEFT RIGHT INNER
+		OUTER ON GROUP BY ORDER HAVING LIMIT AS NULL IS IN LIKE DISTINCT UNION`)}
+	dataSyntax = syntax{hashes, words(`true false null yes no`)}
+)
+
+// syntaxes maps code fence languages to their syntax
+var syntaxes = map[string]syntax{
+	"go": goSyntax, "golang": goSyntax,
+	"js": jsSyntax, "javascript": jsSyntax, "ts": jsSyntax, "typescript": jsSyntax, "jsx": jsSyntax, "tsx": jsSyntax,
+	"py": pySyntax, "python": pySyntax,
+	"rs": rustSyntax, "rust": rustSyntax,
+	"sh": shSyntax, "bash": shSyntax, "zsh": shSyntax, "shell": shSyntax, "console": shSyntax,
+	"sql":  sqlSyntax,
+	"yaml": dataSyntax, "yml": dataSyntax, "toml": dataSyntax, "json": {nil, words("true false null")},
+}
+
+var (
+	codeStyle    = lipgloss.NewStyle().Foreground(theme.Text).Background(theme.Surface)
+	keywordStyle = codeStyle.Foreground(theme.PrimaryBright)
+	stringStyle  = codeStyle.Foreground(theme.Success)
+	numberStyle  = codeStyle.Foreground(theme.Warning)
+	commentStyle = codeStyle.Foreground(theme.TextMuted).Italic(true)
+)
+
+// renderCode renders a fenced code block on a background spanning width,
+// with the language in the top line. Long lines are cut off rather than
+// wrapped.
+func renderCode(lines []string, lang string, width int) []string {
+	if fields := strings.Fields(lang); len(fields) > 0 {
+		lang = strings.ToLower(fields[0])
+	}
+	syn, known := syntaxes[lang]
+
+	pad := func(s string, used int) string {
+		return s + codeStyle.Render(strings.Repeat(" ", max(width-used, 0)))
+	}
+
+	var out []string
+	if lang != "" {
+		out = append(out, pad(commentStyle.Render(" "+lang), runewidth.StringWidth(lang)+1))
+	}
+	for _, line := range lines {
+		line = runewidth.Truncate(strings.ReplaceAll(line, "\t", "    "), width-2, "…")
+		text := codeStyle.Render(line)
+		if known {
+			text = highlight(line, syn)
+		}
+		out = append(out, pad(codeStyle.Render(" ")+text, runewidth.StringWidth(line)+1))
+	}
+	return out
+}
+
+// highlight colors a line of code: keywords, strings, numbers and comments
+func highlight(line string, syn syntax) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		rest := line[i:]
+
+		if commentStart(rest, syn) {
+			b.WriteString(commentStyle.Render(rest))
+			break
+		}
+
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case r == '"' || r == '\'' || r == '`':
+			end := 1
+			for end < len(rest) && rest[end] != byte(r) {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(rest))
+			b.WriteString(stringStyle.Render(rest[:end]))
+			i += end
+
+		case unicode.IsDigit(r):
+			end := strings.IndexFunc(rest, func(r rune) bool {
+				return !unicode.IsDigit(r) && !unicode.IsLetter(r) && r != '.' && r != '_'
+			})
+			if end < 0 {
+				end = len(rest)
+			}
+			b.WriteString(numberStyle.Render(rest[:end]))
+			i += end
+
+		case unicode.IsLetter(r) || r == '_':
+			end := strings.IndexFunc(rest, func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+			})
+			if end < 0 {
+				end = len(rest)
+			}
+			word := rest[:end]
+			if syn.keywords[word] {
+				b.WriteString(keywordStyle.Render(word))
+			} else {
+				b.WriteString(codeStyle.Render(word))
+			}
+			i += end
+
+		default:
+			b.WriteString(codeStyle.Render(rest[:size]))
+			i += size
+		}
+	}
+	return b.String()
+}
+
+// commentStart reports whether a line comment starts at the start of s
+func commentStart(s string, syn syntax) bool {
+	for _, marker := range syn.comments {
+		if strings.HasPrefix(s, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,278 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+var (
+	codeSpanStyle = lipgloss.NewStyle().Foreground(theme.PriorityHigh).Background(theme.Surface)
+	linkStyle     = lipgloss.NewStyle().Foreground(theme.Info).Underline(true)
+	urlStyle      = lipgloss.NewStyle().Foreground(theme.TextMuted)
+)
+
+// escapable are the characters a backslash escapes
+const escapable = "\\`*_{}[]()#+-.!|~<>"
+
+// span is a run of inline text in one style
+type span struct {
+	text  string
+	style lipgloss.Style
+}
+
+// parseInline splits a line into styled spans: code, bold, italic,
+// strikethrough, links, images and bare URLs. Emphasis markers without a
+// closing marker are kept as text.
+func parseInline(s string, base lipgloss.Style) []span {
+	var spans []span
+	var buf strings.Builder
+	bold, italic, strike := false, false, false
+
+	style := func() lipgloss.Style {
+		st := base
+		if bold {
+			st = st.Bold(true)
+		}
+		if italic {
+			st = st.Italic(true)
+		}
+		if strike {
+			st = st.Strikethrough(true)
+		}
+		return st
+	}
+	flush := func() {
+		if buf.Len() > 0 {
+			spans = append(spans, span{buf.String(), style()})
+			buf.Reset()
+		}
+	}
+	// toggle flips an emphasis flag at a marker, if the marker closes an
+	// open emphasis or has a closing marker later in the line
+	toggle := func(flag *bool, marker, rest string) bool {
+		if !*flag && !strings.Contains(rest, marker) {
+			return false
+		}
+		flush()
+		*flag = !*flag
+		return true
+	}
+
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		prev, _ := utf8.DecodeLastRuneInString(s[:i])
+		atWordStart := i == 0 || !isWordRune(prev)
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.IndexByte(escapable, rest[1]) >= 0:
+			buf.WriteByte(rest[1])
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			n := len(rest) - len(strings.TrimLeft(rest, "`"))
+			fence := rest[:n]
+			end := strings.Index(rest[n:], fence)
+			if end < 0 {
+				break
+			}
+			flush()
+			spans = append(spans, span{strings.TrimSpace(rest[n : n+end]), codeSpanStyle})
+			i += 2*n + end
+			continue
+
+		case strings.HasPrefix(rest, "**") || (strings.HasPrefix(rest, "__") && (bold || atWordStart)):
+			if toggle(&bold, rest[:2], rest[2:]) {
+				i += 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "~~"):
+			if toggle(&strike, "~~", rest[2:]) {
+				i += 2
+				continue
+			}
+
+		case rest[0] == '*' && (italic || (len(rest) > 1 && rest[1] != ' ')):
+			if toggle(&italic, "*", rest[1:]) {
+				i++
+				continue
+			}
+
+		case rest[0] == '_' && (italic || atWordStart):
+			next, _ := utf8.DecodeRuneInString(rest[1:])
+			if italic && isWordRune(next) {
+				break
+			}
+			if toggle(&italic, "_", rest[1:]) {
+				i++
+				continue
+			}
+
+		case strings.HasPrefix(rest, "!["):
+			if text, url, n, ok := parseLink(rest[1:]); ok {
+				flush()
+				if text == "" {
+					text = url
+				}
+				spans = append(spans, span{"[image: " + text + "]", urlStyle})
+				i += 1 + n
+				continue
+			}
+
+		case rest[0] == '[':
+			if text, url, n, ok := parseLink(rest); ok {
+				flush()
+				spans = append(spans, linkSpans(text, url)...)
+				i += n
+				continue
+			}
+
+		case rest[0] == '<' && (strings.HasPrefix(rest, "<http://") || strings.HasPrefix(rest, "<https://")):
+			if end := strings.IndexByte(rest, '>'); end > 0 {
+				flush()
+				spans = append(spans, span{rest[1:end], linkStyle})
+				i += end + 1
+				continue
+			}
+
+		case atWordStart && (strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://")):
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			url := strings.TrimRight(rest[:end], ".,;:!?)")
+			flush()
+			spans = append(spans, span{url, linkStyle})
+			i += len(url)
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(rest)
+		buf.WriteString(rest[:size])
+		i += size
+	}
+	flush()
+	return spans
+}
+
+// parseLink parses "[text](url)" at the start of s, returning the length parsed
+func parseLink(s string) (text, url string, n int, ok bool) {
+	closeText := strings.Index(s, "](")
+	if closeText < 1 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(s[closeText+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	text = s[1:closeText]
+	url = s[closeText+2 : closeText+2+closeURL]
+	// Drop a link title: [text](url "title")
+	if space := strings.IndexByte(url, ' '); space >= 0 {
+		url = url[:space]
+	}
+	return text, url, closeText + 3 + closeURL, true
+}
+
+// linkSpans renders a link's text, followed by its URL unless the text
+// already shows it
+func linkSpans(text, url string) []span {
+	spans := []span{{text, linkStyle}}
+	if text != url && url != "" {
+		spans = append(spans, span{" (" + url + ")", urlStyle})
+	}
+	return spans
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// piece is a styled fragment of a word; words can change style midway
+type piece struct {
+	text  string
+	style lipgloss.Style
+}
+
+// wrap lays spans out in lines of at most width cells. The first line starts
+// with prefix and the others with hang, so list items line up.
+func wrap(spans []span, width int, prefix, hang string) []string {
+	var words [][]piece
+	var word []piece
+	endWord := func() {
+		if len(word) > 0 {
+			words = append(words, word)
+			word = nil
+		}
+	}
+	for _, sp := range spans {
+		for j, part := range strings.Split(sp.text, " ") {
+			if j > 0 {
+				endWord()
+			}
+			if part != "" {
+				word = append(word, piece{part, sp.style})
+			}
+		}
+	}
+	endWord()
+
+	var lines []string
+	var line strings.Builder
+	line.WriteString(prefix)
+	avail := max(width-lipgloss.Width(prefix), 1)
+	used := 0
+	newLine := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		line.WriteString(hang)
+		avail = max(width-lipgloss.Width(hang), 1)
+		used = 0
+	}
+
+	for _, w := range words {
+		wordWidth := 0
+		for _, p := range w {
+			wordWidth += runewidth.StringWidth(p.text)
+		}
+		if used > 0 && used+1+wordWidth > avail {
+			newLine()
+		}
+		if used > 0 {
+			line.WriteString(" ")
+			used++
+		}
+
+		if wordWidth <= avail-used {
+			for _, p := range w {
+				line.WriteString(p.style.Render(p.text))
+			}
+			used += wordWidth
+			continue
+		}
+
+		// Break words too long for a line, such as URLs, wherever they fill it
+		for _, p := range w {
+			var chunk strings.Builder
+			for _, r := range p.text {
+				rw := runewidth.RuneWidth(r)
+				if used+rw > avail {
+					line.WriteString(p.style.Render(chunk.String()))
+					chunk.Reset()
+					newLine()
+				}
+				chunk.WriteRune(r)
+				used += rw
+			}
+			line.WriteString(p.style.Render(chunk.String()))
+		}
+	}
+	lines = append(lines, line.String())
+	return lines
+}
@@ -0,0 +1,166 @@
+// Package markdown renders the markdown of issue descriptions and comments
+// as styled terminal text in the theme's palette.
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listItemRe = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	taskRe     = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	ruleRe     = regexp.MustCompile(`^(\*\s*){3,}$|^(-\s*){3,}$|^(_\s*){3,}$`)
+	tableSepRe = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+)
+
+// Render renders markdown wrapped to width: headings, emphasis, lists and
+// task checkboxes, fenced code with syntax highlighting, blockquotes, tables
+// and links. Single line breaks are kept, as in Linear's editor.
+func Render(src string, width int) string {
+	out, _ := RenderTasks(src, width, -1)
+	return out
+}
+
+// RenderTasks renders markdown like Render, highlighting the checkbox of the
+// selected task item, and returns the line each task item starts on in the
+// output. Pass -1 to select none.
+func RenderTasks(src string, width, selected int) (string, []int) {
+	width = max(width, 20)
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	r := &renderer{selected: selected}
+	return strings.Join(r.renderBlocks(strings.Split(src, "\n"), width, textStyle), "\n"), r.tasks
+}
+
+// renderer tracks the task items seen while rendering
+type renderer struct {
+	selected int   // Index of the highlighted task item, -1 for none
+	tasks    []int // Output line of each task item
+}
+
+// shiftTasks moves the output lines of the task items recorded since the
+// first one by offset, once the lines they're in have been placed
+func (r *renderer) shiftTasks(first, offset int) {
+	for k := first; k < len(r.tasks); k++ {
+		r.tasks[k] += offset
+	}
+}
+
+// renderBlocks renders lines of markdown as blocks separated by single blank
+// lines, with text in the base style
+func (r *renderer) renderBlocks(lines []string, width int, base lipgloss.Style) []string {
+	var out []string
+	for _, b := range scanBlocks(lines) {
+		switch b.kind {
+		case blankBlock:
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+
+		case codeBlock:
+			out = append(out, renderCode(b.lines, b.lang, width)...)
+
+		case headingBlock:
+			m := headingRe.FindStringSubmatch(strings.TrimSpace(b.lines[0]))
+			out = append(out, wrap(parseInline(m[2], headingStyle(len(m[1]))), width, "", "")...)
+
+		case ruleBlock:
+			out = append(out, theme.Divider(width))
+
+		case quoteBlock:
+			bar := lipgloss.NewStyle().Foreground(theme.PrimaryDim).Render("│ ")
+			first := len(r.tasks)
+			rendered := r.renderBlocks(b.lines, width-2, quoteStyle)
+			r.shiftTasks(first, len(out))
+			for _, q := range rendered {
+				out = append(out, bar+q)
+			}
+
+		case tableBlock:
+			out = append(out, renderTable(b.lines, width)...)
+
+		case listBlock:
+			first := len(r.tasks)
+			rendered := r.renderList(b.listItems(), width, base)
+			r.shiftTasks(first, len(out))
+			out = append(out, rendered...)
+
+		default:
+			out = append(out, wrap(parseInline(strings.TrimSpace(b.lines[0]), base), width, "", "")...)
+		}
+	}
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+var (
+	textStyle  = lipgloss.NewStyle().Foreground(theme.Text)
+	quoteStyle = lipgloss.NewStyle().Foreground(theme.TextMuted).Italic(true)
+)
+
+// headingStyle returns the style of a heading level
+func headingStyle(level int) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	switch level {
+	case 1:
+		return style.Foreground(theme.PrimaryBright).Underline(true)
+	case 2:
+		return style.Foreground(theme.PrimaryBright)
+	case 3:
+		return style.Foreground(theme.TextBright)
+	default:
+		return style.Foreground(theme.TextMuted)
+	}
+}
+
+// renderList renders list items, nested by their indentation
+func (r *renderer) renderList(items []listItem, width int, base lipgloss.Style) []string {
+	var out []string
+	markerStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+	for _, item := range items {
+		m := listItemRe.FindStringSubmatch(item.text)
+		indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+		level := min(indent/2, 4)
+		marker, text := m[2], m[3]
+
+		switch {
+		case marker[0] >= '0' && marker[0] <= '9':
+			n, _ := strconv.Atoi(strings.TrimRight(marker, ".)"))
+			marker = markerStyle.Render(strconv.Itoa(n) + ".")
+		default:
+			marker = markerStyle.Render([]string{"•", "◦", "▪"}[level%3])
+		}
+
+		style := base
+		if t := taskRe.FindStringSubmatch(text); t != nil {
+			text = t[2]
+			box := lipgloss.NewStyle().Foreground(theme.TextMuted)
+			if t[1] == " " {
+				marker = "☐"
+			} else {
+				box = box.Foreground(theme.Success)
+				marker = "☑"
+				style = lipgloss.NewStyle().Foreground(theme.TextMuted).Strikethrough(true)
+			}
+			if len(r.tasks) == r.selected {
+				box = box.Reverse(true)
+				style = style.Foreground(theme.TextBright)
+			}
+			marker = box.Render(marker)
+			r.tasks = append(r.tasks, len(out))
+		}
+
+		prefix := strings.Repeat("  ", level) + marker + " "
+		hang := strings.Repeat(" ", lipgloss.Width(prefix))
+		out = append(out, wrap(parseInline(text, style), width, prefix, hang)...)
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderTable renders a table's header, separator and body rows with box
+// drawing borders. Columns are narrowed, widest first, to fit width.
+func renderTable(rows []string, width int) []string {
+	var cells [][]string
+	var aligns []lipgloss.Position
+	for i, row := range rows {
+		fields := splitRow(row)
+		if i == 1 {
+			for _, f := range fields {
+				f = strings.TrimSpace(f)
+				switch {
+				case strings.HasPrefix(f, ":") && strings.HasSuffix(f, ":"):
+					aligns = append(aligns, lipgloss.Center)
+				case strings.HasSuffix(f, ":"):
+					aligns = append(aligns, lipgloss.Right)
+				default:
+					aligns = append(aligns, lipgloss.Left)
+				}
+			}
+			continue
+		}
+		cells = append(cells, fields)
+	}
+
+	columns := len(aligns)
+	rendered := make([][]string, len(cells))
+	widths := make([]int, columns)
+	for r, row := range cells {
+		rendered[r] = make([]string, columns)
+		for c := 0; c < columns && c < len(row); c++ {
+			style := textStyle
+			if r == 0 {
+				style = style.Bold(true)
+			}
+			// Cells stay on one line
+			text := strings.Join(wrap(parseInline(strings.TrimSpace(row[c]), style), 1<<16, "", ""), " ")
+			rendered[r][c] = text
+			widths[c] = max(widths[c], lipgloss.Width(text))
+		}
+	}
+
+	// Each column takes its width plus a space either side and a border
+	for total(widths)+3*columns+1 > width {
+		widest := 0
+		for c := range widths {
+			if widths[c] > widths[widest] {
+				widest = c
+			}
+		}
+		if widths[widest] <= 3 {
+			break
+		}
+		widths[widest]--
+	}
+
+	border := lipgloss.NewStyle().Foreground(theme.TextDim)
+	line := func(left, mid, right string) string {
+		parts := make([]string, columns)
+		for c, w := range widths {
+			parts[c] = strings.Repeat("─", w+2)
+		}
+		return border.Render(left + strings.Join(parts, mid) + right)
+	}
+
+	out := []string{line("┌", "┬", "┐")}
+	for r, row := range rendered {
+		var b strings.Builder
+		b.WriteString(border.Render("│"))
+		for c, text := range row {
+			cell := lipgloss.NewStyle().MaxWidth(widths[c]).Render(text)
+			cell = lipgloss.PlaceHorizontal(widths[c], aligns[c], cell)
+			b.WriteString(" " + cell + " " + border.Render("│"))
+		}
+		out = append(out, b.String())
+		if r == 0 {
+			out = append(out, line("├", "┼", "┤"))
+		}
+	}
+	return append(out, line("└", "┴", "┘"))
+}
+
+// splitRow splits a table row into cells at unescaped pipes
+func splitRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case row[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, cell.String())
+}
+
+func total(widths []int) int {
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	return sum
+}
@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Task is a task list item: "- [ ] text" or "- [x] text"
+type Task struct {
+	Line    int // Line of the source it's on
+	Checked bool
+	Text    string
+}
+
+// Tasks returns the task items of src in order: the items Render draws as
+// checkboxes, including those inside blockquotes but not in code
+func Tasks(src string) []Task {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	return appendTasks(nil, lines, 0)
+}
+
+// appendTasks appends the task items of lines, which start at line offset
+// of the source
+func appendTasks(tasks []Task, lines []string, offset int) []Task {
+	for _, b := range scanBlocks(lines) {
+		switch b.kind {
+		case quoteBlock:
+			tasks = appendTasks(tasks, b.lines, offset+b.start)
+		case listBlock:
+			for _, item := range b.listItems() {
+				m := listItemRe.FindStringSubmatch(item.text)
+				if t := taskRe.FindStringSubmatch(m[3]); t != nil {
+					tasks = append(tasks, Task{Line: offset + b.start + item.line, Checked: t[1] != " ", Text: t[2]})
+				}
+			}
+		}
+	}
+	return tasks
+}
+
+// TaskProgress returns how many of the task items in src are checked, and
+// how many there are
+func TaskProgress(src string) (done, total int) {
+	tasks := Tasks(src)
+	for _, task := range tasks {
+		if task.Checked {
+			done++
+		}
+	}
+	return done, len(tasks)
+}
+
+// ProgressLabel renders task progress as "(3/7)", green once every item is
+// checked, or returns "" if src has no task items
+func ProgressLabel(src string) string {
+	done, total := TaskProgress(src)
+	if total == 0 {
+		return ""
+	}
+	style := theme.TextDimStyle
+	if done == total {
+		style = lipgloss.NewStyle().Foreground(theme.Success)
+	}
+	return style.Render(fmt.Sprintf("(%d/%d)", done, total))
+}
+
+// ToggleTask checks or unchecks the nth task item of src, leaving the rest
+// of the text untouched. It returns src unchanged if there's no such item.
+func ToggleTask(src string, n int) string {
+	tasks := Tasks(src)
+	if n < 0 || n >= len(tasks) {
+		return src
+	}
+
+	lines := strings.Split(src, "\n")
+	line := lines[tasks[n].Line]
+	// The checkbox is the first bracket: quote and list markers have none
+	box := strings.IndexByte(line, '[')
+	mark := "x"
+	if tasks[n].Checked {
+		mark = " "
+	}
+	lines[tasks[n].Line] = line[:box+1] + mark + line[box+2:]
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTasks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []Task
+	}{
+		{
+			name: "list items",
+			src:  "Intro\n\n- [ ] one\n- [x] two\n* [X] three\n1. [ ] four\n- not a task",
+			want: []Task{
+				{Line: 2, Text: "one"},
+				{Line: 3, Checked: true, Text: "two"},
+				{Line: 4, Checked: true, Text: "three"},
+				{Line: 5, Text: "four"},
+			},
+		},
+		{
+			name: "fenced code is skipped",
+			src:  "```\n- [ ] in code\n```\n- [ ] after",
+			want: []Task{{Line: 3, Text: "after"}},
+		},
+		{
+			name: "indented fence inside a list item is part of the item",
+			src:  "- [ ] one\n  ```\n- [ ] two\n  ```\n- [x] three",
+			want: []Task{
+				{Line: 0, Text: "one ```"},
+				{Line: 2, Text: "two ```"},
+				{Line: 4, Checked: true, Text: "three"},
+			},
+		},
+		{
+			name: "blockquotes",
+			src:  "> - [ ] quoted\n> > - [x] nested\n\n- [ ] plain",
+			want: []Task{
+				{Line: 0, Text: "quoted"},
+				{Line: 1, Checked: true, Text: "nested"},
+				{Line: 3, Text: "plain"},
+			},
+		},
+		{
+			name: "windows line endings",
+			src:  "- [ ] one\r\n- [x] two\r\n",
+			want: []Task{{Line: 0, Text: "one"}, {Line: 1, Checked: true, Text: "two"}},
+		},
+		{
+			name: "no tasks",
+			src:  "# Heading\n\n| a | b |\n|---|---|\n| - [ ] x | y |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Tasks(tt.src)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tasks() = %+v\nwant      %+v", got, tt.want)
+			}
+			// Every task item is drawn as a checkbox
+			if _, lines := RenderTasks(tt.src, 80, -1); len(lines) != len(got) {
+				t.Errorf("rendered %d task items, Tasks found %d", len(lines), len(got))
+			}
+		})
+	}
+}
+
+func TestToggleTask(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		n    int
+		want string
+	}{
+		{
+			name: "check",
+			src:  "- [ ] one\n- [ ] two",
+			n:    1,
+			want: "- [ ] one\n- [x] two",
+		},
+		{
+			name: "uncheck",
+			src:  "- [X] one",
+			n:    0,
+			want: "- [ ] one",
+		},
+		{
+			name: "skips items in code",
+			src:  "```\n- [ ] code\n```\n- [ ] real",
+			n:    0,
+			want: "```\n- [ ] code\n```\n- [x] real",
+		},
+		{
+			name: "item after an indented fence",
+			src:  "- [ ] one\n  ```\n- [ ] two",
+			n:    1,
+			want: "- [ ] one\n  ```\n- [x] two",
+		},
+		{
+			name: "quoted and nested",
+			src:  "> - [ ] quoted\n  - [ ] nested",
+			n:    1,
+			want: "> - [ ] quoted\n  - [x] nested",
+		},
+		{
+			name: "out of range",
+			src:  "- [ ] one",
+			n:    3,
+			want: "- [ ] one",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToggleTask(tt.src, tt.n); got != tt.want {
+				t.Errorf("ToggleTask() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
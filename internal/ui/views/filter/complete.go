@@ -0,0 +1,157 @@
+package filter
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Completion is the result of completing a search query term
+type Completion struct {
+	Query      string   // The query with the term completed
+	Cursor     int      // Byte offset of the cursor after the completed term
+	Candidates []string // Every completion of the term
+}
+
+// Complete completes the query term before the byte offset cursor: a field
+// name, or a field's value from opts. Repeated completions of the same
+// query pass n = 0, 1, 2... to cycle through the candidates. It returns
+// false if there is nothing to complete.
+func Complete(s string, cursor int, opts Options, n int) (Completion, bool) {
+	start, end := cursor, cursor
+	for _, t := range splitQuery(s) {
+		if t.start <= cursor && cursor <= t.end {
+			start, end = t.start, t.end
+			break
+		}
+	}
+
+	prefix := s[start:cursor]
+	var candidates []string
+	if colon := strings.IndexByte(prefix, ':'); colon < 0 {
+		for _, field := range queryFields {
+			if strings.HasPrefix(field, strings.ToLower(prefix)) {
+				candidates = append(candidates, field+":")
+			}
+		}
+	} else {
+		field := strings.ToLower(prefix[:colon])
+		partial := strings.ToLower(strings.ReplaceAll(prefix[colon+1:], `"`, ""))
+		for _, value := range fieldValues(field, opts) {
+			if strings.HasPrefix(strings.ToLower(value), partial) {
+				candidates = append(candidates, field+":"+quoteValue(value)+" ")
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return Completion{}, false
+	}
+
+	term := candidates[n%len(candidates)]
+	// Don't double the space that separated the term from the next one
+	rest := s[end:]
+	if strings.HasSuffix(term, " ") && strings.HasPrefix(rest, " ") {
+		rest = rest[1:]
+	}
+	return Completion{
+		Query:      s[:start] + term + rest,
+		Cursor:     start + len(term),
+		Candidates: candidates,
+	}, true
+}
+
+// fieldValues returns the values offered when completing a field
+func fieldValues(field string, opts Options) []string {
+	var values []string
+	switch field {
+	case "assignee":
+		values = []string{"me", "none"}
+		for _, u := range opts.Users {
+			values = append(values, u.Name)
+		}
+	case "state":
+		values = append(dedupe(stateNames(opts.States)), stateTypes...)
+	case "label":
+		values = dedupe(labelNames(opts.Labels))
+	case "priority":
+		values = []string{"urgent", "high", "medium", "low", "none", "<=2", ">=3"}
+	case "project":
+		values = projectNames(opts.Projects)
+	case "team":
+		for _, t := range opts.Teams {
+			values = append(values, t.Key)
+		}
+	case "cycle":
+		values = []string{"current", "next", "previous"}
+		for _, c := range opts.Cycles {
+			values = append(values, strconv.Itoa(c.Number))
+		}
+	case "due":
+		values = []string{"overdue", "today", "<7d", "<30d", ">30d"}
+	case "created", "updated":
+		values = []string{"today", "<7d", "<30d", ">30d", ">90d"}
+	}
+	return values
+}
+
+// dedupe sorts names and removes duplicates, ignoring case
+func dedupe(names []string) []string {
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+	var out []string
+	for _, name := range names {
+		if len(out) == 0 || !strings.EqualFold(out[len(out)-1], name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// quoteValue quotes a value containing spaces
+func quoteValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// RenderQuery renders a query with field names highlighted and invalid
+// terms marked. cursor is the cursor's byte offset, or -1 to hide it.
+func RenderQuery(s string, cursor int, q Query) string {
+	fieldStyle := lipgloss.NewStyle().Foreground(theme.Primary)
+	errStyle := theme.ErrorStyle.Underline(true)
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+
+	// styleAt returns the style of the byte at offset i
+	styleAt := func(i int) lipgloss.Style {
+		for _, e := range q.Errors {
+			if i >= e.Start && i < e.End {
+				return errStyle
+			}
+		}
+		for _, t := range q.terms {
+			if t.field != "" && i >= t.start && i <= t.start+len(t.field) {
+				return fieldStyle
+			}
+		}
+		return theme.TextStyle
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		style := styleAt(i)
+		if i == cursor {
+			style = cursorStyle
+		}
+		b.WriteString(style.Render(s[i : i+size]))
+		i += size
+	}
+	if cursor >= len(s) {
+		b.WriteString(cursorStyle.Render(" "))
+	}
+	return b.String()
+}
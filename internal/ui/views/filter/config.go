@@ -0,0 +1,126 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/config"
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// FromConfig builds the issue filter of a view defined in config. Names are
+// left for Linear to match, so no lookups are needed.
+func FromConfig(cfg config.ViewFilterConfig, now time.Time) (linear.IssueFilter, error) {
+	f := linear.IssueFilter{
+		TeamKey:     cfg.Team,
+		StateNames:  cfg.States,
+		LabelNames:  cfg.Labels,
+		Priorities:  cfg.Priorities,
+		ProjectName: cfg.Project,
+		CycleName:   cfg.Cycle,
+	}
+
+	if strings.EqualFold(cfg.Assignee, "none") {
+		f.NoAssignee = true
+	} else {
+		f.AssigneeName = cfg.Assignee
+	}
+
+	for _, p := range cfg.Priorities {
+		if p < 0 || p > 4 {
+			return linear.IssueFilter{}, fmt.Errorf("invalid priority %d (0-4)", p)
+		}
+	}
+
+	dates := []struct {
+		name  string
+		value string
+		r     *linear.DateRange
+	}{
+		{"due", cfg.Due, &f.Due},
+		{"created", cfg.Created, &f.Created},
+		{"updated", cfg.Updated, &f.Updated},
+	}
+	for _, d := range dates {
+		r, err := ParseDateRange(d.value, now)
+		if err != nil {
+			return linear.IssueFilter{}, fmt.Errorf("%s: %w", d.name, err)
+		}
+		*d.r = r
+	}
+	return f, nil
+}
+
+// ToConfig converts an issue filter to the form views are saved in, using
+// names rather than IDs. viewerID is written as "me".
+func ToConfig(f linear.IssueFilter, opts Options, viewerID string) config.ViewFilterConfig {
+	cfg := config.ViewFilterConfig{
+		Team:       f.TeamKey,
+		Assignee:   f.AssigneeName,
+		States:     append([]string(nil), f.StateNames...),
+		Labels:     append([]string(nil), f.LabelNames...),
+		Priorities: append([]int(nil), f.Priorities...),
+		Project:    f.ProjectName,
+		Cycle:      f.CycleName,
+		Due:        FormatDateRange(f.Due),
+		Created:    FormatDateRange(f.Created),
+		Updated:    FormatDateRange(f.Updated),
+	}
+
+	for _, t := range opts.Teams {
+		if t.ID == f.TeamID {
+			cfg.Team = t.Key
+		}
+	}
+
+	switch {
+	case f.NoAssignee:
+		cfg.Assignee = "none"
+	case f.AssigneeID != "" && f.AssigneeID == viewerID:
+		cfg.Assignee = "me"
+	case f.AssigneeID != "":
+		for _, u := range opts.Users {
+			if u.ID == f.AssigneeID {
+				cfg.Assignee = u.Email
+				if cfg.Assignee == "" {
+					cfg.Assignee = u.Name
+				}
+			}
+		}
+	}
+
+	if f.StateType != "" {
+		cfg.States = append(cfg.States, f.StateType)
+	}
+	for _, s := range opts.States {
+		if containsString(f.States, s.ID) {
+			cfg.States = append(cfg.States, s.Name)
+		}
+	}
+	for _, l := range opts.Labels {
+		if containsString(f.Labels, l.ID) {
+			cfg.Labels = append(cfg.Labels, l.Name)
+		}
+	}
+
+	for _, p := range opts.Projects {
+		if p.ID == f.ProjectID {
+			cfg.Project = p.Name
+		}
+	}
+
+	for _, c := range opts.Cycles {
+		if c.ID != f.CycleID {
+			continue
+		}
+		if c.IsActive {
+			cfg.Cycle = "current"
+		} else {
+			cfg.Cycle = strconv.Itoa(c.Number)
+		}
+	}
+
+	return cfg
+}
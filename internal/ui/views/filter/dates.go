@@ -0,0 +1,131 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// dateLayout is the format dates are typed and shown in
+const dateLayout = "2006-01-02"
+
+// DateRangeHelp describes the formats ParseDateRange accepts
+const DateRangeHelp = "2024-01-31, 2024-01-01..2024-01-31, 2024-01-01.., ..2024-01-31, -7d, +2w, today, overdue"
+
+// ParseDateRange parses a range of days relative to now:
+//
+//	2024-01-31               a single day
+//	2024-01-01..2024-01-31   from one day to another, inclusive
+//	2024-01-01.. / ..2024-01-31   open-ended
+//	-7d / -2w / -3m          the last 7 days, 2 weeks or 3 months, up to today
+//	+7d / +2w / +3m          today and the next 7 days, 2 weeks or 3 months
+//	today / overdue          today / any day before today
+func ParseDateRange(s string, now time.Time) (linear.DateRange, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := startOfDay(now)
+
+	switch s {
+	case "":
+		return linear.DateRange{}, nil
+	case "today":
+		return linear.DateRange{From: today, To: today}, nil
+	case "overdue":
+		return linear.DateRange{To: today.AddDate(0, 0, -1)}, nil
+	}
+
+	if (s[0] == '-' || s[0] == '+') && len(s) > 2 {
+		n, err := strconv.Atoi(s[1 : len(s)-1])
+		if err != nil || n <= 0 {
+			return linear.DateRange{}, fmt.Errorf("invalid relative range %q", s)
+		}
+		if s[0] == '-' {
+			n = -n
+		}
+		var other time.Time
+		switch s[len(s)-1] {
+		case 'd':
+			other = today.AddDate(0, 0, n)
+		case 'w':
+			other = today.AddDate(0, 0, 7*n)
+		case 'm':
+			other = today.AddDate(0, n, 0)
+		default:
+			return linear.DateRange{}, fmt.Errorf("invalid relative range %q", s)
+		}
+		if n < 0 {
+			return linear.DateRange{From: other, To: today}, nil
+		}
+		return linear.DateRange{From: today, To: other}, nil
+	}
+
+	from, to, isRange := strings.Cut(s, "..")
+	if !isRange {
+		to = from
+	}
+	var r linear.DateRange
+	var err error
+	if from != "" {
+		if r.From, err = time.ParseInLocation(dateLayout, from, now.Location()); err != nil {
+			return linear.DateRange{}, fmt.Errorf("invalid date %q", from)
+		}
+	}
+	if to != "" {
+		if r.To, err = time.ParseInLocation(dateLayout, to, now.Location()); err != nil {
+			return linear.DateRange{}, fmt.Errorf("invalid date %q", to)
+		}
+	}
+	if r.IsZero() {
+		return linear.DateRange{}, fmt.Errorf("invalid range %q", s)
+	}
+	if !r.From.IsZero() && !r.To.IsZero() && r.To.Before(r.From) {
+		return linear.DateRange{}, fmt.Errorf("range ends before it starts")
+	}
+	return r, nil
+}
+
+// FormatDateRange formats a range in the form ParseDateRange accepts
+func FormatDateRange(r linear.DateRange) string {
+	switch {
+	case r.IsZero():
+		return ""
+	case r.From.Equal(r.To):
+		return r.From.Format(dateLayout)
+	case r.From.IsZero():
+		return ".." + r.To.Format(dateLayout)
+	case r.To.IsZero():
+		return r.From.Format(dateLayout) + ".."
+	default:
+		return r.From.Format(dateLayout) + ".." + r.To.Format(dateLayout)
+	}
+}
+
+// DescribeDateRange formats a range for display, e.g. "Jan 1 – Jan 31"
+func DescribeDateRange(r linear.DateRange) string {
+	day := func(t time.Time) string {
+		if t.Year() != time.Now().Year() {
+			return t.Format("Jan 2, 2006")
+		}
+		return t.Format("Jan 2")
+	}
+	switch {
+	case r.IsZero():
+		return ""
+	case r.From.Equal(r.To):
+		return day(r.From)
+	case r.From.IsZero():
+		return "≤ " + day(r.To)
+	case r.To.IsZero():
+		return "≥ " + day(r.From)
+	default:
+		return day(r.From) + " – " + day(r.To)
+	}
+}
+
+// startOfDay returns midnight at the start of t's day
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,571 @@
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/ui/views/issues"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Field is a dimension of the issue filter
+type Field int
+
+const (
+	FieldTeam Field = iota
+	FieldAssignee
+	FieldState
+	FieldLabel
+	FieldPriority
+	FieldProject
+	FieldCycle
+	FieldDue
+	FieldCreated
+	FieldUpdated
+	fieldCount
+)
+
+// fieldNames are the labels shown for each field
+var fieldNames = [fieldCount]string{
+	"Team", "Assignee", "State", "Label", "Priority", "Project", "Cycle", "Due", "Created", "Updated",
+}
+
+// noAssigneeID is the assignee picker item for unassigned issues
+const noAssigneeID = "none"
+
+// Options are the values the filter panel offers for each field
+type Options struct {
+	Teams    []linear.Team
+	Users    []linear.User
+	States   []linear.WorkflowState
+	Labels   []linear.Label
+	Projects []linear.Project
+	Cycles   []linear.Cycle
+}
+
+// Chip is an active filter, shown under the tab bar
+type Chip struct {
+	Field Field
+	Label string
+}
+
+// ChangedMsg is emitted whenever the filter panel changes the filter
+type ChangedMsg struct {
+	Filter linear.IssueFilter
+}
+
+// Model is the filter panel
+type Model struct {
+	filter linear.IssueFilter
+	opts   Options
+	cursor int
+	width  int
+	height int
+
+	// Editors for the field under the cursor
+	picker      *components.PickerModel
+	multiPicker *components.MultiPickerModel
+	dateInput   textinput.Model
+	editingDate bool
+	dateErr     string
+}
+
+// New creates a filter panel editing filter
+func New(filter linear.IssueFilter, opts Options, width, height int) Model {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. -7d or 2024-01-01..2024-01-31"
+	ti.CharLimit = 30
+	ti.Width = 30
+
+	return Model{
+		filter:    filter,
+		opts:      opts,
+		width:     width,
+		height:    height,
+		dateInput: ti,
+	}
+}
+
+// SetSize updates the panel dimensions
+func (m Model) SetSize(width, height int) Model {
+	m.width = width
+	m.height = height
+	return m
+}
+
+// Editing returns true while a field editor owns the keyboard
+func (m Model) Editing() bool {
+	return m.picker != nil || m.multiPicker != nil || m.editingDate
+}
+
+// IsEmpty reports whether filter has no panel fields set
+func IsEmpty(filter linear.IssueFilter) bool {
+	for field := Field(0); field < fieldCount; field++ {
+		if IsSet(filter, field) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSet reports whether a field of filter is set
+func IsSet(filter linear.IssueFilter, field Field) bool {
+	switch field {
+	case FieldTeam:
+		return filter.TeamID != ""
+	case FieldAssignee:
+		return filter.AssigneeID != "" || filter.NoAssignee
+	case FieldState:
+		return len(filter.States) > 0
+	case FieldLabel:
+		return len(filter.Labels) > 0
+	case FieldPriority:
+		return len(filter.Priorities) > 0
+	case FieldProject:
+		return filter.ProjectID != ""
+	case FieldCycle:
+		return filter.CycleID != ""
+	case FieldDue:
+		return !filter.Due.IsZero()
+	case FieldCreated:
+		return !filter.Created.IsZero()
+	case FieldUpdated:
+		return !filter.Updated.IsZero()
+	}
+	return false
+}
+
+// Clear returns filter with a field cleared
+func Clear(filter linear.IssueFilter, field Field) linear.IssueFilter {
+	switch field {
+	case FieldTeam:
+		filter.TeamID = ""
+	case FieldAssignee:
+		filter.AssigneeID = ""
+		filter.NoAssignee = false
+	case FieldState:
+		filter.States = nil
+	case FieldLabel:
+		filter.Labels = nil
+	case FieldPriority:
+		filter.Priorities = nil
+	case FieldProject:
+		filter.ProjectID = ""
+	case FieldCycle:
+		filter.CycleID = ""
+	case FieldDue:
+		filter.Due = linear.DateRange{}
+	case FieldCreated:
+		filter.Created = linear.DateRange{}
+	case FieldUpdated:
+		filter.Updated = linear.DateRange{}
+	}
+	return filter
+}
+
+// Chips returns the active filters in field order
+func Chips(filter linear.IssueFilter, opts Options) []Chip {
+	var chips []Chip
+	for field := Field(0); field < fieldCount; field++ {
+		if IsSet(filter, field) {
+			chips = append(chips, Chip{Field: field, Label: fieldNames[field] + ": " + describe(filter, field, opts)})
+		}
+	}
+	return chips
+}
+
+// RenderChips renders chips on one line
+func RenderChips(chips []Chip, width int) string {
+	parts := make([]string, len(chips))
+	for i, chip := range chips {
+		parts[i] = theme.LabelStyle.Render(chip.Label)
+	}
+	line := theme.TextDimStyle.Render("Filters ") + strings.Join(parts, " ")
+	return lipgloss.NewStyle().Width(width).MaxHeight(1).Render(" " + line)
+}
+
+// describe returns a field's value for display
+func describe(filter linear.IssueFilter, field Field, opts Options) string {
+	switch field {
+	case FieldTeam:
+		for _, t := range opts.Teams {
+			if t.ID == filter.TeamID {
+				return t.Name
+			}
+		}
+	case FieldAssignee:
+		if filter.NoAssignee {
+			return "No assignee"
+		}
+		for _, u := range opts.Users {
+			if u.ID == filter.AssigneeID {
+				return u.Name
+			}
+		}
+	case FieldState:
+		var names []string
+		for _, s := range opts.States {
+			if containsString(filter.States, s.ID) {
+				names = append(names, s.Name)
+			}
+		}
+		return joinNames(names, len(filter.States))
+	case FieldLabel:
+		var names []string
+		for _, l := range opts.Labels {
+			if containsString(filter.Labels, l.ID) {
+				names = append(names, l.Name)
+			}
+		}
+		return joinNames(names, len(filter.Labels))
+	case FieldPriority:
+		var names []string
+		for _, p := range filter.Priorities {
+			names = append(names, theme.PriorityLabel(p))
+		}
+		return strings.Join(names, ", ")
+	case FieldProject:
+		for _, p := range opts.Projects {
+			if p.ID == filter.ProjectID {
+				return p.Name
+			}
+		}
+	case FieldCycle:
+		for _, c := range opts.Cycles {
+			if c.ID == filter.CycleID {
+				return issues.CycleLabel(c)
+			}
+		}
+	case FieldDue:
+		return DescribeDateRange(filter.Due)
+	case FieldCreated:
+		return DescribeDateRange(filter.Created)
+	case FieldUpdated:
+		return DescribeDateRange(filter.Updated)
+	}
+	if IsSet(filter, field) {
+		return "(unknown)"
+	}
+	return ""
+}
+
+// joinNames lists up to two names, summarising the rest as a count
+func joinNames(names []string, total int) string {
+	if len(names) == 0 {
+		return strconv.Itoa(total) + " selected"
+	}
+	if len(names) > 2 || total > len(names) {
+		return names[0] + " +" + strconv.Itoa(total-1)
+	}
+	return strings.Join(names, ", ")
+}
+
+func containsString(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case m.picker != nil:
+		return m.updatePicker(keyMsg)
+	case m.multiPicker != nil:
+		return m.updateMultiPicker(keyMsg)
+	case m.editingDate:
+		return m.updateDateInput(keyMsg)
+	}
+
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < int(fieldCount)-1 {
+			m.cursor++
+		}
+	case "enter", " ":
+		return m.openEditor()
+	case "x", "backspace", "delete":
+		if IsSet(m.filter, Field(m.cursor)) {
+			return m.setFilter(Clear(m.filter, Field(m.cursor)))
+		}
+	case "X":
+		if !IsEmpty(m.filter) {
+			return m.setFilter(ClearAll(m.filter))
+		}
+	}
+	return m, nil
+}
+
+// ClearAll returns filter with every panel field cleared
+func ClearAll(filter linear.IssueFilter) linear.IssueFilter {
+	for field := Field(0); field < fieldCount; field++ {
+		filter = Clear(filter, field)
+	}
+	return filter
+}
+
+// setFilter replaces the filter and reports the change
+func (m Model) setFilter(filter linear.IssueFilter) (Model, tea.Cmd) {
+	m.filter = filter
+	return m, func() tea.Msg {
+		return ChangedMsg{Filter: filter}
+	}
+}
+
+// openEditor opens the editor for the field under the cursor
+func (m Model) openEditor() (Model, tea.Cmd) {
+	field := Field(m.cursor)
+	title := "Filter by " + strings.ToLower(fieldNames[field])
+
+	switch field {
+	case FieldTeam:
+		m.picker = components.NewPickerModel(title, teamItems(m.opts.Teams), m.width, m.height)
+	case FieldAssignee:
+		m.picker = components.NewPickerModel(title, assigneeItems(m.opts.Users), m.width, m.height)
+	case FieldProject:
+		m.picker = components.NewPickerModel(title, projectItems(m.opts.Projects), m.width, m.height)
+	case FieldCycle:
+		m.picker = components.NewPickerModel(title, cycleItems(m.opts.Cycles), m.width, m.height)
+	case FieldState:
+		m.multiPicker = components.NewMultiPickerModel(title, stateItems(m.opts.States), m.filter.States, m.width, m.height)
+	case FieldLabel:
+		m.multiPicker = components.NewMultiPickerModel(title, issues.LabelsToItems(m.opts.Labels), m.filter.Labels, m.width, m.height)
+	case FieldPriority:
+		var checked []string
+		for _, p := range m.filter.Priorities {
+			checked = append(checked, strconv.Itoa(p))
+		}
+		m.multiPicker = components.NewMultiPickerModel(title, priorityItems(), checked, m.width, m.height)
+	case FieldDue, FieldCreated, FieldUpdated:
+		m.editingDate = true
+		m.dateErr = ""
+		m.dateInput.SetValue(FormatDateRange(m.dateRange(field)))
+		m.dateInput.CursorEnd()
+		return m, m.dateInput.Focus()
+	}
+	return m, nil
+}
+
+// dateRange returns the range of a date field
+func (m Model) dateRange(field Field) linear.DateRange {
+	switch field {
+	case FieldDue:
+		return m.filter.Due
+	case FieldCreated:
+		return m.filter.Created
+	default:
+		return m.filter.Updated
+	}
+}
+
+// updatePicker handles the single-select picker
+func (m Model) updatePicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.picker = nil
+		return m, nil
+	case "enter":
+		item := m.picker.SelectedItem()
+		m.picker = nil
+		if item == nil {
+			return m, nil
+		}
+		filter := m.filter
+		switch Field(m.cursor) {
+		case FieldTeam:
+			filter.TeamID = item.ID
+		case FieldAssignee:
+			filter.NoAssignee = item.ID == noAssigneeID
+			filter.AssigneeID = ""
+			if !filter.NoAssignee {
+				filter.AssigneeID = item.ID
+			}
+		case FieldProject:
+			filter.ProjectID = item.ID
+		case FieldCycle:
+			filter.CycleID = item.ID
+		}
+		return m.setFilter(filter)
+	}
+
+	var cmd tea.Cmd
+	m.picker, cmd = m.picker.Update(msg)
+	return m, cmd
+}
+
+// updateMultiPicker handles the multi-select picker
+func (m Model) updateMultiPicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.multiPicker = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.multiPicker, cmd = m.multiPicker.Update(msg)
+	if !m.multiPicker.Confirmed() {
+		return m, cmd
+	}
+
+	selected := m.multiPicker.SelectedIDs()
+	m.multiPicker = nil
+	filter := m.filter
+	switch Field(m.cursor) {
+	case FieldState:
+		filter.States = selected
+	case FieldLabel:
+		filter.Labels = selected
+	case FieldPriority:
+		filter.Priorities = nil
+		for _, id := range selected {
+			p, _ := strconv.Atoi(id)
+			filter.Priorities = append(filter.Priorities, p)
+		}
+	}
+	return m.setFilter(filter)
+}
+
+// updateDateInput handles typing a date range
+func (m Model) updateDateInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editingDate = false
+		m.dateInput.Blur()
+		return m, nil
+	case "enter":
+		r, err := ParseDateRange(m.dateInput.Value(), time.Now())
+		if err != nil {
+			m.dateErr = err.Error()
+			return m, nil
+		}
+		m.editingDate = false
+		m.dateInput.Blur()
+		filter := m.filter
+		switch Field(m.cursor) {
+		case FieldDue:
+			filter.Due = r
+		case FieldCreated:
+			filter.Created = r
+		case FieldUpdated:
+			filter.Updated = r
+		}
+		return m.setFilter(filter)
+	}
+
+	var cmd tea.Cmd
+	m.dateInput, cmd = m.dateInput.Update(msg)
+	m.dateErr = ""
+	return m, cmd
+}
+
+// View renders the panel, or the open field editor
+func (m Model) View() string {
+	if m.picker != nil {
+		return m.picker.View()
+	}
+	if m.multiPicker != nil {
+		return m.multiPicker.View()
+	}
+
+	modalWidth := 56
+	var rows []string
+	for field := Field(0); field < fieldCount; field++ {
+		selected := int(field) == m.cursor
+		name := theme.SubtitleStyle.Width(10).Render(fieldNames[field])
+		value := theme.TextDimStyle.Render("Any")
+		if IsSet(m.filter, field) {
+			value = theme.TextStyle.Render(describe(m.filter, field, m.opts))
+		}
+		if selected && m.editingDate {
+			value = theme.InputFocusedStyle.Width(modalWidth - 20).Render(m.dateInput.View())
+		}
+
+		cursor := "  "
+		if selected {
+			cursor = lipgloss.NewStyle().Foreground(theme.Primary).Render("▌ ")
+		}
+		rows = append(rows, cursor+name+value)
+	}
+
+	helpText := "enter: edit  x: clear  X: clear all  esc: close"
+	if m.editingDate {
+		helpText = "enter: apply  esc: cancel\n" + DateRangeHelp
+	}
+	parts := []string{
+		theme.ModalTitleStyle.Render("Filters"),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+	}
+	if m.dateErr != "" {
+		parts = append(parts, theme.ErrorStyle.Render(m.dateErr))
+	}
+	parts = append(parts, theme.HelpStyle.Width(modalWidth-4).Render(helpText))
+
+	modal := theme.ModalStyle.Width(modalWidth).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modal)
+}
+
+func teamItems(teams []linear.Team) []components.PickerItem {
+	items := make([]components.PickerItem, len(teams))
+	for i, t := range teams {
+		items[i] = components.PickerItem{ID: t.ID, Label: t.Name, Desc: t.Key}
+	}
+	return items
+}
+
+func assigneeItems(users []linear.User) []components.PickerItem {
+	items := []components.PickerItem{{ID: noAssigneeID, Label: "No assignee", Icon: "○"}}
+	for _, u := range users {
+		items = append(items, components.PickerItem{ID: u.ID, Label: u.Name, Desc: u.Email, Icon: "👤"})
+	}
+	return items
+}
+
+func projectItems(projects []linear.Project) []components.PickerItem {
+	items := make([]components.PickerItem, len(projects))
+	for i, p := range projects {
+		items[i] = components.PickerItem{ID: p.ID, Label: p.Name, Icon: "📁"}
+	}
+	return items
+}
+
+func cycleItems(cycles []linear.Cycle) []components.PickerItem {
+	items := make([]components.PickerItem, len(cycles))
+	for i, c := range cycles {
+		items[i] = components.PickerItem{ID: c.ID, Label: issues.CycleLabel(c), Icon: "🔄"}
+	}
+	return items
+}
+
+func stateItems(states []linear.WorkflowState) []components.PickerItem {
+	items := make([]components.PickerItem, len(states))
+	for i, s := range states {
+		items[i] = components.PickerItem{ID: s.ID, Label: s.Name, Icon: theme.StatusIcon(s.Type)}
+	}
+	return items
+}
+
+func priorityItems() []components.PickerItem {
+	items := make([]components.PickerItem, 5)
+	for p := 0; p <= 4; p++ {
+		items[p] = components.PickerItem{ID: strconv.Itoa(p), Label: theme.PriorityLabel(p), Icon: theme.PriorityIcon(p)}
+	}
+	return items
+}
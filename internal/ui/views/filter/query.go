@@ -0,0 +1,327 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// QueryHelp summarises the search query syntax
+const QueryHelp = `assignee:me state:started label:bug priority:<=2 project:"Auth" updated:<7d "free text"`
+
+// queryFields are the fields a search query can filter on, in completion order
+var queryFields = []string{"assignee", "state", "label", "priority", "project", "team", "cycle", "due", "created", "updated"}
+
+// stateTypes are the workflow state types a state term can name
+var stateTypes = []string{"triage", "backlog", "unstarted", "started", "completed", "canceled"}
+
+// Query is a parsed search query
+type Query struct {
+	Filter     linear.IssueFilter
+	Text       string       // Free text, matched against issue titles
+	Structured bool         // Whether the query has field terms
+	Errors     []QueryError // Problems with individual terms
+	terms      []queryTerm
+}
+
+// QueryError is a problem with a term of a search query
+type QueryError struct {
+	Start, End int // Byte offsets of the term in the query
+	Msg        string
+}
+
+// queryTerm is a whitespace-separated term of a query: field:value or text
+type queryTerm struct {
+	start, end int
+	field      string // Empty for free text
+	value      string // Unquoted
+}
+
+// ParseQuery parses a search query such as
+//
+//	assignee:me state:started label:bug priority:<=2 project:"Auth" updated:<7d "free text"
+//
+// Field values are checked against opts where it has values to check
+// against, and compiled into a filter that matches by name. Terms for the
+// same field match any of their values.
+func ParseQuery(s string, opts Options, now time.Time) Query {
+	var q Query
+	var text []string
+	q.terms = splitQuery(s)
+
+	for _, t := range q.terms {
+		if t.field == "" {
+			if t.value != "" {
+				text = append(text, t.value)
+			}
+			continue
+		}
+		q.Structured = true
+		if err := q.apply(t, opts, now); err != "" {
+			q.Errors = append(q.Errors, QueryError{Start: t.start, End: t.end, Msg: err})
+		}
+	}
+
+	q.Text = strings.Join(text, " ")
+	if q.Structured {
+		q.Filter.Query = q.Text
+	}
+	return q
+}
+
+// Err returns the first error in the query, or nil
+func (q Query) Err() error {
+	if len(q.Errors) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", q.Errors[0].Msg)
+}
+
+// apply adds a field term to the filter, returning a message if it's invalid
+func (q *Query) apply(t queryTerm, opts Options, now time.Time) string {
+	f := &q.Filter
+	if t.value == "" {
+		return t.field + ": missing value"
+	}
+
+	switch t.field {
+	case "assignee":
+		if f.AssigneeName != "" || f.NoAssignee {
+			return "only one assignee can be given"
+		}
+		switch strings.ToLower(t.value) {
+		case "me":
+			f.AssigneeName = "me"
+		case "none":
+			f.NoAssignee = true
+		default:
+			if len(opts.Users) > 0 && !matchesAny(t.value, userNames(opts.Users)) {
+				return "unknown user " + strconv.Quote(t.value)
+			}
+			f.AssigneeName = t.value
+		}
+
+	case "state":
+		if len(opts.States) > 0 && !matchesAny(t.value, append(stateNames(opts.States), stateTypes...)) {
+			return "unknown state " + strconv.Quote(t.value)
+		}
+		f.StateNames = append(f.StateNames, t.value)
+
+	case "label":
+		if len(opts.Labels) > 0 && !matchesAny(t.value, labelNames(opts.Labels)) {
+			return "unknown label " + strconv.Quote(t.value)
+		}
+		f.LabelNames = append(f.LabelNames, t.value)
+
+	case "priority":
+		priorities, err := parsePriority(t.value)
+		if err != "" {
+			return err
+		}
+		f.Priorities = append(f.Priorities, priorities...)
+
+	case "project":
+		if f.ProjectName != "" {
+			return "only one project can be given"
+		}
+		if len(opts.Projects) > 0 && !matchesAny(t.value, projectNames(opts.Projects)) {
+			return "unknown project " + strconv.Quote(t.value)
+		}
+		f.ProjectName = t.value
+
+	case "team":
+		if f.TeamKey != "" {
+			return "only one team can be given"
+		}
+		if len(opts.Teams) > 0 && !matchesAny(t.value, teamNames(opts.Teams)) {
+			return "unknown team " + strconv.Quote(t.value)
+		}
+		f.TeamKey = t.value
+
+	case "cycle":
+		if f.CycleName != "" {
+			return "only one cycle can be given"
+		}
+		if _, err := strconv.Atoi(t.value); err != nil && len(opts.Cycles) > 0 &&
+			!matchesAny(t.value, append(cycleNames(opts.Cycles), "current", "active", "next", "previous")) {
+			return "unknown cycle " + strconv.Quote(t.value)
+		}
+		f.CycleName = t.value
+
+	case "due", "created", "updated":
+		r, err := parseQueryDate(t.value, t.field == "due", now)
+		if err != nil {
+			return t.field + ": " + err.Error()
+		}
+		switch t.field {
+		case "due":
+			f.Due = r
+		case "created":
+			f.Created = r
+		default:
+			f.Updated = r
+		}
+
+	default:
+		return "unknown field " + strconv.Quote(t.field) + " (" + strings.Join(queryFields, ", ") + ")"
+	}
+	return ""
+}
+
+// parsePriority parses a priority term: a number or name, optionally after
+// <, <=, > or >=. Comparisons are by number, so <=2 means urgent or high,
+// and leave out "no priority".
+func parsePriority(value string) ([]int, string) {
+	op := ""
+	for _, prefix := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, prefix) {
+			op, value = prefix, value[len(prefix):]
+			break
+		}
+	}
+
+	n, err := linear.ParsePriority(value)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	if op == "" || op == "=" {
+		return []int{n}, ""
+	}
+	var priorities []int
+	for p := 1; p <= 4; p++ {
+		if (op == "<" && p < n) || (op == "<=" && p <= n) || (op == ">" && p > n) || (op == ">=" && p >= n) {
+			priorities = append(priorities, p)
+		}
+	}
+	if len(priorities) == 0 {
+		return nil, "no priority is " + op + value
+	}
+	return priorities, ""
+}
+
+// parseQueryDate parses a date term. Besides the ranges ParseDateRange
+// accepts, <7d means within the last 7 days and >7d more than 7 days ago;
+// for due dates they look ahead instead (due within or after 7 days).
+func parseQueryDate(value string, future bool, now time.Time) (linear.DateRange, error) {
+	if len(value) < 3 || (value[0] != '<' && value[0] != '>') {
+		return ParseDateRange(value, now)
+	}
+
+	sign := "-"
+	if future {
+		sign = "+"
+	}
+	r, err := ParseDateRange(sign+value[1:], now)
+	if err != nil {
+		return linear.DateRange{}, err
+	}
+
+	// r spans today and the boundary day; keep the side asked for
+	switch {
+	case value[0] == '<' && future:
+		return linear.DateRange{To: r.To}, nil
+	case value[0] == '<':
+		return linear.DateRange{From: r.From}, nil
+	case future:
+		return linear.DateRange{From: r.To.AddDate(0, 0, 1)}, nil
+	default:
+		return linear.DateRange{To: r.From.AddDate(0, 0, -1)}, nil
+	}
+}
+
+// splitQuery splits a query into terms at whitespace outside quotes
+func splitQuery(s string) []queryTerm {
+	var terms []queryTerm
+	i := 0
+	for i < len(s) {
+		if s[i] == ' ' || s[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		inQuote := false
+		for i < len(s) && (inQuote || (s[i] != ' ' && s[i] != '\t')) {
+			if s[i] == '"' {
+				inQuote = !inQuote
+			}
+			i++
+		}
+		terms = append(terms, parseTerm(s[start:i], start))
+	}
+	return terms
+}
+
+// parseTerm parses a single term starting at offset start in the query
+func parseTerm(raw string, start int) queryTerm {
+	t := queryTerm{start: start, end: start + len(raw)}
+	if colon := strings.IndexByte(raw, ':'); colon > 0 && !strings.Contains(raw[:colon], `"`) {
+		t.field = strings.ToLower(raw[:colon])
+		raw = raw[colon+1:]
+	}
+	t.value = strings.ReplaceAll(raw, `"`, "")
+	return t
+}
+
+// matchesAny reports whether value equals one of names, ignoring case
+func matchesAny(value string, names []string) bool {
+	for _, name := range names {
+		if strings.EqualFold(value, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func userNames(users []linear.User) []string {
+	var names []string
+	for _, u := range users {
+		names = append(names, u.Name, u.DisplayName, u.Email)
+	}
+	return names
+}
+
+func stateNames(states []linear.WorkflowState) []string {
+	var names []string
+	for _, s := range states {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func labelNames(labels []linear.Label) []string {
+	var names []string
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func projectNames(projects []linear.Project) []string {
+	var names []string
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func teamNames(teams []linear.Team) []string {
+	var names []string
+	for _, t := range teams {
+		names = append(names, t.Key, t.Name)
+	}
+	return names
+}
+
+func cycleNames(cycles []linear.Cycle) []string {
+	var names []string
+	for _, c := range cycles {
+		if c.Name != "" {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,153 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+var (
+	now = time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	testOptions = Options{
+		Teams:    []linear.Team{{ID: "t1", Key: "ENG", Name: "Engineering"}},
+		Users:    []linear.User{{ID: "u1", Name: "Jane Doe", DisplayName: "jane", Email: "jane@example.com"}},
+		States:   []linear.WorkflowState{{ID: "s1", Name: "In Review", Type: "started"}},
+		Labels:   []linear.Label{{ID: "l1", Name: "bug"}, {ID: "l2", Name: "regression"}},
+		Projects: []linear.Project{{ID: "p1", Name: "Auth revamp"}},
+	}
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query      string
+		want       linear.IssueFilter
+		text       string
+		structured bool
+	}{
+		{
+			query: "fix login",
+			text:  "fix login",
+		},
+		{
+			query:      "assignee:me state:started label:bug",
+			want:       linear.IssueFilter{AssigneeName: "me", StateNames: []string{"started"}, LabelNames: []string{"bug"}},
+			structured: true,
+		},
+		{
+			query:      `project:"Auth revamp" fix "login redirect"`,
+			want:       linear.IssueFilter{ProjectName: "Auth revamp", Query: "fix login redirect"},
+			text:       "fix login redirect",
+			structured: true,
+		},
+		{
+			query:      "Assignee:JANE@example.com",
+			want:       linear.IssueFilter{AssigneeName: "JANE@example.com"},
+			structured: true,
+		},
+		{
+			query:      `state:"in review" label:bug label:Regression`,
+			want:       linear.IssueFilter{StateNames: []string{"in review"}, LabelNames: []string{"bug", "Regression"}},
+			structured: true,
+		},
+		{
+			query:      "assignee:none team:eng cycle:current",
+			want:       linear.IssueFilter{NoAssignee: true, TeamKey: "eng", CycleName: "current"},
+			structured: true,
+		},
+		{
+			query:      "priority:high",
+			want:       linear.IssueFilter{Priorities: []int{2}},
+			structured: true,
+		},
+		{
+			query:      "priority:<=2 priority:none",
+			want:       linear.IssueFilter{Priorities: []int{1, 2, 0}},
+			structured: true,
+		},
+		{
+			query:      "priority:>medium",
+			want:       linear.IssueFilter{Priorities: []int{4}},
+			structured: true,
+		},
+		{
+			query:      "updated:<7d created:2024-01-01..2024-01-31",
+			want:       linear.IssueFilter{Updated: linear.DateRange{From: day(2024, 3, 8)}, Created: linear.DateRange{From: day(2024, 1, 1), To: day(2024, 1, 31)}},
+			structured: true,
+		},
+		{
+			query:      "due:<7d",
+			want:       linear.IssueFilter{Due: linear.DateRange{To: day(2024, 3, 22)}},
+			structured: true,
+		},
+		{
+			query:      "due:>1w",
+			want:       linear.IssueFilter{Due: linear.DateRange{From: day(2024, 3, 23)}},
+			structured: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q := ParseQuery(tt.query, testOptions, now)
+			if len(q.Errors) > 0 {
+				t.Fatalf("unexpected errors: %+v", q.Errors)
+			}
+			if q.Structured != tt.structured {
+				t.Errorf("structured = %v, want %v", q.Structured, tt.structured)
+			}
+			if q.Text != tt.text {
+				t.Errorf("text = %q, want %q", q.Text, tt.text)
+			}
+			if !reflect.DeepEqual(q.Filter, tt.want) {
+				t.Errorf("filter = %+v\nwant     %+v", q.Filter, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		term  string // The part of the query the error points at
+		msg   string
+	}{
+		{"colour:red", "colour:red", "unknown field"},
+		{"state:", "state:", "missing value"},
+		{"state:in label:bug", "state:in", `unknown state "in"`},
+		{"fix label:nope", "label:nope", `unknown label "nope"`},
+		{"assignee:bob", "assignee:bob", `unknown user "bob"`},
+		{"assignee:me assignee:jane", "assignee:jane", "only one assignee"},
+		{"project:Auth project:Billing", "project:Auth", `unknown project "Auth"`},
+		{"team:OPS", "team:OPS", `unknown team "OPS"`},
+		{"priority:5", "priority:5", "invalid priority"},
+		{"priority:<urgent", "priority:<urgent", "no priority is <urgent"},
+		{"due:soon", "due:soon", "due:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q := ParseQuery(tt.query, testOptions, now)
+			if len(q.Errors) == 0 {
+				t.Fatalf("no error, filter %+v", q.Filter)
+			}
+			e := q.Errors[0]
+			if got := tt.query[e.Start:e.End]; got != tt.term {
+				t.Errorf("error points at %q, want %q", got, tt.term)
+			}
+			if !strings.Contains(e.Msg, tt.msg) {
+				t.Errorf("error %q, want it to contain %q", e.Msg, tt.msg)
+			}
+			if q.Err() == nil {
+				t.Error("Err() = nil")
+			}
+		})
+	}
+}
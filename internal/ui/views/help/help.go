@@ -34,6 +34,7 @@ func (m Model) View() string {
 				{"G / End", "Go to bottom"},
 				{"Ctrl+d", "Page down"},
 				{"Ctrl+u", "Page up"},
+				{"z / Z", "Fold group / all groups"},
 			},
 		},
 		{
@@ -45,6 +46,7 @@ func (m Model) View() string {
 				{"2", "All Issues"},
 				{"3", "Active"},
 				{"4", "Backlog"},
+				{"5", "Cycle"},
 			},
 		},
 		{
@@ -58,6 +60,18 @@ func (m Model) View() string {
 				{"q", "Quit"},
 			},
 		},
+		{
+			title: "Bulk Selection",
+			keys: [][]string{
+				{"Space", "Toggle selection"},
+				{"v", "Select range"},
+				{"Ctrl+a", "Select all"},
+				{"s/a/p/l", "Batch status/assignee/priority/label"},
+				{"m / Y", "Batch project / cycle"},
+				{"d d", "Batch delete"},
+				{"Esc", "Clear selection"},
+			},
+		},
 		{
 			title: "Issue Actions",
 			keys: [][]string{
@@ -65,8 +79,19 @@ func (m Model) View() string {
 				{"a", "Change assignee"},
 				{"p", "Change priority"},
 				{"d", "Delete issue"},
+				{"l", "Edit labels (t on board)"},
+				{"Y", "Move to cycle"},
+				{"C", "Comment on issue"},
+				{"{ / }", "Select relation/sub-issue"},
+				{"R", "Add / remove relations"},
+				{"u / S", "Go to parent / new sub-issue"},
+				{"F/U", "Force / discard offline edits"},
+				{"f / F", "Filter panel / clear filters"},
+				{"V", "Views (saved and Linear)"},
+				{"O", "Sort, group & columns"},
 				{"P", "Filter by project"},
 				{"y", "Copy branch name"},
+				{"B", "Check out issue branch"},
 				{"o", "Open in browser"},
 			},
 		},
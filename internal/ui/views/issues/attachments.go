@@ -0,0 +1,95 @@
+package issues
+
+import (
+	"fmt"
+
+	"github.com/brandonli/lazyliner/internal/git"
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetAttachments sets the pull requests, commits and links attached to the issue
+func (m DetailModel) SetAttachments(attachments []linear.Attachment) DetailModel {
+	m.attachments = attachments
+	return m
+}
+
+// SetCommits sets the local commits that mention the issue
+func (m DetailModel) SetCommits(commits []git.Commit) DetailModel {
+	m.commits = commits
+	return m
+}
+
+// attachmentIcon returns the icon for an attachment kind
+func attachmentIcon(kind linear.AttachmentKind) string {
+	switch kind {
+	case linear.AttachmentPullRequest:
+		return "⇄"
+	case linear.AttachmentCommit:
+		return "●"
+	default:
+		return "↗"
+	}
+}
+
+// attachmentStatusStyle returns the badge style for a pull request status
+func attachmentStatusStyle(status string) lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	switch status {
+	case "open", "opened":
+		return style.Foreground(theme.Success)
+	case "merged":
+		return style.Foreground(theme.Primary)
+	case "closed":
+		return style.Foreground(theme.Danger)
+	default:
+		return style.Foreground(theme.TextMuted)
+	}
+}
+
+// renderAttachments renders the pull requests and links section
+func (m DetailModel) renderAttachments() string {
+	if len(m.attachments) == 0 {
+		return ""
+	}
+
+	heading := theme.SubtitleStyle.Bold(true).Render(fmt.Sprintf("Pull requests & links (%d)", len(m.attachments)))
+	lines := []string{heading}
+	for _, a := range m.attachments {
+		title := a.Title
+		if title == "" {
+			title = a.URL
+		}
+		line := theme.TextMutedStyle.Render(attachmentIcon(a.Kind())+" ") + theme.TextStyle.Render(title)
+		if status := a.Status(); status != "" {
+			line += " " + attachmentStatusStyle(status).Render(status)
+		}
+		lines = append(lines, line)
+
+		detail := a.URL
+		if a.Subtitle != "" {
+			detail = a.Subtitle + " · " + a.URL
+		}
+		lines = append(lines, theme.TextDimStyle.Render("  "+util.Truncate(detail, m.width-10)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderCommits renders the local commits that mention the issue
+func (m DetailModel) renderCommits() string {
+	if len(m.commits) == 0 {
+		return ""
+	}
+
+	heading := theme.SubtitleStyle.Bold(true).Render(fmt.Sprintf("Local commits (%d)", len(m.commits)))
+	lines := []string{heading}
+	for _, c := range m.commits {
+		hash := theme.IssueIDStyle.Render(c.ShortHash)
+		age := theme.TextMutedStyle.Render(" · " + formatRelativeTime(c.Date))
+		subject := util.Truncate(c.Subject, m.width-lipgloss.Width(hash)-lipgloss.Width(age)-10)
+		lines = append(lines, hash+" "+theme.TextStyle.Render(subject)+age)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,265 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/markdown"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/util"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column is a column of the issue list
+type Column string
+
+const (
+	ColumnIdentifier Column = "identifier"
+	ColumnTitle      Column = "title"
+	ColumnState      Column = "state"
+	ColumnPriority   Column = "priority"
+	ColumnAssignee   Column = "assignee"
+	ColumnLabels     Column = "labels"
+	ColumnProject    Column = "project"
+	ColumnCycle      Column = "cycle"
+	ColumnEstimate   Column = "estimate"
+	ColumnDue        Column = "due"
+	ColumnUpdated    Column = "updated"
+)
+
+// Columns lists every column in menu order
+var Columns = []Column{
+	ColumnIdentifier, ColumnTitle, ColumnState, ColumnPriority, ColumnAssignee, ColumnLabels,
+	ColumnProject, ColumnCycle, ColumnEstimate, ColumnDue, ColumnUpdated,
+}
+
+// minTitleWidth is the narrowest the title gets before columns are dropped,
+// unless the title column sets its own width
+const minTitleWidth = 20
+
+// ParseColumn parses a column name
+func ParseColumn(s string) (Column, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "id" {
+		return ColumnIdentifier, true
+	}
+	for _, c := range Columns {
+		if string(c) == s {
+			return c, true
+		}
+	}
+	return "", false
+}
+
+// Label returns the column's name as shown in menus
+func (c Column) Label() string {
+	if c == ColumnDue {
+		return "Due date"
+	}
+	return strings.ToUpper(string(c[:1])) + string(c[1:])
+}
+
+// DefaultWidth returns the column's width when the config doesn't set one.
+// The title takes whatever space the other columns leave.
+func (c Column) DefaultWidth() int {
+	switch c {
+	case ColumnIdentifier, ColumnPriority, ColumnDue:
+		return 10
+	case ColumnState, ColumnAssignee, ColumnLabels, ColumnProject:
+		return 15
+	case ColumnCycle:
+		return 12
+	case ColumnEstimate:
+		return 4
+	case ColumnUpdated:
+		return 8
+	}
+	return 0
+}
+
+// ColumnSpec is a column as laid out in the list
+type ColumnSpec struct {
+	Column    Column
+	Width     int // 0 for the column's default width; the title's minimum width
+	HideBelow int // Hide the column when the list is narrower than this
+}
+
+// DefaultColumns are the columns shown when the config doesn't list any
+func DefaultColumns() []ColumnSpec {
+	return []ColumnSpec{
+		{Column: ColumnIdentifier},
+		{Column: ColumnTitle},
+		{Column: ColumnPriority},
+		{Column: ColumnState},
+	}
+}
+
+// layoutColumns fits columns into width. Columns whose HideBelow is over
+// width are hidden; if the title is still squeezed below its minimum width,
+// columns are dropped from the right until it fits. The title gets the space
+// left over.
+func layoutColumns(columns []ColumnSpec, width int) []ColumnSpec {
+	var visible []ColumnSpec
+	minTitle := minTitleWidth
+	for _, col := range columns {
+		if col.HideBelow > 0 && width < col.HideBelow {
+			continue
+		}
+		switch {
+		case col.Column == ColumnTitle && col.Width > 0:
+			minTitle = col.Width
+		case col.Width <= 0:
+			col.Width = col.Column.DefaultWidth()
+		}
+		visible = append(visible, col)
+	}
+
+	for {
+		// Two cells of row padding and two for the cursor, then a gap of two
+		// before every column but the first
+		used := 4 + 2*max(len(visible)-1, 0)
+		title := -1
+		for i, col := range visible {
+			if col.Column == ColumnTitle {
+				title = i
+				continue
+			}
+			used += col.Width
+		}
+		if title < 0 {
+			return visible
+		}
+		visible[title].Width = width - used
+		if visible[title].Width >= minTitle || len(visible) == 1 {
+			visible[title].Width = max(visible[title].Width, minTitle)
+			return visible
+		}
+
+		// Drop the rightmost column that isn't the title
+		drop := len(visible) - 1
+		if drop == title {
+			drop--
+		}
+		visible = append(visible[:drop], visible[drop+1:]...)
+	}
+}
+
+// renderCell renders an issue's value for a column, width cells wide
+func (m ListModel) renderCell(issue linear.Issue, col ColumnSpec, isSelected bool) string {
+	width := col.Width
+	dim := func(s string) string {
+		return theme.TextDimStyle.Render(util.Truncate(s, width))
+	}
+
+	switch col.Column {
+	case ColumnIdentifier:
+		return theme.IssueIDStyle.Render(util.Truncate(issue.Identifier, width))
+
+	case ColumnTitle:
+		// Prefixed with markers for unsynced offline edits and blockers, and
+		// followed by checklist progress
+		marker := syncMarker(m.syncStates[issue.ID]) + blockedMarker(issue)
+		progress := markdown.ProgressLabel(issue.Description)
+		if progress != "" {
+			progress = " " + progress
+		}
+		title := util.Truncate(issue.Title, width-lipgloss.Width(marker)-lipgloss.Width(progress))
+		if isSelected {
+			title = lipgloss.NewStyle().Foreground(theme.TextBright).Render(title)
+		} else {
+			title = lipgloss.NewStyle().Foreground(theme.Text).Render(title)
+		}
+		return marker + title + progress
+
+	case ColumnPriority:
+		return lipgloss.NewStyle().
+			Foreground(theme.PriorityColor(issue.Priority)).
+			Render(util.Truncate(theme.PriorityIcon(issue.Priority)+" "+theme.PriorityLabel(issue.Priority), width))
+
+	case ColumnState:
+		statusName, statusType := "Unknown", ""
+		if issue.State != nil {
+			statusName, statusType = issue.State.Name, issue.State.Type
+		}
+		return theme.StatusStyle(statusType).
+			Render(theme.StatusIcon(statusType) + " " + util.Truncate(statusName, width-3))
+
+	case ColumnAssignee:
+		if issue.Assignee == nil {
+			return dim("-")
+		}
+		name := issue.Assignee.DisplayName
+		if name == "" {
+			name = issue.Assignee.Name
+		}
+		return util.Truncate(name, width)
+
+	case ColumnLabels:
+		if len(issue.Labels) == 0 {
+			return dim("-")
+		}
+		var names []string
+		for _, label := range issue.Labels {
+			names = append(names, label.Name)
+		}
+		first := issue.Labels[0]
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(first.Color))
+		return style.Render("●") + " " + util.Truncate(strings.Join(names, ", "), width-2)
+
+	case ColumnProject:
+		if issue.Project == nil {
+			return dim("-")
+		}
+		return util.Truncate(issue.Project.Name, width)
+
+	case ColumnCycle:
+		if issue.Cycle == nil {
+			return dim("-")
+		}
+		return util.Truncate(CycleLabel(*issue.Cycle), width)
+
+	case ColumnEstimate:
+		if issue.Estimate == nil {
+			return dim("-")
+		}
+		return util.Truncate(fmt.Sprintf("%d", *issue.Estimate), width)
+
+	case ColumnDue:
+		if issue.DueDate == nil || *issue.DueDate == "" {
+			return dim("-")
+		}
+		label := *issue.DueDate
+		if due, err := time.Parse("2006-01-02", label); err == nil {
+			label = due.Format("Jan 2")
+		}
+		label = util.Truncate(label, width)
+		// Dates are ISO, so they compare as strings
+		if !issue.IsFinished() && *issue.DueDate < time.Now().Format("2006-01-02") {
+			return theme.ErrorStyle.Render(label)
+		}
+		return label
+
+	case ColumnUpdated:
+		return dim(shortRelativeTime(issue.UpdatedAt))
+	}
+	return ""
+}
+
+// shortRelativeTime formats a past time compactly, e.g. "5m", "3h", "2d"
+func shortRelativeTime(t time.Time) string {
+	diff := time.Since(t)
+	switch {
+	case diff < time.Minute:
+		return "now"
+	case diff < time.Hour:
+		return fmt.Sprintf("%dm", int(diff.Minutes()))
+	case diff < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(diff.Hours()))
+	case diff < 30*24*time.Hour:
+		return fmt.Sprintf("%dd", int(diff.Hours()/24))
+	default:
+		return t.Format("Jan 2")
+	}
+}
@@ -1,11 +1,15 @@
 package issues
 
 import (
+	"strconv"
 	"strings"
 
+	"github.com/brandonli/lazyliner/internal/ai"
+	"github.com/brandonli/lazyliner/internal/editor"
 	"github.com/brandonli/lazyliner/internal/linear"
 	"github.com/brandonli/lazyliner/internal/ui/components"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/util"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -24,12 +28,26 @@ type CreateModel struct {
 	states   []linear.WorkflowState
 	users    []linear.User
 	labels   []linear.Label
+	cycles   []linear.Cycle
 
 	// Selected values
 	selectedTeam     int
 	selectedProject  int
 	selectedPriority int
 	selectedAssignee int
+	selectedCycle    int
+	selectedLabels   []string
+
+	// Parent issue when creating a sub-issue
+	parent *linear.Issue
+
+	// AI draft state
+	aiInput      textinput.Model
+	aiMode       bool
+	aiGenerating bool
+
+	// Validation errors from the API, keyed by field index
+	fieldErrors map[int]string
 
 	// UI state
 	focusIndex   int
@@ -38,8 +56,9 @@ type CreateModel struct {
 	height       int
 
 	// Picker state
-	picker     *components.PickerModel
-	pickerType string // "team", "project", "priority", "assignee"
+	picker      *components.PickerModel
+	pickerType  string // "team", "project", "priority", "assignee", "cycle"
+	labelPicker *components.MultiPickerModel
 }
 
 // Field indices
@@ -50,11 +69,25 @@ const (
 	fieldProject
 	fieldPriority
 	fieldAssignee
+	fieldCycle
+	fieldLabels
 	fieldCount
 )
 
+// createInputFields maps IssueCreateInput field names to form fields
+var createInputFields = map[string]int{
+	"title":       fieldTitle,
+	"description": fieldDescription,
+	"teamId":      fieldTeam,
+	"projectId":   fieldProject,
+	"priority":    fieldPriority,
+	"assigneeId":  fieldAssignee,
+	"cycleId":     fieldCycle,
+	"labelIds":    fieldLabels,
+}
+
 // NewCreateModel creates a new create model
-func NewCreateModel(teams []linear.Team, projects []linear.Project, states []linear.WorkflowState, users []linear.User, labels []linear.Label, width, height int) CreateModel {
+func NewCreateModel(teams []linear.Team, projects []linear.Project, states []linear.WorkflowState, users []linear.User, labels []linear.Label, cycles []linear.Cycle, width, height int) CreateModel {
 	// Title input
 	ti := textinput.New()
 	ti.Placeholder = "Issue title"
@@ -69,18 +102,27 @@ func NewCreateModel(teams []linear.Team, projects []linear.Project, states []lin
 	ta.SetWidth(width - 20)
 	ta.SetHeight(6)
 
+	// AI prompt input
+	ap := textinput.New()
+	ap.Placeholder = "Describe the issue in one line..."
+	ap.CharLimit = 500
+	ap.Width = width - 20
+
 	return CreateModel{
 		titleInput:       ti,
 		descInput:        ta,
+		aiInput:          ap,
 		teams:            teams,
 		projects:         projects,
 		states:           states,
 		users:            users,
 		labels:           labels,
+		cycles:           cycles,
 		selectedTeam:     0,
 		selectedProject:  -1, // No project by default
 		selectedPriority: 0,  // No priority by default
 		selectedAssignee: -1, // Unassigned by default
+		selectedCycle:    -1, // No cycle by default
 		focusIndex:       fieldTitle,
 		width:            width,
 		height:           height,
@@ -98,9 +140,191 @@ func (m CreateModel) SetSize(width, height int) CreateModel {
 	if m.descInput.Placeholder != "" {
 		m.descInput.SetWidth(width - 20)
 	}
+	if m.aiInput.Placeholder != "" {
+		m.aiInput.Width = width - 20
+	}
+	return m
+}
+
+// GenerateDraftMsg is emitted when the user submits an AI draft prompt
+type GenerateDraftMsg struct {
+	Prompt string
+}
+
+// CancelDraftMsg is emitted when the user stops a draft that is being generated
+type CancelDraftMsg struct{}
+
+// StartAIDraft opens the AI prompt bar above the form
+func (m CreateModel) StartAIDraft() CreateModel {
+	m.aiMode = true
+	m.titleInput.Blur()
+	m.descInput.Blur()
+	m.aiInput.Focus()
+	return m
+}
+
+// InAIMode returns true while the AI prompt bar has focus
+func (m CreateModel) InAIMode() bool {
+	return m.aiMode
+}
+
+// SetParent makes the form create a sub-issue of parent, pre-filling its
+// team and project
+func (m CreateModel) SetParent(parent *linear.Issue) CreateModel {
+	m.parent = parent
+	if parent.Team != nil {
+		for i, team := range m.teams {
+			if team.ID == parent.Team.ID {
+				m.selectedTeam = i
+				break
+			}
+		}
+	}
+	if parent.Project != nil {
+		for i, project := range m.projects {
+			if project.ID == parent.Project.ID {
+				m.selectedProject = i
+				break
+			}
+		}
+	}
+	return m
+}
+
+// Parent returns the parent issue when creating a sub-issue, or nil
+func (m CreateModel) Parent() *linear.Issue {
+	return m.parent
+}
+
+// ApplyDraft pre-fills the form from AI-generated content so it can be reviewed
+func (m CreateModel) ApplyDraft(draft *ai.GenerateIssueOutput) CreateModel {
+	m.aiGenerating = false
+	m.aiMode = false
+	m.aiInput.Blur()
+
+	if draft == nil {
+		m.updateFocus()
+		return m
+	}
+
+	if draft.Title != "" {
+		m.titleInput.SetValue(draft.Title)
+	}
+	if draft.Description != "" {
+		m.descInput.SetValue(draft.Description)
+	}
+	if draft.SuggestedPriority >= 0 && draft.SuggestedPriority <= 4 {
+		m.selectedPriority = draft.SuggestedPriority
+	}
+
+	// Map suggested label names back to label IDs, ignoring unknown names
+	m.selectedLabels = nil
+	for _, name := range draft.SuggestedLabels {
+		for _, label := range m.labels {
+			if strings.EqualFold(label.Name, strings.TrimSpace(name)) {
+				m.selectedLabels = append(m.selectedLabels, label.ID)
+				break
+			}
+		}
+	}
+
+	m.focusIndex = fieldTitle
+	m.updateFocus()
+	return m
+}
+
+// EditorDraft returns the form as a markdown file for editing in $EDITOR
+func (m CreateModel) EditorDraft() string {
+	var assignee *linear.User
+	if m.selectedAssignee >= 0 && m.selectedAssignee < len(m.users) {
+		assignee = &m.users[m.selectedAssignee]
+	}
+	var project *linear.Project
+	if m.selectedProject >= 0 && m.selectedProject < len(m.projects) {
+		project = &m.projects[m.selectedProject]
+	}
+	fields := draftFields(m.titleInput.Value(), m.selectedPriority, assignee, project, m.labels, m.selectedLabels)
+	if m.selectedTeam >= 0 && m.selectedTeam < len(m.teams) {
+		// New issues can still change team
+		team := editor.Field{Key: "team", Value: m.teams[m.selectedTeam].Key}
+		fields = append(fields[:1], append([]editor.Field{team}, fields[1:]...)...)
+	}
+	return editor.Format(fields, m.descInput.Value())
+}
+
+// ApplyEditorDraft fills the form from a draft saved in $EDITOR. Values that
+// don't resolve, such as unknown labels, are shown as field errors and leave
+// their field as it was.
+func (m CreateModel) ApplyEditorDraft(text string) CreateModel {
+	d := parseDraft(text, m.users, m.projects, m.labels)
+	m.descInput.SetValue(d.description)
+	if d.title != nil {
+		m.titleInput.SetValue(*d.title)
+	}
+	if d.team != nil {
+		d.errors["teamId"] = "unknown team " + strconv.Quote(*d.team)
+		for i, team := range m.teams {
+			if strings.EqualFold(team.Key, *d.team) || strings.EqualFold(team.Name, *d.team) {
+				m.selectedTeam = i
+				delete(d.errors, "teamId")
+				break
+			}
+		}
+	}
+	if d.priority != nil {
+		m.selectedPriority = *d.priority
+	}
+	if d.assignee != nil {
+		m.selectedAssignee = *d.assignee
+	}
+	if d.project != nil {
+		m.selectedProject = *d.project
+	}
+	if d.labelIDs != nil {
+		m.selectedLabels = *d.labelIDs
+	}
+	return m.SetFieldErrors(d.errors)
+}
+
+// DraftFailed clears the generating state while keeping the prompt and form intact
+func (m CreateModel) DraftFailed() CreateModel {
+	m.aiGenerating = false
 	return m
 }
 
+// updateAIMode handles keys while the AI prompt bar has focus
+func (m CreateModel) updateAIMode(msg tea.KeyMsg) (CreateModel, tea.Cmd) {
+	if m.aiGenerating {
+		// Esc stops the request and keeps the prompt for another try
+		if msg.String() == "esc" {
+			m.aiGenerating = false
+			return m, func() tea.Msg { return CancelDraftMsg{} }
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.aiMode = false
+		m.aiInput.Blur()
+		m.updateFocus()
+		return m, nil
+	case "enter":
+		prompt := strings.TrimSpace(m.aiInput.Value())
+		if prompt == "" {
+			return m, nil
+		}
+		m.aiGenerating = true
+		return m, func() tea.Msg {
+			return GenerateDraftMsg{Prompt: prompt}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.aiInput, cmd = m.aiInput.Update(msg)
+	return m, cmd
+}
+
 // Update handles messages
 func (m CreateModel) Update(msg tea.Msg) (CreateModel, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -111,6 +335,13 @@ func (m CreateModel) Update(msg tea.Msg) (CreateModel, tea.Cmd) {
 		if m.picker != nil {
 			return m.updatePicker(msg)
 		}
+		if m.labelPicker != nil {
+			return m.updateLabelPicker(msg)
+		}
+
+		if m.aiMode {
+			return m.updateAIMode(msg)
+		}
 
 		switch msg.String() {
 		case "tab", "down":
@@ -127,6 +358,9 @@ func (m CreateModel) Update(msg tea.Msg) (CreateModel, tea.Cmd) {
 			// Open picker for select fields
 			m.openPickerForField()
 		default:
+			// Editing a field clears its error
+			delete(m.fieldErrors, m.focusIndex)
+
 			// Forward to focused field
 			switch m.focusIndex {
 			case fieldTitle:
@@ -159,7 +393,67 @@ func (m *CreateModel) openPickerForField() {
 	case fieldAssignee:
 		m.picker = components.NewPickerModel("Select Assignee", m.usersToItems(), m.width, m.height)
 		m.pickerType = "assignee"
+	case fieldCycle:
+		m.picker = components.NewPickerModel("Select Cycle", CyclesToItems(m.cycles), m.width, m.height)
+		m.pickerType = "cycle"
+	case fieldLabels:
+		m.labelPicker = components.NewMultiPickerModel("Select Labels", LabelsToItems(m.labels), m.selectedLabels, m.width, m.height).EnableCreate()
+	}
+}
+
+// updateLabelPicker handles label picker interactions
+func (m CreateModel) updateLabelPicker(msg tea.KeyMsg) (CreateModel, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.labelPicker = nil
+		return m, nil
+	}
+
+	// Ignore keys while a new label is being created
+	if m.labelPicker.PendingCreate() != "" {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.labelPicker, cmd = m.labelPicker.Update(msg)
+
+	if m.labelPicker.Confirmed() {
+		delete(m.fieldErrors, fieldLabels)
+		m.selectedLabels = m.labelPicker.SelectedIDs()
+		m.labelPicker = nil
+		return m, nil
 	}
+
+	if name := m.labelPicker.PendingCreate(); name != "" {
+		teamID := ""
+		if m.selectedTeam >= 0 && m.selectedTeam < len(m.teams) {
+			teamID = m.teams[m.selectedTeam].ID
+		}
+		return m, func() tea.Msg {
+			return CreateLabelMsg{TeamID: teamID, Name: name}
+		}
+	}
+	return m, cmd
+}
+
+// AddLabel finishes creating a label requested from the label picker. A nil
+// label means creating it failed.
+func (m CreateModel) AddLabel(label *linear.Label) CreateModel {
+	if m.labelPicker == nil {
+		return m
+	}
+	if label == nil {
+		m.labelPicker.ResolveCreate(nil)
+		return m
+	}
+	m.labels = append(m.labels, *label)
+	item := LabelItem(*label)
+	m.labelPicker.ResolveCreate(&item)
+	return m
+}
+
+// PickerOpen returns true while a picker owns the keyboard
+func (m CreateModel) PickerOpen() bool {
+	return m.picker != nil || m.labelPicker != nil
 }
 
 // updatePicker handles picker interactions
@@ -190,6 +484,8 @@ func (m CreateModel) updatePicker(msg tea.KeyMsg) (CreateModel, tea.Cmd) {
 
 // handlePickerSelection handles the selection from a picker
 func (m *CreateModel) handlePickerSelection(item *components.PickerItem) {
+	delete(m.fieldErrors, m.focusIndex)
+
 	switch m.pickerType {
 	case "team":
 		for i, team := range m.teams {
@@ -235,6 +531,8 @@ func (m *CreateModel) handlePickerSelection(item *components.PickerItem) {
 				}
 			}
 		}
+	case "cycle":
+		m.selectedCycle = cycleIndex(m.cycles, item.ID)
 	}
 }
 
@@ -318,13 +616,16 @@ func (m *CreateModel) updateFocus() {
 }
 
 func (m *CreateModel) fieldHeights() []int {
-	return []int{4, 9, 2, 2, 2, 2}
+	return []int{4, 9, 2, 2, 2, 2, 2, 2}
 }
 
 func (m *CreateModel) ensureFocusVisible() {
 	heights := m.fieldHeights()
 
 	fieldTop := 3
+	if m.parent != nil {
+		fieldTop++ // "of <parent>" line below the header
+	}
 	for i := 0; i < m.focusIndex; i++ {
 		fieldTop += heights[i]
 	}
@@ -356,12 +657,33 @@ func (m *CreateModel) handleLeftRight(dir int) {
 		m.selectedPriority = clamp(m.selectedPriority+dir, 0, 4)
 	case fieldAssignee:
 		m.selectedAssignee = clamp(m.selectedAssignee+dir, -1, len(m.users)-1)
+	case fieldCycle:
+		m.selectedCycle = clamp(m.selectedCycle+dir, -1, len(m.cycles)-1)
+	}
+}
+
+// SetFieldErrors shows validation errors next to the offending fields and
+// focuses the first of them. Errors are keyed by IssueCreateInput field name;
+// names that don't match a form field are ignored.
+func (m CreateModel) SetFieldErrors(errs map[string]string) CreateModel {
+	m.fieldErrors = make(map[int]string)
+	first := fieldCount
+	for name, msg := range errs {
+		if field, ok := createInputFields[name]; ok {
+			m.fieldErrors[field] = msg
+			first = min(first, field)
+		}
+	}
+	if first < fieldCount {
+		m.focusIndex = first
+		m.updateFocus()
 	}
+	return m
 }
 
 // IsOnSelectField returns true if the current focus is on a select field (not text input)
 func (m CreateModel) IsOnSelectField() bool {
-	return m.focusIndex >= fieldTeam && m.focusIndex <= fieldAssignee
+	return m.focusIndex >= fieldTeam && m.focusIndex <= fieldCycle
 }
 
 // GetInput returns the current form input as IssueCreateInput
@@ -387,6 +709,18 @@ func (m CreateModel) GetInput() linear.IssueCreateInput {
 		input.AssigneeID = m.users[m.selectedAssignee].ID
 	}
 
+	if m.selectedCycle >= 0 && m.selectedCycle < len(m.cycles) {
+		input.CycleID = m.cycles[m.selectedCycle].ID
+	}
+
+	if len(m.selectedLabels) > 0 {
+		input.LabelIDs = m.selectedLabels
+	}
+
+	if m.parent != nil {
+		input.ParentID = m.parent.ID
+	}
+
 	return input
 }
 
@@ -396,11 +730,29 @@ func (m CreateModel) View() string {
 	if m.picker != nil {
 		return m.picker.View()
 	}
+	if m.labelPicker != nil {
+		return m.labelPicker.View()
+	}
 
 	header := theme.TitleStyle.Render("Create Issue")
+	if m.parent != nil {
+		header = theme.TitleStyle.Render("Create Sub-issue") + "\n" +
+			theme.TextMutedStyle.Render("of ") + theme.IssueIDStyle.Render(m.parent.Identifier) +
+			theme.TextMutedStyle.Render(" "+util.Truncate(m.parent.Title, m.width-20))
+	}
 
 	var fields []string
 
+	if m.aiMode {
+		aiLabel := lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render("✨ AI Draft")
+		aiField := theme.InputFocusedStyle.Render(m.aiInput.View())
+		aiHint := theme.HelpStyle.Render("Enter: generate  Esc: back to form")
+		if m.aiGenerating {
+			aiHint = theme.TextMutedStyle.Render("Generating draft...  Esc: cancel")
+		}
+		fields = append(fields, aiLabel+"\n"+aiField+"\n"+aiHint, "")
+	}
+
 	titleLabel := m.fieldLabel("Title", fieldTitle)
 	titleStyle := theme.InputStyle
 	if m.focusIndex == fieldTitle {
@@ -446,7 +798,18 @@ func (m CreateModel) View() string {
 	assigneeField := m.selectField(assigneeValue, m.focusIndex == fieldAssignee)
 	fields = append(fields, assigneeLabel+"  "+assigneeField)
 
-	help := theme.HelpStyle.Render("Tab: next field  ←/→: change selection  Enter/Ctrl+S: submit  Esc: cancel")
+	cycleLabel := m.fieldLabel("Cycle", fieldCycle)
+	cycleValue := "None"
+	if m.selectedCycle >= 0 && m.selectedCycle < len(m.cycles) {
+		cycleValue = CycleLabel(m.cycles[m.selectedCycle])
+	}
+	cycleField := m.selectField(cycleValue, m.focusIndex == fieldCycle)
+	fields = append(fields, cycleLabel+"  "+cycleField)
+
+	labelsLabel := m.fieldLabel("Labels", fieldLabels)
+	fields = append(fields, labelsLabel+"  "+labelsField(m.labels, m.selectedLabels, m.focusIndex == fieldLabels))
+
+	help := theme.HelpStyle.Render("Tab: next field  ←/→: change selection  Ctrl+G: AI draft  Ctrl+O: $EDITOR  Enter/Ctrl+S: submit  Esc: cancel")
 
 	formContent := lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -512,7 +875,7 @@ func (m CreateModel) fieldLabel(label string, fieldIndex int) string {
 	if m.focusIndex == fieldIndex {
 		style = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
 	}
-	return style.Render(label)
+	return style.Render(label) + fieldError(m.fieldErrors[fieldIndex])
 }
 
 // selectField renders a select field
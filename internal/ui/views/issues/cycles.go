@@ -0,0 +1,60 @@
+package issues
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+)
+
+// CycleLabel returns a short display name for a cycle, e.g. "Cycle 12 - Polish"
+func CycleLabel(c linear.Cycle) string {
+	label := fmt.Sprintf("Cycle %d", c.Number)
+	if c.Name != "" {
+		label += " - " + c.Name
+	}
+	return label
+}
+
+// CycleDaysLeft returns the number of days until a cycle ends, rounded up
+func CycleDaysLeft(c linear.Cycle) int {
+	days := int(math.Ceil(time.Until(c.EndsAt).Hours() / 24))
+	return max(days, 0)
+}
+
+// CyclesToItems converts cycles to picker items, with a "None" option first
+// that takes an issue out of its cycle. The current and next cycles are
+// marked as such.
+func CyclesToItems(cycles []linear.Cycle) []components.PickerItem {
+	items := []components.PickerItem{{ID: "", Label: "None", Icon: "🔄"}}
+	next := false
+	for _, c := range cycles {
+		label := CycleLabel(c)
+		switch {
+		case c.IsActive:
+			label += " (current)"
+		case c.IsFuture && !next:
+			label += " (next)"
+			next = true
+		}
+		items = append(items, components.PickerItem{
+			ID:    c.ID,
+			Label: label,
+			Icon:  "🔄",
+			Desc:  c.StartsAt.Format("Jan 2") + " - " + c.EndsAt.Format("Jan 2"),
+		})
+	}
+	return items
+}
+
+// cycleIndex returns the index of the cycle with the given ID, or -1
+func cycleIndex(cycles []linear.Cycle, id string) int {
+	for i, c := range cycles {
+		if c.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -2,31 +2,108 @@ package issues
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/brandonli/lazyliner/internal/git"
 	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/markdown"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // DetailModel is the issue detail view
 type DetailModel struct {
-	issue      *linear.Issue
-	width      int
-	height     int
-	scrollY    int
-	maxScrollY int
+	issue     *linear.Issue
+	width     int
+	height    int
+	scrollY   int
+	syncState SyncState
+
+	// Description task list
+	taskCursor int // -1 when no task item is selected
+
+	// Comment thread
+	viewerID        string
+	comments        []linear.Comment
+	commentsPage    linear.PageInfo
+	commentsLoading bool
+	commentCursor   int // -1 when no comment is selected
+
+	// Sub-issue tree
+	ancestors     []linear.Issue  // Parent chain, root first
+	children      []linear.Issue  // Sub-issues, with their own Children
+	collapsed     map[string]bool // Sub-issues whose children are hidden
+	treeCollapsed bool
+
+	// Relations, and the selected relation or sub-issue row
+	relations  []linear.RelatedIssue
+	linkCursor int // -1 when no linked issue is selected
+
+	// Linked work
+	attachments []linear.Attachment
+	commits     []git.Commit
+
+	// Comment compose box
+	composeInput textarea.Model
+	composing    bool
+	submitting   bool
+	editingID    string // ID of the comment being edited, empty for a new comment
+}
+
+// SubmitCommentMsg is emitted when the user submits the compose box.
+// CommentID is empty for a new comment.
+type SubmitCommentMsg struct {
+	IssueID   string
+	CommentID string
+	Body      string
+}
+
+// DeleteCommentMsg is emitted when the user deletes one of their comments
+type DeleteCommentMsg struct {
+	CommentID string
+}
+
+// ComposeInEditorMsg is emitted when the user opens the comment being
+// written in $EDITOR
+type ComposeInEditorMsg struct {
+	Body string
+}
+
+// ToggleTaskMsg is emitted when the user checks or unchecks a task item in
+// the description. Description is the updated markdown.
+type ToggleTaskMsg struct {
+	IssueID     string
+	Description string
+}
+
+// LoadMoreCommentsMsg is emitted when the user asks for the next page of comments
+type LoadMoreCommentsMsg struct {
+	IssueID string
+	After   string
 }
 
 // NewDetailModel creates a new detail model
 func NewDetailModel(issue *linear.Issue, width, height int) DetailModel {
+	ta := textarea.New()
+	ta.Placeholder = "Write a comment (markdown supported)"
+	ta.CharLimit = 10000
+	ta.SetWidth(width - 12)
+	ta.SetHeight(5)
+
 	return DetailModel{
-		issue:   issue,
-		width:   width,
-		height:  height,
-		scrollY: 0,
+		issue:           issue,
+		width:           width,
+		height:          height,
+		scrollY:         0,
+		commentsLoading: issue != nil,
+		taskCursor:      -1,
+		commentCursor:   -1,
+		linkCursor:      -1,
+		composeInput:    ta,
 	}
 }
 
@@ -34,31 +111,335 @@ func NewDetailModel(issue *linear.Issue, width, height int) DetailModel {
 func (m DetailModel) SetSize(width, height int) DetailModel {
 	m.width = width
 	m.height = height
+	if m.composeInput.Placeholder != "" {
+		m.composeInput.SetWidth(width - 12)
+	}
+	return m
+}
+
+// SetIssue replaces the displayed issue while keeping the loaded comment thread
+func (m DetailModel) SetIssue(issue *linear.Issue) DetailModel {
+	m.issue = issue
+	m.taskCursor = min(m.taskCursor, m.taskCount()-1)
+	return m
+}
+
+// SetSyncState marks whether the issue has unsynced offline edits
+func (m DetailModel) SetSyncState(state SyncState) DetailModel {
+	m.syncState = state
+	return m
+}
+
+// SetViewerID sets the current user's ID so their own comments can be edited
+func (m DetailModel) SetViewerID(id string) DetailModel {
+	m.viewerID = id
+	return m
+}
+
+// SetComments sets (or appends) a page of comments. The thread is sorted
+// oldest first once the page is in, as pages don't come in date order; the
+// selected comment stays selected.
+func (m DetailModel) SetComments(comments []linear.Comment, pageInfo linear.PageInfo, appendPage bool) DetailModel {
+	var selectedID string
+	if m.commentCursor >= 0 && m.commentCursor < len(m.comments) {
+		selectedID = m.comments[m.commentCursor].ID
+	}
+	if appendPage {
+		m.comments = append(m.comments, comments...)
+	} else {
+		m.comments = comments
+	}
+	sort.SliceStable(m.comments, func(i, j int) bool {
+		return m.comments[i].CreatedAt.Before(m.comments[j].CreatedAt)
+	})
+	for i := range m.comments {
+		if selectedID != "" && m.comments[i].ID == selectedID {
+			m.commentCursor = i
+		}
+	}
+	m.commentsPage = pageInfo
+	m.commentsLoading = false
+	if m.commentCursor >= len(m.comments) {
+		m.commentCursor = len(m.comments) - 1
+	}
+	return m
+}
+
+// CommentsFailed clears the loading indicator after a failed comment fetch
+func (m DetailModel) CommentsFailed() DetailModel {
+	m.commentsLoading = false
+	return m
+}
+
+// CommentSaved inserts or replaces a comment and closes the compose box
+func (m DetailModel) CommentSaved(comment *linear.Comment) DetailModel {
+	m.submitting = false
+	m.composing = false
+	m.editingID = ""
+	m.composeInput.Reset()
+	m.composeInput.Blur()
+
+	if comment == nil {
+		return m
+	}
+
+	for i := range m.comments {
+		if m.comments[i].ID == comment.ID {
+			m.comments[i] = *comment
+			return m
+		}
+	}
+	m.comments = append(m.comments, *comment)
+	m.commentCursor = len(m.comments) - 1
+	m.scrollY = m.maxScroll()
+	return m
+}
+
+// CommentSaveFailed re-enables the compose box without discarding the draft
+func (m DetailModel) CommentSaveFailed() DetailModel {
+	m.submitting = false
 	return m
 }
 
+// CommentDeleted removes a comment from the thread
+func (m DetailModel) CommentDeleted(commentID string) DetailModel {
+	for i := range m.comments {
+		if m.comments[i].ID == commentID {
+			m.comments = append(m.comments[:i], m.comments[i+1:]...)
+			break
+		}
+	}
+	if m.commentCursor >= len(m.comments) {
+		m.commentCursor = len(m.comments) - 1
+	}
+	return m
+}
+
+// IsComposing returns true while the comment compose box has focus
+func (m DetailModel) IsComposing() bool {
+	return m.composing
+}
+
 // Update handles messages
 func (m DetailModel) Update(msg tea.Msg) (DetailModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.composing {
+			return m.updateCompose(msg)
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if m.scrollY > 0 {
 				m.scrollY--
 			}
 		case "down", "j":
-			if m.scrollY < m.maxScrollY {
+			if m.scrollY < m.maxScroll() {
 				m.scrollY++
 			}
 		case "home", "g":
 			m.scrollY = 0
 		case "end", "G":
-			m.scrollY = m.maxScrollY
+			m.scrollY = m.maxScroll()
+		case "C":
+			return m.startCompose("", "")
+		case "]":
+			if m.commentCursor < len(m.comments)-1 {
+				m.commentCursor++
+				m.scrollToComment()
+			}
+		case "[":
+			if m.commentCursor > 0 {
+				m.commentCursor--
+				m.scrollToComment()
+			}
+		case "E":
+			if comment := m.selectedOwnComment(); comment != nil {
+				return m.startCompose(comment.ID, comment.Body)
+			}
+		case "X":
+			if comment := m.selectedOwnComment(); comment != nil {
+				commentID := comment.ID
+				return m, func() tea.Msg {
+					return DeleteCommentMsg{CommentID: commentID}
+				}
+			}
+		case ")":
+			if m.taskCursor < m.taskCount()-1 {
+				m.taskCursor++
+				m.scrollToTask()
+			}
+		case "(":
+			if m.taskCursor > 0 {
+				m.taskCursor--
+				m.scrollToTask()
+			}
+		case "x":
+			return m.toggleTask()
+		case "{", "}", "z", "Z", "enter", "u":
+			return m.updateLinks(msg.String())
+		case "M":
+			if m.issue != nil && m.commentsPage.HasNextPage && !m.commentsLoading {
+				m.commentsLoading = true
+				issueID := m.issue.ID
+				after := m.commentsPage.EndCursor
+				return m, func() tea.Msg {
+					return LoadMoreCommentsMsg{IssueID: issueID, After: after}
+				}
+			}
 		}
 	}
 	return m, nil
 }
 
+// startCompose opens the compose box for a new comment or for editing an existing one
+func (m DetailModel) startCompose(commentID, body string) (DetailModel, tea.Cmd) {
+	if m.issue == nil {
+		return m, nil
+	}
+	m.composing = true
+	m.editingID = commentID
+	m.composeInput.SetValue(body)
+	return m, m.composeInput.Focus()
+}
+
+// updateCompose handles keys while the compose box has focus
+func (m DetailModel) updateCompose(msg tea.KeyMsg) (DetailModel, tea.Cmd) {
+	if m.submitting {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.composing = false
+		m.editingID = ""
+		m.composeInput.Reset()
+		m.composeInput.Blur()
+		return m, nil
+	case "ctrl+o":
+		body := m.composeInput.Value()
+		return m, func() tea.Msg {
+			return ComposeInEditorMsg{Body: body}
+		}
+	case "ctrl+s":
+		body := strings.TrimSpace(m.composeInput.Value())
+		if body == "" || m.issue == nil {
+			return m, nil
+		}
+		m.submitting = true
+		submit := SubmitCommentMsg{IssueID: m.issue.ID, CommentID: m.editingID, Body: body}
+		return m, func() tea.Msg {
+			return submit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.composeInput, cmd = m.composeInput.Update(msg)
+	return m, cmd
+}
+
+// SetComposeBody replaces the text of the open compose box, as saved in $EDITOR
+func (m DetailModel) SetComposeBody(body string) DetailModel {
+	if m.composing {
+		m.composeInput.SetValue(strings.TrimSpace(body))
+	}
+	return m
+}
+
+// selectedOwnComment returns the selected comment if it was written by the viewer
+func (m DetailModel) selectedOwnComment() *linear.Comment {
+	if m.commentCursor < 0 || m.commentCursor >= len(m.comments) {
+		return nil
+	}
+	comment := &m.comments[m.commentCursor]
+	if comment.User == nil || m.viewerID == "" || comment.User.ID != m.viewerID {
+		return nil
+	}
+	return comment
+}
+
+// viewportHeight returns the number of content lines visible at once
+func (m DetailModel) viewportHeight() int {
+	h := m.height - 2
+	if m.composing {
+		h -= lipgloss.Height(m.renderCompose())
+	}
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// maxScroll returns the largest valid scroll offset for the current content
+func (m DetailModel) maxScroll() int {
+	if m.issue == nil {
+		return 0
+	}
+	content, _ := m.renderContent()
+	max := lipgloss.Height(content) - m.viewportHeight()
+	if max < 0 {
+		return 0
+	}
+	return max
+}
+
+// taskCount returns the number of task items in the description
+func (m DetailModel) taskCount() int {
+	if m.issue == nil {
+		return 0
+	}
+	return len(markdown.Tasks(m.issue.Description))
+}
+
+// toggleTask checks or unchecks the selected task item. The change shows
+// at once while the app writes the description back to Linear.
+func (m DetailModel) toggleTask() (DetailModel, tea.Cmd) {
+	if m.issue == nil || m.taskCursor < 0 || m.taskCursor >= m.taskCount() {
+		return m, nil
+	}
+	issue := *m.issue
+	issue.Description = markdown.ToggleTask(issue.Description, m.taskCursor)
+	m.issue = &issue
+
+	toggle := ToggleTaskMsg{IssueID: issue.ID, Description: issue.Description}
+	return m, func() tea.Msg {
+		return toggle
+	}
+}
+
+// scrollToTask scrolls so the selected task item is visible
+func (m *DetailModel) scrollToTask() {
+	_, offsets := m.renderContent()
+	if m.taskCursor < 0 || m.taskCursor >= len(offsets.tasks) {
+		return
+	}
+	m.scrollToLine(offsets.tasks[m.taskCursor])
+}
+
+// scrollToComment scrolls so the selected comment's header is visible
+func (m *DetailModel) scrollToComment() {
+	_, offsets := m.renderContent()
+	if m.commentCursor < 0 || m.commentCursor >= len(offsets.comments) {
+		return
+	}
+	m.scrollToLine(offsets.comments[m.commentCursor])
+}
+
+// scrollToLine scrolls so a line of the content is visible
+func (m *DetailModel) scrollToLine(line int) {
+	if line < m.scrollY {
+		m.scrollY = line
+	} else if line >= m.scrollY+m.viewportHeight()-2 {
+		m.scrollY = line - m.viewportHeight() + 3
+	}
+	if max := m.maxScroll(); m.scrollY > max {
+		m.scrollY = max
+	}
+	if m.scrollY < 0 {
+		m.scrollY = 0
+	}
+}
+
 // View renders the detail view
 func (m DetailModel) View() string {
 	if m.issue == nil {
@@ -71,8 +452,53 @@ func (m DetailModel) View() string {
 		)
 	}
 
+	content, _ := m.renderContent()
+
+	// Apply scrolling
+	lines := strings.Split(content, "\n")
+	viewport := m.viewportHeight()
+	start := m.scrollY
+	if max := len(lines) - viewport; start > max {
+		start = max
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + viewport
+	if end > len(lines) {
+		end = len(lines)
+	}
+	content = strings.Join(lines[start:end], "\n")
+
+	if m.composing {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, m.renderCompose())
+	}
+
+	// Apply padding
+	return lipgloss.NewStyle().
+		Padding(1, 2).
+		Width(m.width).
+		Height(m.height).
+		Render(content)
+}
+
+// contentOffsets holds the line offsets of selectable rows in the content
+type contentOffsets struct {
+	tasks    []int // Task items in the description
+	links    []int // Relation rows, then sub-issue rows
+	comments []int
+}
+
+// renderContent renders the full scrollable content and returns the line
+// offsets of the task items, linked issue rows and comment headers within it
+func (m DetailModel) renderContent() (string, contentOffsets) {
+	var offsets contentOffsets
+
 	// Header with back button and ID
 	header := m.renderHeader()
+	if breadcrumb := m.renderBreadcrumb(); breadcrumb != "" {
+		header = lipgloss.JoinVertical(lipgloss.Left, header, breadcrumb)
+	}
 
 	// Title
 	title := theme.TitleStyle.
@@ -85,9 +511,6 @@ func (m DetailModel) View() string {
 	// Divider
 	divider := theme.Divider(m.width - 4)
 
-	// Description
-	description := m.renderDescription()
-
 	// Labels
 	labels := m.renderLabels()
 
@@ -95,26 +518,155 @@ func (m DetailModel) View() string {
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
 		header,
-		"",
+		m.renderSyncBanner(),
 		title,
 		"",
 		metadata,
 		"",
 		divider,
 		"",
-		description,
 	)
 
+	// Description
+	start := lipgloss.Height(content)
+	description, tasks := m.renderDescription()
+	for _, row := range tasks {
+		offsets.tasks = append(offsets.tasks, start+row)
+	}
+	content = lipgloss.JoinVertical(lipgloss.Left, content, description)
+
 	if labels != "" {
 		content = lipgloss.JoinVertical(lipgloss.Left, content, "", labels)
 	}
 
-	// Apply padding
-	return lipgloss.NewStyle().
-		Padding(1, 2).
-		Width(m.width).
-		Height(m.height).
-		Render(content)
+	// Relations and sub-issues
+	relations, relationRows := m.renderRelations()
+	tree, treeRows := m.renderSubIssues()
+	for _, section := range []struct {
+		view string
+		rows []int
+	}{{relations, relationRows}, {tree, treeRows}} {
+		if section.view == "" {
+			continue
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "")
+		start := lipgloss.Height(content)
+		for _, row := range section.rows {
+			offsets.links = append(offsets.links, start+row)
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, content, section.view)
+	}
+
+	// Pull requests, links and local commits
+	for _, section := range []string{m.renderAttachments(), m.renderCommits()} {
+		if section != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, "", section)
+		}
+	}
+
+	content = lipgloss.JoinVertical(lipgloss.Left, content, "", divider, "")
+	content, offsets.comments = m.appendComments(content)
+	return content, offsets
+}
+
+// appendComments renders the comment thread below content
+func (m DetailModel) appendComments(content string) (string, []int) {
+	heading := theme.SubtitleStyle.Bold(true).Render(fmt.Sprintf("Comments (%d)", len(m.comments)))
+	content = lipgloss.JoinVertical(lipgloss.Left, content, heading)
+
+	var offsets []int
+	if len(m.comments) == 0 {
+		empty := "No comments yet"
+		if m.commentsLoading {
+			empty = "Loading comments..."
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "", theme.TextMutedStyle.Render(empty))
+	}
+
+	for i, comment := range m.comments {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "")
+		offsets = append(offsets, lipgloss.Height(content))
+		content = lipgloss.JoinVertical(lipgloss.Left, content, m.renderComment(comment, i == m.commentCursor))
+	}
+
+	if m.commentsPage.HasNextPage {
+		more := "M: load more comments"
+		if m.commentsLoading {
+			more = "Loading more comments..."
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "", theme.TextDimStyle.Render(more))
+	}
+
+	return content, offsets
+}
+
+// renderComment renders a single comment with author and relative time
+func (m DetailModel) renderComment(comment linear.Comment, selected bool) string {
+	author := "Unknown"
+	own := false
+	if comment.User != nil {
+		author = comment.User.Name
+		own = m.viewerID != "" && comment.User.ID == m.viewerID
+	}
+
+	marker := "  "
+	authorStyle := lipgloss.NewStyle().Foreground(theme.Text).Bold(true)
+	if selected {
+		marker = lipgloss.NewStyle().Foreground(theme.Primary).Render("▌ ")
+		authorStyle = authorStyle.Foreground(theme.Primary)
+	}
+
+	meta := formatRelativeTime(comment.CreatedAt)
+	if comment.UpdatedAt.Sub(comment.CreatedAt) > time.Minute {
+		meta += " (edited)"
+	}
+	if own {
+		meta += " · you"
+	}
+
+	headerLine := marker + authorStyle.Render(author) + theme.TextMutedStyle.Render(" · "+meta)
+
+	maxWidth := m.width - 10
+	if maxWidth < 40 {
+		maxWidth = 40
+	}
+	body := lipgloss.NewStyle().
+		PaddingLeft(2).
+		Render(markdown.Render(comment.Body, maxWidth))
+
+	return lipgloss.JoinVertical(lipgloss.Left, headerLine, body)
+}
+
+// renderCompose renders the comment compose box
+func (m DetailModel) renderCompose() string {
+	label := "New comment"
+	if m.editingID != "" {
+		label = "Edit comment"
+	}
+	hint := "Ctrl+S: post  Ctrl+O: $EDITOR  Esc: cancel"
+	if m.submitting {
+		hint = "Posting..."
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render(label),
+		theme.InputFocusedStyle.Render(m.composeInput.View()),
+		theme.HelpStyle.Render(hint),
+	)
+}
+
+// renderSyncBanner renders a notice for unsynced offline edits
+func (m DetailModel) renderSyncBanner() string {
+	switch m.syncState {
+	case SyncPending:
+		return theme.WarningStyle.Render("⏳ Offline edits pending - they will be sent when Linear is reachable") + "\n"
+	case SyncConflict:
+		return theme.ErrorStyle.Render("⚠ Offline edits conflict with changes made on the server") + "\n" +
+			theme.HelpStyle.Render("F: apply my edits anyway  U: discard my edits") + "\n"
+	default:
+		return ""
+	}
 }
 
 // renderHeader renders the detail header
@@ -165,6 +717,11 @@ func (m DetailModel) renderMetadata() string {
 		parts = append(parts, fmt.Sprintf("Project: %s", m.issue.Project.Name))
 	}
 
+	// Cycle
+	if m.issue.Cycle != nil {
+		parts = append(parts, fmt.Sprintf("Cycle: %s", CycleLabel(*m.issue.Cycle)))
+	}
+
 	// Team
 	if m.issue.Team != nil {
 		parts = append(parts, fmt.Sprintf("Team: %s", m.issue.Team.Name))
@@ -197,26 +754,18 @@ func (m DetailModel) renderMetadata() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", right)
 }
 
-// renderDescription renders the description
-func (m DetailModel) renderDescription() string {
+// renderDescription renders the description, returning the line offsets of
+// its task items
+func (m DetailModel) renderDescription() (string, []int) {
 	if m.issue.Description == "" {
-		return theme.TextMutedStyle.Render("No description")
+		return theme.TextMutedStyle.Render("No description"), nil
 	}
 
-	// Simple markdown-ish rendering
-	desc := m.issue.Description
-
-	// Wrap to width
 	maxWidth := m.width - 8
 	if maxWidth < 40 {
 		maxWidth = 40
 	}
-
-	wrapped := wordWrap(desc, maxWidth)
-	return lipgloss.NewStyle().
-		Foreground(theme.Text).
-		Width(maxWidth).
-		Render(wrapped)
+	return markdown.RenderTasks(m.issue.Description, maxWidth, m.taskCursor)
 }
 
 // renderLabels renders the labels section
@@ -274,37 +823,3 @@ func formatRelativeTime(t time.Time) string {
 		return t.Format("Jan 2, 2006")
 	}
 }
-
-func wordWrap(text string, width int) string {
-	if width <= 0 {
-		return text
-	}
-
-	var result strings.Builder
-	lines := strings.Split(text, "\n")
-
-	for i, line := range lines {
-		if i > 0 {
-			result.WriteString("\n")
-		}
-
-		words := strings.Fields(line)
-		if len(words) == 0 {
-			continue
-		}
-
-		currentLine := words[0]
-		for _, word := range words[1:] {
-			if len(currentLine)+1+len(word) <= width {
-				currentLine += " " + word
-			} else {
-				result.WriteString(currentLine)
-				result.WriteString("\n")
-				currentLine = word
-			}
-		}
-		result.WriteString(currentLine)
-	}
-
-	return result.String()
-}
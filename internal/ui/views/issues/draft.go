@@ -0,0 +1,147 @@
+package issues
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/editor"
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+)
+
+// draftFields returns the front matter of an issue form's draft for $EDITOR:
+// its title, priority, assignee, project and labels
+func draftFields(title string, priority int, assignee *linear.User, project *linear.Project, labels []linear.Label, labelIDs []string) []editor.Field {
+	assigneeName := ""
+	if assignee != nil {
+		assigneeName = assignee.Name
+	}
+	projectName := ""
+	if project != nil {
+		projectName = project.Name
+	}
+	var names []string
+	for _, id := range labelIDs {
+		for _, label := range labels {
+			if label.ID == id {
+				names = append(names, label.Name)
+				break
+			}
+		}
+	}
+
+	return []editor.Field{
+		{Key: "title", Value: title},
+		{Key: "priority", Value: strings.ToLower(theme.PriorityLabel(priority))},
+		{Key: "assignee", Value: assigneeName},
+		{Key: "project", Value: projectName},
+		{Key: "labels", Value: strings.Join(names, ", ")},
+	}
+}
+
+// draft is an issue draft read back from $EDITOR. Fields left out of the
+// front matter are nil, so the form keeps its values for them.
+type draft struct {
+	title       *string
+	team        *string // Team key or name, for new issues
+	description string
+	priority    *int
+	assignee    *int // Index into the users, -1 for unassigned
+	project     *int // Index into the projects, -1 for none
+	labelIDs    *[]string
+
+	// Values that didn't parse, keyed by input field name
+	errors map[string]string
+}
+
+// parseDraft reads an issue draft edited in $EDITOR, resolving the assignee,
+// project and labels by name
+func parseDraft(text string, users []linear.User, projects []linear.Project, labels []linear.Label) draft {
+	fields, body := editor.Parse(text)
+	d := draft{description: body, errors: make(map[string]string)}
+
+	if title, ok := fields["title"]; ok {
+		d.title = &title
+	}
+	if team, ok := fields["team"]; ok && team != "" {
+		d.team = &team
+	}
+
+	if value, ok := fields["priority"]; ok {
+		priority := 0
+		if value != "" {
+			var err error
+			if priority, err = linear.ParsePriority(value); err != nil {
+				d.errors["priority"] = err.Error()
+			}
+		}
+		if d.errors["priority"] == "" {
+			d.priority = &priority
+		}
+	}
+
+	if name, ok := fields["assignee"]; ok {
+		index := -1
+		if name != "" && !strings.EqualFold(name, "unassigned") && !strings.EqualFold(name, "none") {
+			if user := linear.FindUser(users, name); user != nil {
+				index = userIndex(users, user.ID)
+			} else {
+				d.errors["assigneeId"] = "unknown user " + strconv.Quote(name)
+			}
+		}
+		if d.errors["assigneeId"] == "" {
+			d.assignee = &index
+		}
+	}
+
+	if name, ok := fields["project"]; ok {
+		index := -1
+		if name != "" && !strings.EqualFold(name, "none") {
+			index = projectIndex(projects, name)
+			if index < 0 {
+				d.errors["projectId"] = "unknown project " + strconv.Quote(name)
+			}
+		}
+		if d.errors["projectId"] == "" {
+			d.project = &index
+		}
+	}
+
+	if value, ok := fields["labels"]; ok {
+		var ids, unknown []string
+		for _, name := range editor.List(value) {
+			if label := linear.FindLabel(labels, name); label != nil {
+				ids = append(ids, label.ID)
+			} else {
+				unknown = append(unknown, strconv.Quote(name))
+			}
+		}
+		if len(unknown) > 0 {
+			d.errors["labelIds"] = "unknown label " + strings.Join(unknown, ", ")
+		} else {
+			d.labelIDs = &ids
+		}
+	}
+
+	return d
+}
+
+// projectIndex returns the index of the project with the given name, or -1
+func projectIndex(projects []linear.Project, name string) int {
+	for i, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// userIndex returns the index of the user with the given ID, or -1
+func userIndex(users []linear.User, id string) int {
+	for i, u := range users {
+		if u.ID == id {
+			return i
+		}
+	}
+	return -1
+}
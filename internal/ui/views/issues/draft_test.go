@@ -0,0 +1,85 @@
+package issues
+
+import (
+	"testing"
+
+	"github.com/brandonli/lazyliner/internal/editor"
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+var (
+	testUsers    = []linear.User{{ID: "u1", Name: "Jane Doe"}, {ID: "u2", Name: "Sam Lee"}}
+	testProjects = []linear.Project{{ID: "p1", Name: "Auth revamp"}}
+	testLabels   = []linear.Label{{ID: "l1", Name: "bug"}, {ID: "l2", Name: "UI"}}
+)
+
+func TestDraftRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority int
+		assignee int
+		project  int
+		labelIDs []string
+	}{
+		{name: "no priority, unassigned", priority: 0, assignee: -1, project: -1},
+		{name: "urgent", priority: 1, assignee: 1, project: 0, labelIDs: []string{"l1", "l2"}},
+		{name: "high", priority: 2, assignee: 0, project: -1, labelIDs: []string{"l2"}},
+		{name: "medium", priority: 3, assignee: -1, project: 0},
+		{name: "low", priority: 4, assignee: 0, project: 0, labelIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var assignee *linear.User
+			if tt.assignee >= 0 {
+				assignee = &testUsers[tt.assignee]
+			}
+			var project *linear.Project
+			if tt.project >= 0 {
+				project = &testProjects[tt.project]
+			}
+			fields := draftFields("Fix: login loop", tt.priority, assignee, project, testLabels, tt.labelIDs)
+
+			d := parseDraft(editor.Format(fields, "Body"), testUsers, testProjects, testLabels)
+			if len(d.errors) > 0 {
+				t.Fatalf("errors: %v", d.errors)
+			}
+			if d.title == nil || *d.title != "Fix: login loop" {
+				t.Errorf("title = %v", d.title)
+			}
+			if d.description != "Body" {
+				t.Errorf("description = %q", d.description)
+			}
+			if d.priority == nil || *d.priority != tt.priority {
+				t.Errorf("priority = %v, want %d", d.priority, tt.priority)
+			}
+			if d.assignee == nil || *d.assignee != tt.assignee {
+				t.Errorf("assignee = %v, want %d", d.assignee, tt.assignee)
+			}
+			if d.project == nil || *d.project != tt.project {
+				t.Errorf("project = %v, want %d", d.project, tt.project)
+			}
+			if d.labelIDs == nil || len(*d.labelIDs) != len(tt.labelIDs) {
+				t.Fatalf("labels = %v, want %v", d.labelIDs, tt.labelIDs)
+			}
+			for i, id := range *d.labelIDs {
+				if id != tt.labelIDs[i] {
+					t.Errorf("labels = %v, want %v", *d.labelIDs, tt.labelIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDraftErrors(t *testing.T) {
+	text := "---\npriority: critical\nassignee: nobody\nproject: Billing\nlabels: bug, nope\n---\n"
+	d := parseDraft(text, testUsers, testProjects, testLabels)
+	for _, key := range []string{"priority", "assigneeId", "projectId", "labelIds"} {
+		if d.errors[key] == "" {
+			t.Errorf("no %s error in %v", key, d.errors)
+		}
+	}
+	if d.priority != nil || d.assignee != nil || d.project != nil || d.labelIDs != nil {
+		t.Errorf("fields that didn't parse were set: %+v", d)
+	}
+}
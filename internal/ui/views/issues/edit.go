@@ -1,6 +1,7 @@
 package issues
 
 import (
+	"github.com/brandonli/lazyliner/internal/editor"
 	"github.com/brandonli/lazyliner/internal/linear"
 	"github.com/brandonli/lazyliner/internal/ui/components"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
@@ -25,6 +26,7 @@ type EditModel struct {
 	states   []linear.WorkflowState
 	users    []linear.User
 	labels   []linear.Label
+	cycles   []linear.Cycle
 
 	// Selected values (indices)
 	selectedTeam     int
@@ -32,6 +34,11 @@ type EditModel struct {
 	selectedState    int
 	selectedPriority int
 	selectedAssignee int
+	selectedCycle    int
+	selectedLabels   []string
+
+	// Validation errors from the API, keyed by field index
+	fieldErrors map[int]string
 
 	// UI state
 	focusIndex int
@@ -39,8 +46,9 @@ type EditModel struct {
 	height     int
 
 	// Picker state
-	picker     *components.PickerModel
-	pickerType string // "state", "project", "priority", "assignee"
+	picker      *components.PickerModel
+	pickerType  string // "state", "project", "priority", "assignee", "cycle"
+	labelPicker *components.MultiPickerModel
 }
 
 // Edit field indices
@@ -51,11 +59,25 @@ const (
 	editFieldPriority
 	editFieldAssignee
 	editFieldProject
+	editFieldCycle
+	editFieldLabels
 	editFieldCount
 )
 
+// editInputFields maps IssueUpdateInput field names to form fields
+var editInputFields = map[string]int{
+	"title":       editFieldTitle,
+	"description": editFieldDescription,
+	"stateId":     editFieldState,
+	"priority":    editFieldPriority,
+	"assigneeId":  editFieldAssignee,
+	"projectId":   editFieldProject,
+	"cycleId":     editFieldCycle,
+	"labelIds":    editFieldLabels,
+}
+
 // NewEditModel creates a new edit model pre-populated with issue data
-func NewEditModel(issue *linear.Issue, teams []linear.Team, projects []linear.Project, states []linear.WorkflowState, users []linear.User, labels []linear.Label, width, height int) EditModel {
+func NewEditModel(issue *linear.Issue, teams []linear.Team, projects []linear.Project, states []linear.WorkflowState, users []linear.User, labels []linear.Label, cycles []linear.Cycle, width, height int) EditModel {
 	// Title input
 	ti := textinput.New()
 	ti.Placeholder = "Issue title"
@@ -113,6 +135,27 @@ func NewEditModel(issue *linear.Issue, teams []linear.Team, projects []linear.Pr
 		}
 	}
 
+	// Only current and upcoming cycles are loaded; keep the issue's own
+	// cycle selectable even if it has already ended
+	selectedCycle := -1 // -1 means no cycle
+	if issue.Cycle != nil {
+		selectedCycle = cycleIndex(cycles, issue.Cycle.ID)
+		if selectedCycle == -1 {
+			cycles = append([]linear.Cycle{*issue.Cycle}, cycles...)
+			selectedCycle = 0
+		}
+	}
+
+	// Labels from other teams aren't loaded; keep them on the issue
+	labels = labels[:len(labels):len(labels)]
+	var selectedLabels []string
+	for _, label := range issue.Labels {
+		selectedLabels = append(selectedLabels, label.ID)
+		if !HasLabel(labels, label.ID) {
+			labels = append(labels, label)
+		}
+	}
+
 	return EditModel{
 		issue:            issue,
 		titleInput:       ti,
@@ -122,11 +165,14 @@ func NewEditModel(issue *linear.Issue, teams []linear.Team, projects []linear.Pr
 		states:           states,
 		users:            users,
 		labels:           labels,
+		cycles:           cycles,
 		selectedTeam:     selectedTeam,
 		selectedProject:  selectedProject,
 		selectedState:    selectedState,
 		selectedPriority: issue.Priority,
 		selectedAssignee: selectedAssignee,
+		selectedCycle:    selectedCycle,
+		selectedLabels:   selectedLabels,
 		focusIndex:       editFieldTitle,
 		width:            width,
 		height:           height,
@@ -156,6 +202,9 @@ func (m EditModel) Update(msg tea.Msg) (EditModel, tea.Cmd) {
 		if m.picker != nil {
 			return m.updatePicker(msg)
 		}
+		if m.labelPicker != nil {
+			return m.updateLabelPicker(msg)
+		}
 
 		switch msg.String() {
 		case "tab", "down":
@@ -172,6 +221,9 @@ func (m EditModel) Update(msg tea.Msg) (EditModel, tea.Cmd) {
 			// Open picker for select fields
 			m.openPickerForField()
 		default:
+			// Editing a field clears its error
+			delete(m.fieldErrors, m.focusIndex)
+
 			// Forward to focused field
 			switch m.focusIndex {
 			case editFieldTitle:
@@ -204,7 +256,67 @@ func (m *EditModel) openPickerForField() {
 	case editFieldProject:
 		m.picker = components.NewPickerModel("Select Project", m.projectsToItems(), m.width, m.height)
 		m.pickerType = "project"
+	case editFieldCycle:
+		m.picker = components.NewPickerModel("Select Cycle", CyclesToItems(m.cycles), m.width, m.height)
+		m.pickerType = "cycle"
+	case editFieldLabels:
+		m.labelPicker = components.NewMultiPickerModel("Select Labels", LabelsToItems(m.labels), m.selectedLabels, m.width, m.height).EnableCreate()
+	}
+}
+
+// updateLabelPicker handles label picker interactions
+func (m EditModel) updateLabelPicker(msg tea.KeyMsg) (EditModel, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.labelPicker = nil
+		return m, nil
+	}
+
+	// Ignore keys while a new label is being created
+	if m.labelPicker.PendingCreate() != "" {
+		return m, nil
 	}
+
+	var cmd tea.Cmd
+	m.labelPicker, cmd = m.labelPicker.Update(msg)
+
+	if m.labelPicker.Confirmed() {
+		delete(m.fieldErrors, editFieldLabels)
+		m.selectedLabels = m.labelPicker.SelectedIDs()
+		m.labelPicker = nil
+		return m, nil
+	}
+
+	if name := m.labelPicker.PendingCreate(); name != "" {
+		teamID := ""
+		if m.issue.Team != nil {
+			teamID = m.issue.Team.ID
+		}
+		return m, func() tea.Msg {
+			return CreateLabelMsg{TeamID: teamID, Name: name}
+		}
+	}
+	return m, cmd
+}
+
+// AddLabel finishes creating a label requested from the label picker. A nil
+// label means creating it failed.
+func (m EditModel) AddLabel(label *linear.Label) EditModel {
+	if m.labelPicker == nil {
+		return m
+	}
+	if label == nil {
+		m.labelPicker.ResolveCreate(nil)
+		return m
+	}
+	m.labels = append(m.labels, *label)
+	item := LabelItem(*label)
+	m.labelPicker.ResolveCreate(&item)
+	return m
+}
+
+// PickerOpen returns true while a picker owns the keyboard
+func (m EditModel) PickerOpen() bool {
+	return m.picker != nil || m.labelPicker != nil
 }
 
 // updatePicker handles picker interactions
@@ -235,6 +347,8 @@ func (m EditModel) updatePicker(msg tea.KeyMsg) (EditModel, tea.Cmd) {
 
 // handlePickerSelection handles the selection from a picker
 func (m *EditModel) handlePickerSelection(item *components.PickerItem) {
+	delete(m.fieldErrors, m.focusIndex)
+
 	switch m.pickerType {
 	case "state":
 		for i, state := range m.states {
@@ -280,6 +394,8 @@ func (m *EditModel) handlePickerSelection(item *components.PickerItem) {
 				}
 			}
 		}
+	case "cycle":
+		m.selectedCycle = cycleIndex(m.cycles, item.ID)
 	}
 }
 
@@ -371,9 +487,30 @@ func (m *EditModel) handleLeftRight(dir int) {
 		m.selectedAssignee = clamp(m.selectedAssignee+dir, -1, len(m.users)-1)
 	case editFieldProject:
 		m.selectedProject = clamp(m.selectedProject+dir, -1, len(m.projects)-1)
+	case editFieldCycle:
+		m.selectedCycle = clamp(m.selectedCycle+dir, -1, len(m.cycles)-1)
 	}
 }
 
+// SetFieldErrors shows validation errors next to the offending fields and
+// focuses the first of them. Errors are keyed by IssueUpdateInput field name;
+// names that don't match a form field are ignored.
+func (m EditModel) SetFieldErrors(errs map[string]string) EditModel {
+	m.fieldErrors = make(map[int]string)
+	first := editFieldCount
+	for name, msg := range errs {
+		if field, ok := editInputFields[name]; ok {
+			m.fieldErrors[field] = msg
+			first = min(first, field)
+		}
+	}
+	if first < editFieldCount {
+		m.focusIndex = first
+		m.updateFocus()
+	}
+	return m
+}
+
 // GetIssueID returns the ID of the issue being edited
 func (m EditModel) GetIssueID() string {
 	if m.issue == nil {
@@ -382,6 +519,44 @@ func (m EditModel) GetIssueID() string {
 	return m.issue.ID
 }
 
+// EditorDraft returns the form as a markdown file for editing in $EDITOR
+func (m EditModel) EditorDraft() string {
+	var assignee *linear.User
+	if m.selectedAssignee >= 0 && m.selectedAssignee < len(m.users) {
+		assignee = &m.users[m.selectedAssignee]
+	}
+	var project *linear.Project
+	if m.selectedProject >= 0 && m.selectedProject < len(m.projects) {
+		project = &m.projects[m.selectedProject]
+	}
+	fields := draftFields(m.titleInput.Value(), m.selectedPriority, assignee, project, m.labels, m.selectedLabels)
+	return editor.Format(fields, m.descInput.Value())
+}
+
+// ApplyEditorDraft fills the form from a draft saved in $EDITOR. Values that
+// don't resolve, such as unknown labels, are shown as field errors and leave
+// their field as it was.
+func (m EditModel) ApplyEditorDraft(text string) EditModel {
+	d := parseDraft(text, m.users, m.projects, m.labels)
+	m.descInput.SetValue(d.description)
+	if d.title != nil {
+		m.titleInput.SetValue(*d.title)
+	}
+	if d.priority != nil {
+		m.selectedPriority = *d.priority
+	}
+	if d.assignee != nil {
+		m.selectedAssignee = *d.assignee
+	}
+	if d.project != nil {
+		m.selectedProject = *d.project
+	}
+	if d.labelIDs != nil {
+		m.selectedLabels = *d.labelIDs
+	}
+	return m.SetFieldErrors(d.errors)
+}
+
 // GetUpdateInput returns the current form input as IssueUpdateInput
 func (m EditModel) GetUpdateInput() linear.IssueUpdateInput {
 	title := m.titleInput.Value()
@@ -417,6 +592,22 @@ func (m EditModel) GetUpdateInput() linear.IssueUpdateInput {
 		input.AssigneeID = &assigneeID
 	}
 
+	// Cycle. "None" takes the issue out of its cycle.
+	if m.selectedCycle >= 0 && m.selectedCycle < len(m.cycles) {
+		cycleID := m.cycles[m.selectedCycle].ID
+		input.CycleID = &cycleID
+	} else if m.issue.Cycle != nil {
+		noCycle := ""
+		input.CycleID = &noCycle
+	}
+
+	// Labels, sent as changes so labels added elsewhere are kept
+	var before []string
+	for _, label := range m.issue.Labels {
+		before = append(before, label.ID)
+	}
+	input.AddedLabelIDs, input.RemovedLabelIDs = LabelChanges(before, m.selectedLabels)
+
 	return input
 }
 
@@ -426,6 +617,9 @@ func (m EditModel) View() string {
 	if m.picker != nil {
 		return m.picker.View()
 	}
+	if m.labelPicker != nil {
+		return m.labelPicker.View()
+	}
 
 	// Header
 	headerText := "Edit Issue"
@@ -488,8 +682,21 @@ func (m EditModel) View() string {
 	projectField := m.selectField(projectValue, m.focusIndex == editFieldProject)
 	fields = append(fields, projectLabel+"  "+projectField)
 
+	// Cycle
+	cycleLabel := m.fieldLabel("Cycle", editFieldCycle)
+	cycleValue := "None"
+	if m.selectedCycle >= 0 && m.selectedCycle < len(m.cycles) {
+		cycleValue = CycleLabel(m.cycles[m.selectedCycle])
+	}
+	cycleField := m.selectField(cycleValue, m.focusIndex == editFieldCycle)
+	fields = append(fields, cycleLabel+"  "+cycleField)
+
+	// Labels
+	labelsLabel := m.fieldLabel("Labels", editFieldLabels)
+	fields = append(fields, labelsLabel+"  "+labelsField(m.labels, m.selectedLabels, m.focusIndex == editFieldLabels))
+
 	// Help
-	help := theme.HelpStyle.Render("Tab: next  Enter: select  ←/→: quick change  Ctrl+S: save  Esc: cancel")
+	help := theme.HelpStyle.Render("Tab: next  Enter: select  ←/→: quick change  Ctrl+O: $EDITOR  Ctrl+S: save  Esc: cancel")
 
 	// Combine
 	content := lipgloss.JoinVertical(
@@ -514,7 +721,15 @@ func (m EditModel) fieldLabel(label string, fieldIndex int) string {
 	if m.focusIndex == fieldIndex {
 		style = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
 	}
-	return style.Render(label)
+	return style.Render(label) + fieldError(m.fieldErrors[fieldIndex])
+}
+
+// fieldError renders a validation error shown beside a field label
+func fieldError(msg string) string {
+	if msg == "" {
+		return ""
+	}
+	return "  " + theme.ErrorStyle.Render("⚠ "+msg)
 }
 
 // selectField renders a select field
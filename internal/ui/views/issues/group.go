@@ -0,0 +1,146 @@
+package issues
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// GroupBy is the field the issue list is grouped by
+type GroupBy string
+
+const (
+	GroupNone     GroupBy = "none"
+	GroupState    GroupBy = "state"
+	GroupAssignee GroupBy = "assignee"
+	GroupProject  GroupBy = "project"
+	GroupCycle    GroupBy = "cycle"
+	GroupLabel    GroupBy = "label"
+	GroupPriority GroupBy = "priority"
+)
+
+// GroupBys lists the groupings in menu order
+var GroupBys = []GroupBy{GroupNone, GroupState, GroupAssignee, GroupProject, GroupCycle, GroupLabel, GroupPriority}
+
+// Label returns the grouping's name as shown in menus
+func (g GroupBy) Label() string {
+	if g == GroupNone {
+		return "No grouping"
+	}
+	return strings.ToUpper(string(g[:1])) + string(g[1:])
+}
+
+// ParseGroupBy parses a grouping name; "" means no grouping
+func ParseGroupBy(s string) (GroupBy, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return GroupNone, true
+	}
+	for _, g := range GroupBys {
+		if string(g) == s {
+			return g, true
+		}
+	}
+	return GroupNone, false
+}
+
+// issueGroup is a run of issues sharing a group key in the list
+type issueGroup struct {
+	key   string
+	label string
+	icon  string
+	style lipgloss.Style // Style of the icon
+	rank  int            // Groups sort by rank, then label
+	start int            // Index of the group's first issue in the list
+	count int
+}
+
+// groupOf returns the group an issue belongs to. Issues with several labels
+// are grouped under the first one alphabetically; the latest cycles come first.
+func groupOf(issue linear.Issue, by GroupBy) issueGroup {
+	switch by {
+	case GroupState:
+		// States are grouped by name, so each team's "In Progress" shares a group
+		if issue.State == nil {
+			return issueGroup{key: "state:", label: "No state", icon: theme.StatusIcon(""), style: theme.StatusStyle(""), rank: 6}
+		}
+		return issueGroup{
+			key:   "state:" + strings.ToLower(issue.State.Name),
+			label: issue.State.Name,
+			icon:  theme.StatusIcon(issue.State.Type),
+			style: theme.StatusStyle(issue.State.Type),
+			rank:  StateTypeRank(issue.State.Type),
+		}
+	case GroupAssignee:
+		if issue.Assignee == nil {
+			return issueGroup{key: "assignee:", label: "No assignee", rank: 1}
+		}
+		return issueGroup{key: "assignee:" + issue.Assignee.ID, label: issue.Assignee.Name, icon: "@"}
+	case GroupProject:
+		if issue.Project == nil {
+			return issueGroup{key: "project:", label: "No project", rank: 1}
+		}
+		return issueGroup{key: "project:" + issue.Project.ID, label: issue.Project.Name, icon: "◆"}
+	case GroupCycle:
+		if issue.Cycle == nil {
+			return issueGroup{key: "cycle:", label: "No cycle", rank: 1}
+		}
+		return issueGroup{key: "cycle:" + issue.Cycle.ID, label: CycleLabel(*issue.Cycle), icon: "↻", rank: -issue.Cycle.Number}
+	case GroupLabel:
+		if len(issue.Labels) == 0 {
+			return issueGroup{key: "label:", label: "No label", rank: 1}
+		}
+		first := issue.Labels[0]
+		for _, label := range issue.Labels[1:] {
+			if strings.ToLower(label.Name) < strings.ToLower(first.Name) {
+				first = label
+			}
+		}
+		return issueGroup{key: "label:" + strings.ToLower(first.Name), label: first.Name, icon: "●", style: lipgloss.NewStyle().Foreground(lipgloss.Color(first.Color))}
+	case GroupPriority:
+		return issueGroup{
+			key:   "priority:" + theme.PriorityLabel(issue.Priority),
+			label: theme.PriorityLabel(issue.Priority),
+			icon:  theme.PriorityIcon(issue.Priority),
+			style: lipgloss.NewStyle().Foreground(theme.PriorityColor(issue.Priority)),
+			rank:  priorityRank(issue.Priority),
+		}
+	}
+	return issueGroup{}
+}
+
+// groupIssues orders issues into groups, keeping their order within each
+// group, and returns the reordered issues with the groups in display order
+func groupIssues(list []linear.Issue, by GroupBy) ([]linear.Issue, []issueGroup) {
+	if by == GroupNone || by == "" {
+		return list, nil
+	}
+
+	var groups []issueGroup
+	members := make(map[string][]linear.Issue)
+	for _, issue := range list {
+		g := groupOf(issue, by)
+		if _, ok := members[g.key]; !ok {
+			groups = append(groups, g)
+		}
+		members[g.key] = append(members[g.key], issue)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if c := cmp.Compare(groups[i].rank, groups[j].rank); c != 0 {
+			return c < 0
+		}
+		return strings.ToLower(groups[i].label) < strings.ToLower(groups[j].label)
+	})
+
+	ordered := make([]linear.Issue, 0, len(list))
+	for i := range groups {
+		groups[i].start = len(ordered)
+		groups[i].count = len(members[groups[i].key])
+		ordered = append(ordered, members[groups[i].key]...)
+	}
+	return ordered, groups
+}
@@ -0,0 +1,102 @@
+package issues
+
+import (
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CreateLabelMsg is emitted when the user asks to create a label from a
+// label picker
+type CreateLabelMsg struct {
+	TeamID string
+	Name   string
+}
+
+// LabelsToItems converts labels to picker items colored like the labels
+func LabelsToItems(labels []linear.Label) []components.PickerItem {
+	items := make([]components.PickerItem, len(labels))
+	for i, l := range labels {
+		items[i] = LabelItem(l)
+	}
+	return items
+}
+
+// LabelItem converts a label to a picker item
+func LabelItem(l linear.Label) components.PickerItem {
+	return components.PickerItem{
+		ID:    l.ID,
+		Label: l.Name,
+		Icon:  "🏷",
+		Desc:  l.Description,
+		Color: l.Color,
+	}
+}
+
+// LabelChanges returns the labels added and removed going from before to after
+func LabelChanges(before, after []string) (added, removed []string) {
+	for _, id := range after {
+		if !containsID(before, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range before {
+		if !containsID(after, id) {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// containsID reports whether ids contains id
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLabel reports whether labels contains the label with the given ID
+func HasLabel(labels []linear.Label, id string) bool {
+	for _, label := range labels {
+		if label.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// labelChips renders the labels with the given IDs as colored chips
+func labelChips(labels []linear.Label, ids []string) string {
+	var chips []string
+	for _, id := range ids {
+		for _, label := range labels {
+			if label.ID == id {
+				style := theme.LabelStyle
+				if label.Color != "" {
+					style = style.Background(lipgloss.Color(label.Color))
+				}
+				chips = append(chips, style.Render(label.Name))
+				break
+			}
+		}
+	}
+	return strings.Join(chips, " ")
+}
+
+// labelsField renders the value of a labels form field
+func labelsField(labels []linear.Label, ids []string, focused bool) string {
+	value := labelChips(labels, ids)
+	if value == "" {
+		value = theme.TextMutedStyle.Render("None")
+	}
+	if focused {
+		value += theme.TextDimStyle.Render("  enter: choose labels")
+	}
+	return value
+}
@@ -6,12 +6,32 @@ import (
 
 	"github.com/brandonli/lazyliner/internal/linear"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
-	"github.com/brandonli/lazyliner/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 )
 
+// SyncState describes whether an issue has offline edits not yet sent to Linear
+type SyncState int
+
+const (
+	SyncNone     SyncState = iota
+	SyncPending            // Edits are queued for replay
+	SyncConflict           // Replay found the issue changed on the server
+)
+
+// syncMarker returns the row marker for a sync state
+func syncMarker(state SyncState) string {
+	switch state {
+	case SyncPending:
+		return theme.WarningStyle.Render("⏳") + " "
+	case SyncConflict:
+		return theme.ErrorStyle.Render("⚠") + " "
+	default:
+		return ""
+	}
+}
+
 // ListModel is the issue list view
 type ListModel struct {
 	issues      []linear.Issue
@@ -21,6 +41,25 @@ type ListModel struct {
 	height      int
 	pageSize    int
 	hasNextPage bool
+	pageLocal   bool // The layout orders issues differently from the API, so it only covers the loaded pages
+	syncStates  map[string]SyncState
+	columns     []ColumnSpec
+
+	// Multi-select state: selected issue IDs and the row a range starts from
+	selected map[string]bool
+	anchor   int
+
+	// Grouping. rows are the lines shown: group headers and the issues of
+	// expanded groups, in display order.
+	groups    []issueGroup
+	collapsed map[string]bool // Collapsed group keys
+	rows      []listRow
+}
+
+// listRow is a line of the list: a group header or an issue
+type listRow struct {
+	group int // Index into groups, or -1 when the list isn't grouped
+	issue int // Index into issues, or -1 for a group header
 }
 
 // NewListModel creates a new list model
@@ -29,7 +68,7 @@ func NewListModel(issues []linear.Issue, width, height int) ListModel {
 	if pageSize < 1 {
 		pageSize = 10
 	}
-	return ListModel{
+	m := ListModel{
 		issues:      issues,
 		cursor:      0,
 		offset:      0,
@@ -37,7 +76,10 @@ func NewListModel(issues []linear.Issue, width, height int) ListModel {
 		height:      height,
 		pageSize:    pageSize,
 		hasNextPage: false,
+		columns:     DefaultColumns(),
 	}
+	m.buildRows()
+	return m
 }
 
 // NewListModelWithPagination creates a new list model with pagination info
@@ -58,11 +100,301 @@ func (m ListModel) SetSize(width, height int) ListModel {
 	return m
 }
 
+// SetLayout sorts and groups the issues, collapsing the groups whose keys
+// are set in collapsed
+func (m ListModel) SetLayout(order SortOrder, by GroupBy, collapsed map[string]bool) ListModel {
+	m.collapsed = collapsed
+	// Pages come from the API most recently updated first
+	m.pageLocal = order != SortUpdated || by != GroupNone
+	m.issues, m.groups = groupIssues(SortIssues(m.issues, order), by)
+	m.buildRows()
+	return m
+}
+
+// MoveToIssue moves the cursor to an issue, or to its group's header if the
+// group is collapsed
+func (m ListModel) MoveToIssue(id string) ListModel {
+	m.moveToIssue(id)
+	return m
+}
+
+// Collapsed returns the keys of the collapsed groups
+func (m ListModel) Collapsed() map[string]bool {
+	return m.collapsed
+}
+
+// buildRows lays out the rows from the groups and which are collapsed
+func (m *ListModel) buildRows() {
+	rows := make([]listRow, 0, len(m.issues)+len(m.groups))
+	if len(m.groups) == 0 {
+		for i := range m.issues {
+			rows = append(rows, listRow{group: -1, issue: i})
+		}
+	}
+	for gi, g := range m.groups {
+		rows = append(rows, listRow{group: gi, issue: -1})
+		if m.collapsed[g.key] {
+			continue
+		}
+		for i := g.start; i < g.start+g.count; i++ {
+			rows = append(rows, listRow{group: gi, issue: i})
+		}
+	}
+	m.rows = rows
+
+	m.cursor = max(min(m.cursor, len(m.rows)-1), 0)
+	m.anchor = min(m.anchor, len(m.rows)-1)
+	m.scrollToCursor()
+}
+
+// scrollToCursor scrolls the list so the cursor's row is visible
+func (m *ListModel) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.pageSize {
+		m.offset = m.cursor - m.pageSize + 1
+	}
+}
+
+// moveToIssue moves the cursor to an issue, or to its group's header if the
+// group is collapsed
+func (m *ListModel) moveToIssue(id string) {
+	for i, row := range m.rows {
+		if row.issue >= 0 && m.issues[row.issue].ID == id {
+			m.cursor = i
+			m.scrollToCursor()
+			return
+		}
+	}
+	for i, issue := range m.issues {
+		if issue.ID == id {
+			m.moveToGroup(m.groupIndexOf(i))
+			return
+		}
+	}
+}
+
+// moveToGroup moves the cursor to a group's header
+func (m *ListModel) moveToGroup(group int) {
+	for i, row := range m.rows {
+		if row.group == group && row.issue < 0 {
+			m.cursor = i
+			m.scrollToCursor()
+			return
+		}
+	}
+}
+
+// groupIndexOf returns the index of the group holding issue i
+func (m ListModel) groupIndexOf(i int) int {
+	for gi, g := range m.groups {
+		if i >= g.start && i < g.start+g.count {
+			return gi
+		}
+	}
+	return -1
+}
+
+// toggleGroup collapses or expands the group under the cursor
+func (m *ListModel) toggleGroup() {
+	if m.cursor < 0 || m.cursor >= len(m.rows) || m.rows[m.cursor].group < 0 {
+		return
+	}
+	group := m.rows[m.cursor].group
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	key := m.groups[group].key
+	m.collapsed[key] = !m.collapsed[key]
+	m.buildRows()
+	m.moveToGroup(group)
+}
+
+// toggleAllGroups collapses every group, or expands them all when they are
+// already collapsed
+func (m *ListModel) toggleAllGroups() {
+	if len(m.groups) == 0 {
+		return
+	}
+	group := -1
+	if m.cursor >= 0 && m.cursor < len(m.rows) {
+		group = m.rows[m.cursor].group
+	}
+
+	collapse := false
+	for _, g := range m.groups {
+		if !m.collapsed[g.key] {
+			collapse = true
+		}
+	}
+	m.collapsed = make(map[string]bool)
+	if collapse {
+		for _, g := range m.groups {
+			m.collapsed[g.key] = true
+		}
+	}
+	m.buildRows()
+	m.moveToGroup(group)
+}
+
+// SetColumns sets the columns shown, in order
+func (m ListModel) SetColumns(columns []ColumnSpec) ListModel {
+	m.columns = columns
+	return m
+}
+
+// SetSyncStates marks issues that have unsynced offline edits
+func (m ListModel) SetSyncStates(states map[string]SyncState) ListModel {
+	m.syncStates = states
+	return m
+}
+
+// SetSelection restores a multi-selection, dropping IDs not in the list
+func (m ListModel) SetSelection(ids []string) ListModel {
+	m.selected = make(map[string]bool)
+	present := make(map[string]bool, len(m.issues))
+	for _, issue := range m.issues {
+		present[issue.ID] = true
+	}
+	for _, id := range ids {
+		if present[id] {
+			m.selected[id] = true
+		}
+	}
+	return m
+}
+
+// HasSelection reports whether any issues are multi-selected
+func (m ListModel) HasSelection() bool {
+	return len(m.selected) > 0
+}
+
+// SelectionCount returns the number of multi-selected issues
+func (m ListModel) SelectionCount() int {
+	return len(m.selected)
+}
+
+// SelectedIDs returns the IDs of the multi-selected issues in list order
+func (m ListModel) SelectedIDs() []string {
+	var ids []string
+	for _, issue := range m.issues {
+		if m.selected[issue.ID] {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids
+}
+
+// SelectedIssues returns the multi-selected issues in list order
+func (m ListModel) SelectedIssues() []linear.Issue {
+	var selected []linear.Issue
+	for _, issue := range m.issues {
+		if m.selected[issue.ID] {
+			selected = append(selected, issue)
+		}
+	}
+	return selected
+}
+
+// ClearSelection drops the multi-selection
+func (m ListModel) ClearSelection() ListModel {
+	m.selected = nil
+	return m
+}
+
+// rowIssues returns the issues a row stands for: its issue, or every issue
+// in the group for a header
+func (m ListModel) rowIssues(row listRow) []linear.Issue {
+	if row.issue >= 0 {
+		return m.issues[row.issue : row.issue+1]
+	}
+	g := m.groups[row.group]
+	return m.issues[g.start : g.start+g.count]
+}
+
+// toggleSelection selects or deselects the issue under the cursor, or every
+// issue in the group on a header, and makes it the anchor for range selection
+func (m *ListModel) toggleSelection() {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[string]bool)
+	}
+	rowIssues := m.rowIssues(m.rows[m.cursor])
+	allSelected := true
+	for _, issue := range rowIssues {
+		allSelected = allSelected && m.selected[issue.ID]
+	}
+	for _, issue := range rowIssues {
+		if allSelected {
+			delete(m.selected, issue.ID)
+		} else {
+			m.selected[issue.ID] = true
+		}
+	}
+	m.anchor = m.cursor
+}
+
+// selectRange selects every issue between the anchor and the cursor,
+// including those in collapsed groups
+func (m *ListModel) selectRange() {
+	if len(m.rows) == 0 {
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[string]bool)
+	}
+	from, to := min(m.anchor, m.cursor), max(m.anchor, m.cursor)
+	to = min(to, len(m.rows)-1)
+	for i := max(from, 0); i <= to; i++ {
+		row := m.rows[i]
+		if row.issue < 0 && !m.collapsed[m.groups[row.group].key] {
+			continue
+		}
+		for _, issue := range m.rowIssues(row) {
+			m.selected[issue.ID] = true
+		}
+	}
+}
+
+// toggleAll selects every issue in the list, or clears the selection when
+// everything is already selected
+func (m *ListModel) toggleAll() {
+	if len(m.selected) == len(m.issues) {
+		m.selected = nil
+		return
+	}
+	m.selected = make(map[string]bool, len(m.issues))
+	for _, issue := range m.issues {
+		m.selected[issue.ID] = true
+	}
+}
+
 // Update handles messages
 func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
+		case " ":
+			m.toggleSelection()
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+				if m.cursor >= m.offset+m.pageSize {
+					m.offset = m.cursor - m.pageSize + 1
+				}
+			}
+		case "v":
+			m.selectRange()
+		case "ctrl+a":
+			m.toggleAll()
+		case "esc":
+			m.selected = nil
+		case "z":
+			m.toggleGroup()
+		case "Z":
+			m.toggleAllGroups()
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
@@ -71,7 +403,7 @@ func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 				}
 			}
 		case "down", "j":
-			if m.cursor < len(m.issues)-1 {
+			if m.cursor < len(m.rows)-1 {
 				m.cursor++
 				if m.cursor >= m.offset+m.pageSize {
 					m.offset = m.cursor - m.pageSize + 1
@@ -81,7 +413,7 @@ func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 			m.cursor = 0
 			m.offset = 0
 		case "end", "G":
-			m.cursor = len(m.issues) - 1
+			m.cursor = len(m.rows) - 1
 			if m.cursor >= m.pageSize {
 				m.offset = m.cursor - m.pageSize + 1
 			}
@@ -93,8 +425,8 @@ func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 			m.offset = m.cursor
 		case "pgdown", "ctrl+d":
 			m.cursor += m.pageSize
-			if m.cursor >= len(m.issues) {
-				m.cursor = len(m.issues) - 1
+			if m.cursor >= len(m.rows) {
+				m.cursor = len(m.rows) - 1
 			}
 			if m.cursor >= m.offset+m.pageSize {
 				m.offset = m.cursor - m.pageSize + 1
@@ -104,10 +436,10 @@ func (m ListModel) Update(msg tea.Msg) (ListModel, tea.Cmd) {
 	return m, nil
 }
 
-// SelectedIssue returns the currently selected issue
+// SelectedIssue returns the currently selected issue, or nil on a group header
 func (m ListModel) SelectedIssue() *linear.Issue {
-	if m.cursor >= 0 && m.cursor < len(m.issues) {
-		return &m.issues[m.cursor]
+	if m.cursor >= 0 && m.cursor < len(m.rows) && m.rows[m.cursor].issue >= 0 {
+		return &m.issues[m.rows[m.cursor].issue]
 	}
 	return nil
 }
@@ -128,34 +460,43 @@ func (m ListModel) View() string {
 
 	// Calculate visible range
 	end := m.offset + m.pageSize
-	if end > len(m.issues) {
-		end = len(m.issues)
+	if end > len(m.rows) {
+		end = len(m.rows)
 	}
 
-	// Column widths
-	idWidth := 10
-	titleWidth := m.width - idWidth - 30 // Leave room for priority and status
-	if titleWidth < 20 {
-		titleWidth = 20
-	}
-	priorityWidth := 10
-	statusWidth := 15
+	columns := layoutColumns(m.columns, m.width)
 
 	for i := m.offset; i < end; i++ {
-		issue := m.issues[i]
 		isSelected := i == m.cursor
+		if m.rows[i].issue < 0 {
+			rows = append(rows, m.renderHeader(m.groups[m.rows[i].group], isSelected))
+			continue
+		}
 
-		row := m.renderRow(issue, isSelected, idWidth, titleWidth, priorityWidth, statusWidth)
+		issue := m.issues[m.rows[i].issue]
+		row := m.renderRow(issue, isSelected, columns)
 		rows = append(rows, row)
 	}
 
 	scrollInfo := ""
-	if len(m.issues) > m.pageSize || m.hasNextPage {
+	if len(m.rows) > m.pageSize || m.hasNextPage {
 		suffix := ""
 		if m.hasNextPage {
 			suffix = "+ (L to load more)"
+			if m.pageLocal {
+				suffix = "+ (sorted within loaded, L to load more)"
+			}
 		}
-		scrollInfo = theme.TextDimStyle.Render(fmt.Sprintf(" %d/%d%s ", m.cursor+1, len(m.issues), suffix))
+		// Count issues rather than rows; a header counts as its first issue
+		position := m.rows[m.cursor].issue
+		if position < 0 {
+			position = m.groups[m.rows[m.cursor].group].start
+		}
+		scrollInfo = theme.TextDimStyle.Render(fmt.Sprintf(" %d/%d%s ", position+1, len(m.issues), suffix))
+	}
+	if len(m.selected) > 0 {
+		scrollInfo = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).
+			Render(fmt.Sprintf(" %d selected ", len(m.selected))) + scrollInfo
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
@@ -169,8 +510,28 @@ func (m ListModel) View() string {
 	return content
 }
 
+// renderHeader renders a group's header row
+func (m ListModel) renderHeader(g issueGroup, isSelected bool) string {
+	baseStyle := theme.ListItemStyle
+	if isSelected {
+		baseStyle = theme.ListItemSelectedStyle
+	}
+	fold := "▾ "
+	if m.collapsed[g.key] {
+		fold = "▸ "
+	}
+
+	label := lipgloss.NewStyle().Foreground(theme.TextBright).Bold(true).Render(g.label)
+	if g.icon != "" {
+		label = g.style.Render(g.icon) + " " + label
+	}
+	count := theme.TextDimStyle.Render(fmt.Sprintf("%d", g.count))
+
+	return baseStyle.Width(m.width).Render(fold + label + "  " + count)
+}
+
 // renderRow renders a single issue row
-func (m ListModel) renderRow(issue linear.Issue, isSelected bool, idWidth, titleWidth, priorityWidth, statusWidth int) string {
+func (m ListModel) renderRow(issue linear.Issue, isSelected bool, columns []ColumnSpec) string {
 	baseStyle := theme.ListItemStyle
 	if isSelected {
 		baseStyle = theme.ListItemSelectedStyle
@@ -178,54 +539,21 @@ func (m ListModel) renderRow(issue linear.Issue, isSelected bool, idWidth, title
 	cursor := "○ "
 	if isSelected {
 		cursor = "● "
-	} else {
-		cursor = "○ "
+	}
+	if m.selected[issue.ID] {
+		cursor = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true).Render("✓") + " "
 	}
 
-	// Issue ID
-	id := theme.IssueIDStyle.Render(util.Truncate(issue.Identifier, idWidth))
+	cells := make([]string, len(columns))
+	for i, col := range columns {
+		cell := m.renderCell(issue, col, isSelected)
+		if i < len(columns)-1 {
+			cell = padRight(cell, col.Width)
+		}
+		cells[i] = cell
+	}
 
-	// Title
-	title := util.Truncate(issue.Title, titleWidth)
-	if isSelected {
-		title = lipgloss.NewStyle().Foreground(theme.TextBright).Render(title)
-	} else {
-		title = lipgloss.NewStyle().Foreground(theme.Text).Render(title)
-	}
-
-	// Priority
-	priorityIcon := theme.PriorityIcon(issue.Priority)
-	priorityLabel := theme.PriorityLabel(issue.Priority)
-	priority := lipgloss.NewStyle().
-		Foreground(theme.PriorityColor(issue.Priority)).
-		Width(priorityWidth).
-		Render(priorityIcon + " " + priorityLabel)
-
-	// Status
-	var statusName string
-	var statusType string
-	if issue.State != nil {
-		statusName = issue.State.Name
-		statusType = issue.State.Type
-	} else {
-		statusName = "Unknown"
-		statusType = ""
-	}
-	statusIcon := theme.StatusIcon(statusType)
-	status := theme.StatusStyle(statusType).
-		Width(statusWidth).
-		Render(statusIcon + " " + util.Truncate(statusName, statusWidth-3))
-
-	// Build row
-	row := fmt.Sprintf("%s%s  %s  %s  %s",
-		cursor,
-		padRight(id, idWidth),
-		padRight(title, titleWidth),
-		priority,
-		status,
-	)
-
-	return baseStyle.Width(m.width).Render(row)
+	return baseStyle.Width(m.width).Render(cursor + strings.Join(cells, "  "))
 }
 
 func padRight(s string, width int) string {
@@ -0,0 +1,109 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/components"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+)
+
+// RelationLabels are the section headings for each relation kind
+var RelationLabels = map[linear.RelationKind]string{
+	linear.RelationBlockedBy:    "Blocked by",
+	linear.RelationBlocks:       "Blocks",
+	linear.RelationDuplicateOf:  "Duplicate of",
+	linear.RelationDuplicatedBy: "Duplicated by",
+	linear.RelationRelated:      "Related",
+}
+
+// blockedMarker marks an unfinished issue that is blocked by another
+func blockedMarker(issue linear.Issue) string {
+	if issue.IsFinished() || !issue.IsBlocked() {
+		return ""
+	}
+	return theme.ErrorStyle.Render("⛔") + " "
+}
+
+// SetRelations sets the issue's relations to other issues
+func (m DetailModel) SetRelations(relations []linear.RelatedIssue) DetailModel {
+	m.relations = relations
+	m.clampLinkCursor()
+	return m
+}
+
+// Relations returns the issue's relations to other issues
+func (m DetailModel) Relations() []linear.RelatedIssue {
+	return m.relations
+}
+
+// hasOpenBlocker reports whether an unfinished issue blocks this one
+func (m DetailModel) hasOpenBlocker() bool {
+	for _, rel := range m.relations {
+		if rel.Kind == linear.RelationBlockedBy && !rel.Issue.IsFinished() {
+			return true
+		}
+	}
+	return false
+}
+
+// RelationsToItems converts relations to picker items, keyed by relation ID
+func RelationsToItems(relations []linear.RelatedIssue) []components.PickerItem {
+	items := make([]components.PickerItem, 0, len(relations))
+	for _, rel := range relations {
+		items = append(items, components.PickerItem{
+			ID:    rel.RelationID,
+			Label: rel.Issue.Identifier + " " + rel.Issue.Title,
+			Desc:  RelationLabels[rel.Kind],
+		})
+	}
+	return items
+}
+
+// IssuesToItems converts issues to picker items, skipping the issue with excludeID
+func IssuesToItems(issues []linear.Issue, excludeID string) []components.PickerItem {
+	items := make([]components.PickerItem, 0, len(issues))
+	for _, issue := range issues {
+		if issue.ID == excludeID {
+			continue
+		}
+		icon := theme.StatusIcon("")
+		if issue.State != nil {
+			icon = theme.StatusIcon(issue.State.Type)
+		}
+		items = append(items, components.PickerItem{
+			ID:    issue.ID,
+			Label: issue.Identifier + " " + issue.Title,
+			Icon:  icon,
+		})
+	}
+	return items
+}
+
+// renderRelations renders the relations grouped by kind, returning the line
+// offset of each row within the section
+func (m DetailModel) renderRelations() (string, []int) {
+	if len(m.relations) == 0 {
+		return "", nil
+	}
+
+	heading := theme.SubtitleStyle.Bold(true).Render(fmt.Sprintf("Relations (%d)", len(m.relations)))
+	lines := []string{heading}
+
+	var offsets []int
+	var kind linear.RelationKind
+	for i, rel := range m.relations {
+		if rel.Kind != kind {
+			kind = rel.Kind
+			group := theme.TextMutedStyle.Render("  " + RelationLabels[kind])
+			if kind == linear.RelationBlockedBy && m.hasOpenBlocker() {
+				group = theme.ErrorStyle.Render("  ⛔ " + RelationLabels[kind])
+			}
+			lines = append(lines, group)
+		}
+		offsets = append(offsets, len(lines))
+		lines = append(lines, m.renderLinkedIssue(rel.Issue, "  ", i == m.linkCursor))
+	}
+	return strings.Join(lines, "\n"), offsets
+}
@@ -0,0 +1,163 @@
+package issues
+
+import (
+	"cmp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+)
+
+// SortOrder is the order the issue list is sorted in
+type SortOrder string
+
+const (
+	SortStatus     SortOrder = "status"     // Incomplete first, then priority, then recently updated
+	SortPriority   SortOrder = "priority"   // Urgent first, no priority last
+	SortDue        SortOrder = "due"        // Soonest due first, no due date last
+	SortCreated    SortOrder = "created"    // Newest first
+	SortUpdated    SortOrder = "updated"    // Recently updated first
+	SortEstimate   SortOrder = "estimate"   // Largest first, unestimated last
+	SortIdentifier SortOrder = "identifier" // By team, then issue number
+	SortManual     SortOrder = "manual"     // The order issues were dragged into in Linear
+)
+
+// SortOrders lists the sort orders in menu order
+var SortOrders = []SortOrder{SortStatus, SortPriority, SortDue, SortCreated, SortUpdated, SortEstimate, SortIdentifier, SortManual}
+
+// Label returns the sort order's name as shown in menus
+func (o SortOrder) Label() string {
+	switch o {
+	case SortDue:
+		return "Due date"
+	case SortManual:
+		return "Manual"
+	}
+	return strings.ToUpper(string(o[:1])) + string(o[1:])
+}
+
+// ParseSortOrder parses a sort order name; "" is the default status order
+func ParseSortOrder(s string) (SortOrder, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return SortStatus, true
+	}
+	for _, o := range SortOrders {
+		if string(o) == s {
+			return o, true
+		}
+	}
+	return SortStatus, false
+}
+
+// StateTypeRank returns the sort rank of a workflow state type. Lower values
+// appear first; incomplete states come before completed ones.
+func StateTypeRank(stateType string) int {
+	switch stateType {
+	case "started":
+		return 0 // In progress - highest priority
+	case "unstarted":
+		return 1 // Not yet started
+	case "backlog":
+		return 2 // Backlog items
+	case "triage":
+		return 3 // Triage items
+	case "completed":
+		return 4 // Done
+	case "canceled":
+		return 5 // Canceled - lowest priority
+	default:
+		return 3 // Unknown states go in the middle
+	}
+}
+
+// SortIssues returns a copy of issues sorted in order. Ties keep the status
+// order's tie-breakers, so issues never jump around between refreshes.
+func SortIssues(list []linear.Issue, order SortOrder) []linear.Issue {
+	sorted := make([]linear.Issue, len(list))
+	copy(sorted, list)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := &sorted[i], &sorted[j]
+		if c := compareIssues(a, b, order); c != 0 {
+			return c < 0
+		}
+		// Fall back to priority, then most recently updated
+		if c := cmp.Compare(priorityRank(a.Priority), priorityRank(b.Priority)); c != 0 {
+			return c < 0
+		}
+		return a.UpdatedAt.After(b.UpdatedAt)
+	})
+
+	return sorted
+}
+
+// compareIssues compares two issues by the primary key of a sort order
+func compareIssues(a, b *linear.Issue, order SortOrder) int {
+	switch order {
+	case SortPriority:
+		return cmp.Compare(priorityRank(a.Priority), priorityRank(b.Priority))
+	case SortDue:
+		// ISO dates sort as strings; issues without one go last
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+			return 0
+		case a.DueDate == nil:
+			return 1
+		case b.DueDate == nil:
+			return -1
+		}
+		return strings.Compare(*a.DueDate, *b.DueDate)
+	case SortCreated:
+		return -a.CreatedAt.Compare(b.CreatedAt)
+	case SortUpdated:
+		return -a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortEstimate:
+		switch {
+		case a.Estimate == nil && b.Estimate == nil:
+			return 0
+		case a.Estimate == nil:
+			return 1
+		case b.Estimate == nil:
+			return -1
+		}
+		return cmp.Compare(*b.Estimate, *a.Estimate)
+	case SortIdentifier:
+		teamA, numberA := splitIdentifier(a.Identifier)
+		teamB, numberB := splitIdentifier(b.Identifier)
+		if c := strings.Compare(teamA, teamB); c != 0 {
+			return c
+		}
+		return cmp.Compare(numberA, numberB)
+	case SortManual:
+		return cmp.Compare(a.SortOrder, b.SortOrder)
+	default:
+		stateA, stateB := "unstarted", "unstarted"
+		if a.State != nil {
+			stateA = a.State.Type
+		}
+		if b.State != nil {
+			stateB = b.State.Type
+		}
+		return cmp.Compare(StateTypeRank(stateA), StateTypeRank(stateB))
+	}
+}
+
+// priorityRank orders priorities urgent first, with no priority (0) last
+func priorityRank(priority int) int {
+	if priority == 0 {
+		return 5
+	}
+	return priority
+}
+
+// splitIdentifier splits an identifier such as ENG-123 into its team key and number
+func splitIdentifier(identifier string) (string, int) {
+	dash := strings.LastIndexByte(identifier, '-')
+	if dash < 0 {
+		return identifier, 0
+	}
+	number, _ := strconv.Atoi(identifier[dash+1:])
+	return identifier[:dash], number
+}
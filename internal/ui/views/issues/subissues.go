@@ -0,0 +1,246 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/theme"
+	"github.com/brandonli/lazyliner/internal/util"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// OpenIssueMsg is emitted when the user navigates to a parent or sub-issue
+type OpenIssueMsg struct {
+	IssueID string
+}
+
+// treeRow is a visible row of the sub-issue tree
+type treeRow struct {
+	issue *linear.Issue
+	depth int
+}
+
+// SetHierarchy sets the issue's ancestors and sub-issue tree, as returned by
+// linear.Client.GetIssueHierarchy
+func (m DetailModel) SetHierarchy(hierarchy *linear.Issue) DetailModel {
+	m.ancestors = nil
+	for parent := hierarchy.Parent; parent != nil; parent = parent.Parent {
+		m.ancestors = append([]linear.Issue{*parent}, m.ancestors...)
+	}
+	m.children = hierarchy.Children
+	m.clampLinkCursor()
+	return m
+}
+
+// parentID returns the ID of the issue's parent, or "" if it has none
+func (m DetailModel) parentID() string {
+	if len(m.ancestors) > 0 {
+		return m.ancestors[len(m.ancestors)-1].ID
+	}
+	if m.issue != nil && m.issue.Parent != nil {
+		return m.issue.Parent.ID
+	}
+	return ""
+}
+
+// treeRows returns the visible rows of the sub-issue tree, depth first
+func (m DetailModel) treeRows() []treeRow {
+	if m.treeCollapsed {
+		return nil
+	}
+	var rows []treeRow
+	var walk func(issues []linear.Issue, depth int)
+	walk = func(issues []linear.Issue, depth int) {
+		for i := range issues {
+			rows = append(rows, treeRow{issue: &issues[i], depth: depth})
+			if !m.collapsed[issues[i].ID] {
+				walk(issues[i].Children, depth+1)
+			}
+		}
+	}
+	walk(m.children, 0)
+	return rows
+}
+
+// linkCount returns the number of selectable relation and sub-issue rows
+func (m DetailModel) linkCount() int {
+	return len(m.relations) + len(m.treeRows())
+}
+
+// clampLinkCursor keeps the linked issue selection within the rows
+func (m *DetailModel) clampLinkCursor() {
+	if count := m.linkCount(); m.linkCursor >= count {
+		m.linkCursor = count - 1
+	}
+}
+
+// selectedSubIssue returns the selected row of the sub-issue tree, if any
+func (m DetailModel) selectedSubIssue() *treeRow {
+	rows := m.treeRows()
+	i := m.linkCursor - len(m.relations)
+	if m.linkCursor < 0 || i < 0 || i >= len(rows) {
+		return nil
+	}
+	return &rows[i]
+}
+
+// selectedLinkedIssue returns the selected related issue or sub-issue, if any
+func (m DetailModel) selectedLinkedIssue() *linear.Issue {
+	if m.linkCursor >= 0 && m.linkCursor < len(m.relations) {
+		return m.relations[m.linkCursor].Issue
+	}
+	if row := m.selectedSubIssue(); row != nil {
+		return row.issue
+	}
+	return nil
+}
+
+// updateLinks handles the relation and sub-issue keys
+func (m DetailModel) updateLinks(key string) (DetailModel, tea.Cmd) {
+	switch key {
+	case "}":
+		if m.linkCursor < m.linkCount()-1 {
+			m.linkCursor++
+		}
+	case "{":
+		if m.linkCursor > 0 {
+			m.linkCursor--
+		}
+	case "z":
+		// Collapse or expand the selected sub-issue's children
+		if row := m.selectedSubIssue(); row != nil && len(row.issue.Children) > 0 {
+			if m.collapsed == nil {
+				m.collapsed = make(map[string]bool)
+			}
+			m.collapsed[row.issue.ID] = !m.collapsed[row.issue.ID]
+		}
+		return m, nil
+	case "Z":
+		m.treeCollapsed = !m.treeCollapsed
+		m.clampLinkCursor()
+		return m, nil
+	case "enter":
+		if issue := m.selectedLinkedIssue(); issue != nil {
+			return m, openIssue(issue.ID)
+		}
+		return m, nil
+	case "u":
+		if id := m.parentID(); id != "" {
+			return m, openIssue(id)
+		}
+		return m, nil
+	}
+
+	_, offsets := m.renderContent()
+	if m.linkCursor >= 0 && m.linkCursor < len(offsets.links) {
+		m.scrollToLine(offsets.links[m.linkCursor])
+	}
+	return m, nil
+}
+
+// openIssue returns a command emitting OpenIssueMsg
+func openIssue(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		return OpenIssueMsg{IssueID: issueID}
+	}
+}
+
+// renderBreadcrumb renders the chain of parent issues above the title
+func (m DetailModel) renderBreadcrumb() string {
+	ancestors := m.ancestors
+	if len(ancestors) == 0 && m.issue.Parent != nil {
+		ancestors = []linear.Issue{*m.issue.Parent}
+	}
+	if len(ancestors) == 0 {
+		return ""
+	}
+
+	maxTitle := (m.width - 20) / len(ancestors)
+	var crumbs []string
+	for _, parent := range ancestors {
+		crumb := theme.IssueIDStyle.Render(parent.Identifier)
+		if maxTitle > 12 {
+			crumb += theme.TextMutedStyle.Render(" " + util.Truncate(parent.Title, maxTitle-len(parent.Identifier)-1))
+		}
+		crumbs = append(crumbs, crumb)
+	}
+	return theme.TextMutedStyle.Render("↑ ") + strings.Join(crumbs, theme.TextDimStyle.Render(" › "))
+}
+
+// countSubIssues counts the sub-issues in a tree and how many of them are done
+func countSubIssues(issues []linear.Issue) (done, total int) {
+	for _, issue := range issues {
+		total++
+		if issue.State != nil && (issue.State.Type == "completed" || issue.State.Type == "canceled") {
+			done++
+		}
+		d, t := countSubIssues(issue.Children)
+		done += d
+		total += t
+	}
+	return done, total
+}
+
+// renderSubIssues renders the sub-issue tree, returning the line offset of
+// each row within the section
+func (m DetailModel) renderSubIssues() (string, []int) {
+	if len(m.children) == 0 {
+		return "", nil
+	}
+
+	done, total := countSubIssues(m.children)
+	fold := "▾ "
+	if m.treeCollapsed {
+		fold = "▸ "
+	}
+	heading := theme.SubtitleStyle.Bold(true).Render(fmt.Sprintf("%sSub-issues (%d/%d done)", fold, done, total))
+	lines := []string{heading}
+
+	var offsets []int
+	for i, row := range m.treeRows() {
+		offsets = append(offsets, len(lines))
+		lines = append(lines, m.renderSubIssue(row, len(m.relations)+i == m.linkCursor))
+	}
+	return strings.Join(lines, "\n"), offsets
+}
+
+// renderSubIssue renders a single row of the sub-issue tree
+func (m DetailModel) renderSubIssue(row treeRow, selected bool) string {
+	fold := "  "
+	if len(row.issue.Children) > 0 {
+		fold = "▾ "
+		if m.collapsed[row.issue.ID] {
+			fold = "▸ "
+		}
+	}
+	indent := strings.Repeat("  ", row.depth) + theme.TextMutedStyle.Render(fold)
+	return m.renderLinkedIssue(row.issue, indent, selected)
+}
+
+// renderLinkedIssue renders a related issue or sub-issue row with its
+// status, title, state and assignee
+func (m DetailModel) renderLinkedIssue(issue *linear.Issue, indent string, selected bool) string {
+	marker := "  "
+	idStyle := theme.IssueIDStyle
+	if selected {
+		marker = lipgloss.NewStyle().Foreground(theme.Primary).Render("▌ ")
+		idStyle = idStyle.Foreground(theme.Primary)
+	}
+
+	status, stateName := theme.StatusIcon(""), ""
+	if issue.State != nil {
+		status = theme.StatusIcon(issue.State.Type)
+		stateName = issue.State.Name
+	}
+	meta := stateName
+	if issue.Assignee != nil {
+		meta += " · " + issue.Assignee.Name
+	}
+
+	prefix := marker + indent + status + " " + idStyle.Render(issue.Identifier) + " "
+	suffix := theme.TextMutedStyle.Render("  " + meta)
+	titleWidth := m.width - 8 - lipgloss.Width(prefix) - lipgloss.Width(suffix)
+	return prefix + theme.TextStyle.Render(util.Truncate(issue.Title, titleWidth)) + suffix
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/brandonli/lazyliner/internal/linear"
+	"github.com/brandonli/lazyliner/internal/ui/markdown"
 	"github.com/brandonli/lazyliner/internal/ui/theme"
 	"github.com/brandonli/lazyliner/internal/util"
 	tea "github.com/charmbracelet/bubbletea"
@@ -304,6 +305,9 @@ func (m Model) renderCard(issue linear.Issue, isSelected bool) string {
 		"  ",
 		priorityIcon,
 	)
+	if progress := markdown.ProgressLabel(issue.Description); progress != "" {
+		line1 = lipgloss.JoinHorizontal(lipgloss.Top, line1, "  ", progress)
+	}
 
 	titleStyle := lipgloss.NewStyle().Foreground(theme.Text)
 	if isSelected {
@@ -10,6 +10,7 @@ import (
 type Model struct {
 	width  int
 	height int
+	err    string // Why the configured API key was rejected, if it was
 }
 
 // New creates a new setup model
@@ -24,6 +25,12 @@ func (m Model) SetSize(width, height int) Model {
 	return m
 }
 
+// SetError shows why the configured API key didn't work
+func (m Model) SetError(err string) Model {
+	m.err = err
+	return m
+}
+
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
@@ -35,6 +42,14 @@ func (m Model) View() string {
 	logo := theme.LogoStyle.Render("Welcome to Lazyliner")
 
 	subtitle := theme.TextMutedStyle.Render("A beautiful, keyboard-driven terminal TUI for Linear")
+	if m.err != "" {
+		subtitle = lipgloss.JoinVertical(
+			lipgloss.Center,
+			subtitle,
+			"",
+			theme.ErrorStyle.Render("⚠ "+m.err+" - check your API key"),
+		)
+	}
 
 	// Setup instructions
 	sections := []struct {